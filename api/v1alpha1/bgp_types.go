@@ -58,21 +58,23 @@ type BGPSpec struct {
 	Description string `json:"description,omitempty"`
 
 	// +kubebuilder:validation:Enum=enabled;disabled
-	State              string      `json:"state,omitempty"`
-	Multihop           BGPMultihop `json:"multihop,omitempty"`
-	BGPPassword        string      `json:"bgpPassword,omitempty"`
-	AllowAsIn          int         `json:"allowAsIn,omitempty"`
-	DefaultOriginate   bool        `json:"defaultOriginate,omitempty"`
-	PrefixInboundMax   int         `json:"prefixInboundMax,omitempty"`
-	InboundRouteMap    string      `json:"inboundRouteMap,omitempty"`
-	OutboundRouteMap   string      `json:"outboundRouteMap,omitempty"`
-	LocalPreference    int         `json:"localPreference,omitempty"`
-	Weight             int         `json:"weight,omitempty"`
-	PrependInbound     int         `json:"prependInbound,omitempty"`
-	PrependOutbound    int         `json:"prependOutbound,omitempty"`
-	PrefixListInbound  []string    `json:"prefixListInbound,omitempty"`
-	PrefixListOutbound []string    `json:"prefixListOutbound,omitempty"`
-	SendBGPCommunity   []string    `json:"sendBGPCommunity,omitempty"`
+	State       string      `json:"state,omitempty"`
+	Multihop    BGPMultihop `json:"multihop,omitempty"`
+	BGPPassword string      `json:"bgpPassword,omitempty"`
+	// +kubebuilder:validation:Enum=enabled;disabled
+	BFD                string   `json:"bfd,omitempty"`
+	AllowAsIn          int      `json:"allowAsIn,omitempty"`
+	DefaultOriginate   bool     `json:"defaultOriginate,omitempty"`
+	PrefixInboundMax   int      `json:"prefixInboundMax,omitempty"`
+	InboundRouteMap    string   `json:"inboundRouteMap,omitempty"`
+	OutboundRouteMap   string   `json:"outboundRouteMap,omitempty"`
+	LocalPreference    int      `json:"localPreference,omitempty"`
+	Weight             int      `json:"weight,omitempty"`
+	PrependInbound     int      `json:"prependInbound,omitempty"`
+	PrependOutbound    int      `json:"prependOutbound,omitempty"`
+	PrefixListInbound  []string `json:"prefixListInbound,omitempty"`
+	PrefixListOutbound []string `json:"prefixListOutbound,omitempty"`
+	SendBGPCommunity   []string `json:"sendBGPCommunity,omitempty"`
 }
 
 // BGPMultihop .
@@ -87,7 +89,7 @@ type BGPMultihop struct {
 
 // BGPTransport .
 type BGPTransport struct {
-	// +kubebuilder:validation:Enum=port;vnet
+	// +kubebuilder:validation:Enum=port;vnet;lag
 	Type string `json:"type,omitempty"`
 	// +kubebuilder:validation:Pattern=`(^[a-zA-Z0-9]+@[a-zA-Z0-9-]+$)|(^[a-zA-Z0-9]([-a-zA-Z0-9]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([-a-zA-Z0-9]*[a-zA-Z0-9])?)*$)`
 	Name   string `json:"name"`
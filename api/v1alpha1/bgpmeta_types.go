@@ -39,6 +39,7 @@ type BGPMetaSpec struct {
 	VnetID             int    `json:"vnet"`
 	Site               string `json:"site"`
 	BgpPassword        string `json:"bgp_password"`
+	Bfd                string `json:"bfd,omitempty"`
 	Community          string `json:"community"`
 	Description        string `json:"description"`
 	InboundRouteMap    int    `json:"inboundRouteMap"`
@@ -63,6 +64,8 @@ type BGPMetaSpec struct {
 	UpdateSource       string `json:"update_source"`
 	Vlan               int    `json:"vlan"`
 	Weight             int    `json:"weight"`
+
+	Tags []string `json:"tags,omitempty"`
 }
 
 // BGPMetaStatus defines the observed state of BGPMeta
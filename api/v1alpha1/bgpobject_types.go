@@ -0,0 +1,67 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BGPObjectSpec defines the desired state of BGPObject
+type BGPObjectSpec struct {
+	// Type is the BGP object type: prefix list, community list or AS-path list
+	// +kubebuilder:validation:Enum=ipv4;ipv6;community;large-community;expanded-community;aspath
+	Type string `json:"type"`
+	// Entries is the list of prefix-list, community-list or AS-path-list entries
+	// +kubebuilder:validation:MinItems=1
+	Entries []string `json:"entries"`
+}
+
+// BGPObjectStatus defines the observed state of BGPObject
+type BGPObjectStatus struct {
+	// Status is the provisioning status (OK, Failure)
+	Status string `json:"status,omitempty"`
+	// Message contains additional status information
+	Message string `json:"message,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// BGPObject is the Schema for the bgpobjects API
+type BGPObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BGPObjectSpec   `json:"spec,omitempty"`
+	Status BGPObjectStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// BGPObjectList contains a list of BGPObject
+type BGPObjectList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BGPObject `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BGPObject{}, &BGPObjectList{})
+}
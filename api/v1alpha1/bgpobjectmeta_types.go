@@ -0,0 +1,67 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BGPObjectMetaSpec defines the desired state of BGPObjectMeta
+type BGPObjectMetaSpec struct {
+	// Imported indicates if this resource was imported from existing Netris
+	Imported bool `json:"imported"`
+	// Reclaim indicates if the resource should be retained when the CR is deleted
+	Reclaim bool `json:"reclaimPolicy"`
+	// BGPObjectCRGeneration tracks the generation of the parent CR
+	BGPObjectCRGeneration int64 `json:"bgpObjectGeneration"`
+	// ID is the Netris API ID
+	ID int `json:"id"`
+	// BGPObjectName is the name of the parent CR
+	BGPObjectName string `json:"bgpObjectName"`
+	// Type is the BGP object type
+	Type string `json:"type"`
+	// TypeValue is the newline separated list of entries
+	TypeValue string `json:"typeValue"`
+}
+
+// BGPObjectMetaStatus defines the observed state of BGPObjectMeta
+type BGPObjectMetaStatus struct{}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// BGPObjectMeta is the Schema for the bgpobjectmeta API
+type BGPObjectMeta struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BGPObjectMetaSpec   `json:"spec,omitempty"`
+	Status BGPObjectMetaStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// BGPObjectMetaList contains a list of BGPObjectMeta
+type BGPObjectMetaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BGPObjectMeta `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BGPObjectMeta{}, &BGPObjectMetaList{})
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CalicoIntegrationSpec defines how the operator peers the Calico nodes with the Netris fabric
+type CalicoIntegrationSpec struct {
+	// Enabled turns the integration on. When turned off, the generated BGPs and peers are removed
+	// and the node-to-node mesh is restored.
+	Enabled bool `json:"enabled"`
+	// ASNRange is the range the node AS numbers are assigned from, e.g. "4230000000-4239999999".
+	// Defaults to the operator's calicoASNRange.
+	// +kubebuilder:validation:Pattern=`^[0-9]+-[0-9]+$`
+	ASNRange string `json:"asnRange,omitempty"`
+	// ASNReclaimAfter is how long the AS number of a removed node stays reserved
+	// for a node re-created with the same name, "24h" if not set
+	ASNReclaimAfter *metav1.Duration `json:"asnReclaimAfter,omitempty"`
+	// Namespace is the namespace of the generated BGPs, "default" if not set
+	Namespace string `json:"namespace,omitempty"`
+	// NodeSelector selects the nodes peering with the fabric. If not set, all nodes peer.
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+	// PrefixListPolicy controls the prefix lists of the generated BGPs
+	PrefixListPolicy CalicoPrefixListPolicy `json:"prefixListPolicy,omitempty"`
+	// BGP holds the session options of the generated BGPs
+	BGP CalicoBGPOptions `json:"bgp,omitempty"`
+	// MeshFallback decides what happens to the Calico node-to-node mesh:
+	// "auto" disables it once enough sessions are established and enables it back when they are not,
+	// "never" disables it once enough sessions are established and never enables it back,
+	// "keep" leaves it enabled, "manual" never touches it. Defaults to "auto".
+	// +kubebuilder:validation:Enum=auto;never;keep;manual
+	MeshFallback string `json:"meshFallback,omitempty"`
+	// MeshMinHealthyPercent is the share of established sessions required to run without the mesh, 100 if not set
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	MeshMinHealthyPercent int `json:"meshMinHealthyPercent,omitempty"`
+	// MeshDisableDelay is how long the sessions must stay healthy before the mesh is disabled, "60s" if not set
+	MeshDisableDelay *metav1.Duration `json:"meshDisableDelay,omitempty"`
+	// MeshEnableDelay is how long the sessions must stay unhealthy before the mesh is enabled back, "30s" if not set
+	MeshEnableDelay *metav1.Duration `json:"meshEnableDelay,omitempty"`
+	// RouteReflectors peers only the route reflector nodes with the fabric,
+	// the other nodes learn the routes from the route reflectors.
+	RouteReflectors *CalicoRouteReflectors `json:"routeReflectors,omitempty"`
+}
+
+// CalicoRouteReflectors selects the route reflector nodes
+type CalicoRouteReflectors struct {
+	// NodeSelector selects the route reflector nodes
+	NodeSelector metav1.LabelSelector `json:"nodeSelector"`
+	// ClusterID is the routeReflectorClusterID of the route reflector nodes, "244.0.0.1" if not set
+	// +kubebuilder:validation:Pattern=`^(([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\.){3}([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])$`
+	ClusterID string `json:"clusterID,omitempty"`
+}
+
+// CalicoPrefixListPolicy controls the prefix lists of the generated BGPs
+type CalicoPrefixListPolicy struct {
+	// DefaultRoute advertises the default route to the nodes, true if not set
+	DefaultRoute *bool `json:"defaultRoute,omitempty"`
+	// ServiceCIDRs accepts the Calico service CIDRs from the nodes, true if not set
+	ServiceCIDRs *bool `json:"serviceCIDRs,omitempty"`
+	// Inbound entries are appended to the inbound prefix list of every session, e.g. "permit 10.0.0.0/8 le 32"
+	Inbound []string `json:"inbound,omitempty"`
+	// Outbound entries are appended to the outbound prefix list of every session
+	Outbound []string `json:"outbound,omitempty"`
+}
+
+// CalicoBGPOptions holds the session options of the generated BGPs
+type CalicoBGPOptions struct {
+	// Password references the secret key holding the session password.
+	// It is set on both the Netris BGPs and the Calico BGPPeers.
+	Password *CalicoBGPPassword `json:"password,omitempty"`
+	// Multihop is the number of hops to the nodes, direct sessions if not set
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	Multihop int `json:"multihop,omitempty"`
+	// BFD enables BFD on the Netris side of the sessions
+	BFD bool `json:"bfd,omitempty"`
+}
+
+// CalicoBGPPassword references the secret key holding the BGP session password.
+// Calico reads the secret from the namespace calico-node is running in, so the secret must live there.
+type CalicoBGPPassword struct {
+	// Namespace of the secret, the namespace calico-node runs in: "calico-system" for operator
+	// installations, "kube-system" for manifest installations. "calico-system" if not set.
+	// +kubebuilder:validation:Enum=calico-system;kube-system
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Key       string `json:"key"`
+}
+
+// CalicoIntegrationStatus defines the observed state of CalicoIntegration
+type CalicoIntegrationStatus struct {
+	Status       string      `json:"status,omitempty"`
+	Message      string      `json:"message,omitempty"`
+	ModifiedDate metav1.Time `json:"modified,omitempty"`
+
+	// NodeToNodeMesh is the state of the Calico node-to-node mesh
+	NodeToNodeMesh string `json:"nodeToNodeMesh,omitempty"`
+	// Established is the number of established sessions out of all, e.g. "3/4"
+	Established string `json:"established,omitempty"`
+	// Sessions is the state of the BGP sessions of the nodes
+	Sessions []CalicoSessionStatus `json:"sessions,omitempty"`
+}
+
+// CalicoSessionStatus is the state of a single node session
+type CalicoSessionStatus struct {
+	Node     string `json:"node"`
+	BGP      string `json:"bgp"`
+	RemoteIP string `json:"remoteIP,omitempty"`
+	State    string `json:"state,omitempty"`
+	Prefixes int    `json:"prefixes,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Enabled",type=boolean,JSONPath=`.spec.enabled`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+//+kubebuilder:printcolumn:name="Established",type=string,JSONPath=`.status.established`
+//+kubebuilder:printcolumn:name="Mesh",type=string,JSONPath=`.status.nodeToNodeMesh`
+//+kubebuilder:printcolumn:name="Modified",type=date,JSONPath=`.status.modified`,priority=1
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// CalicoIntegration is the Schema for the calicointegrations API
+type CalicoIntegration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CalicoIntegrationSpec   `json:"spec,omitempty"`
+	Status CalicoIntegrationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CalicoIntegrationList contains a list of CalicoIntegration
+type CalicoIntegrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CalicoIntegration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CalicoIntegration{}, &CalicoIntegrationList{})
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterLoadBalancerPolicySpec defines the L4LB defaults of the Services selected by the policy in any namespace
+type ClusterLoadBalancerPolicySpec struct {
+	// NamespaceSelector selects the namespaces of the Services the policy applies to.
+	// If not set, the policy applies to Services in all namespaces.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	LoadBalancerPolicySpec `json:",inline"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster,shortName=clusterlbpolicy
+//+kubebuilder:printcolumn:name="Priority",type=integer,JSONPath=`.spec.priority`
+//+kubebuilder:printcolumn:name="Site",type=string,JSONPath=`.spec.site`
+//+kubebuilder:printcolumn:name="Tenant",type=string,JSONPath=`.spec.tenant`
+//+kubebuilder:printcolumn:name="VPC",type=string,JSONPath=`.spec.vpc`
+//+kubebuilder:printcolumn:name="Subnet",type=string,JSONPath=`.spec.frontendSubnet`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterLoadBalancerPolicy is the Schema for the clusterloadbalancerpolicies API
+type ClusterLoadBalancerPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterLoadBalancerPolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterLoadBalancerPolicyList contains a list of ClusterLoadBalancerPolicy
+type ClusterLoadBalancerPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterLoadBalancerPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterLoadBalancerPolicy{}, &ClusterLoadBalancerPolicyList{})
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DHCPOptionSetSpec defines the desired state of DHCPOptionSet
+type DHCPOptionSetSpec struct {
+	// Description of the option set
+	Description string `json:"description,omitempty"`
+	// DNSServers is the list of DNS server addresses (option 6)
+	DNSServers []string `json:"dnsServers,omitempty"`
+	// DomainSearch is the domain search list (option 119)
+	DomainSearch string `json:"domainSearch,omitempty"`
+	// NTPServers is the list of NTP server addresses (option 42)
+	NTPServers []string `json:"ntpServers,omitempty"`
+	// LeaseTime is the lease time in seconds
+	// +kubebuilder:validation:Minimum=60
+	LeaseTime int `json:"leaseTime,omitempty"`
+	// AdditionalOptions is the list of standard or custom DHCP options
+	AdditionalOptions []DHCPOption `json:"additionalOptions,omitempty"`
+}
+
+// DHCPOption is a single DHCP option
+type DHCPOption struct {
+	// Code is the DHCP option code
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=254
+	Code int `json:"code"`
+	// Type is the value type of the option
+	// +kubebuilder:validation:Enum=boolean;uint8;uint16;uint32;int8;int16;int32;ipv4-address;string;hex
+	Type string `json:"type"`
+	// Value of the option
+	Value string `json:"value"`
+	// Custom marks the option as a custom (non-standard) option
+	Custom bool `json:"custom,omitempty"`
+}
+
+// DHCPOptionSetStatus defines the observed state of DHCPOptionSet
+type DHCPOptionSetStatus struct {
+	// Status is the provisioning status (OK, Failure)
+	Status string `json:"status,omitempty"`
+	// Message contains additional status information
+	Message string `json:"message,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Domain",type=string,JSONPath=`.spec.domainSearch`
+//+kubebuilder:printcolumn:name="Lease Time",type=integer,JSONPath=`.spec.leaseTime`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DHCPOptionSet is the Schema for the dhcpoptionsets API
+type DHCPOptionSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DHCPOptionSetSpec   `json:"spec,omitempty"`
+	Status DHCPOptionSetStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DHCPOptionSetList contains a list of DHCPOptionSet
+type DHCPOptionSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DHCPOptionSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DHCPOptionSet{}, &DHCPOptionSetList{})
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DHCPOptionSetMetaSpec defines the desired state of DHCPOptionSetMeta
+type DHCPOptionSetMetaSpec struct {
+	// Imported indicates if this resource was imported from existing Netris
+	Imported bool `json:"imported"`
+	// Reclaim indicates if the resource should be retained when the CR is deleted
+	Reclaim bool `json:"reclaimPolicy"`
+	// DHCPOptionSetCRGeneration tracks the generation of the parent CR
+	DHCPOptionSetCRGeneration int64 `json:"dhcpOptionSetGeneration"`
+	// ID is the Netris API ID
+	ID int `json:"id"`
+	// DHCPOptionSetName is the name of the parent CR
+	DHCPOptionSetName string `json:"dhcpOptionSetName"`
+	// Description of the option set
+	Description string `json:"description,omitempty"`
+	// DNSServers is the list of DNS server addresses
+	DNSServers []string `json:"dnsServers"`
+	// DomainSearch is the domain search list
+	DomainSearch string `json:"domainSearch"`
+	// NTPServers is the list of NTP server addresses
+	NTPServers []string `json:"ntpServers"`
+	// LeaseTime is the lease time in seconds
+	LeaseTime int `json:"leaseTime"`
+	// AdditionalOptions is the list of standard or custom DHCP options
+	AdditionalOptions []DHCPOption `json:"additionalOptions"`
+}
+
+// DHCPOptionSetMetaStatus defines the observed state of DHCPOptionSetMeta
+type DHCPOptionSetMetaStatus struct{}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// DHCPOptionSetMeta is the Schema for the dhcpoptionsetmeta API
+type DHCPOptionSetMeta struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DHCPOptionSetMetaSpec   `json:"spec,omitempty"`
+	Status DHCPOptionSetMetaStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DHCPOptionSetMetaList contains a list of DHCPOptionSetMeta
+type DHCPOptionSetMetaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DHCPOptionSetMeta `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DHCPOptionSetMeta{}, &DHCPOptionSetMetaList{})
+}
@@ -25,7 +25,9 @@ type InventoryServerLink struct {
 	// Name of the server NIC (e.g., "eth0", "eth1")
 	Local string `json:"local"`
 	// Switch port in format "portName@switchName" (e.g., "swp1@leaf01")
-	Remote string `json:"remote"`
+	Remote string `json:"remote,omitempty"`
+	// Name of a LAG resource in the same namespace, used instead of Remote. Exactly one of Remote and LAG must be set
+	LAG string `json:"lag,omitempty"`
 }
 
 // InventoryServerSpec defines the desired state of InventoryServer
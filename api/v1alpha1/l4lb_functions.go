@@ -70,3 +70,19 @@ func (l *L4LB) SetServiceIngressIPs(s string) {
 	anns["serviceingressips"] = s
 	l.SetAnnotations(anns)
 }
+
+// GetSharedIPKey gets the frontend IP sharing key from annotations.
+func (l *L4LB) GetSharedIPKey() string {
+	return l.GetAnnotations()["sharedipkey"]
+}
+
+// SetSharedIPKey set frontend IP sharing key into annotations. An empty key removes it.
+func (l *L4LB) SetSharedIPKey(s string) {
+	anns := l.GetAnnotations()
+	if s == "" {
+		delete(anns, "sharedipkey")
+	} else {
+		anns["sharedipkey"] = s
+	}
+	l.SetAnnotations(anns)
+}
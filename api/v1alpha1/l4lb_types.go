@@ -33,6 +33,7 @@ type L4LBSpec struct {
 	Check       L4LBCheck `json:"check,omitempty"`
 	OwnerTenant string    `json:"ownerTenant,omitempty"`
 	Site        string    `json:"site,omitempty"`
+	VPC         string    `json:"vpc,omitempty"`
 
 	// +kubebuilder:validation:Enum=tcp;udp
 	Protocol string `json:"protocol,omitempty"`
@@ -49,8 +50,8 @@ type L4LBCheck struct {
 	RequestPath string `json:"requestPath,omitempty"`
 }
 
-// L4LBBackend .
-// +kubebuilder:validation:Pattern=`^(([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\.){3}([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5]):([1-9]|[1-9][0-9]{1,3}|[1-5][0-9]{4}|6[0-4][0-9]{3}|65[0-4][0-9]{2}|655[0-2][0-9]|6553[0-4])$`
+// L4LBBackend is an "ip:port" pair, IPv6 addresses are written in brackets: "[2001:db8::1]:443".
+// +kubebuilder:validation:Pattern=`^((([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\.){3}([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])|\[[0-9a-fA-F:.]+\]):([1-9]|[1-9][0-9]{1,3}|[1-5][0-9]{4}|6[0-4][0-9]{3}|65[0-4][0-9]{2}|655[0-2][0-9]|6553[0-4])$`
 type L4LBBackend string
 
 // L4LBFrontend .
@@ -59,7 +60,7 @@ type L4LBFrontend struct {
 	// +kubebuilder:validation:Maximum=65534
 	Port int `json:"port"`
 
-	// +kubebuilder:validation:Pattern=`^(([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\.){3}([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])$`
+	// +kubebuilder:validation:Pattern=`^((([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\.){3}([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])|[0-9a-fA-F]*:[0-9a-fA-F:.]+)$`
 	IP string `json:"ip,omitempty"`
 }
 
@@ -72,6 +73,30 @@ type L4LBStatus struct { // INSERT ADDITIONAL STATUS FIELD - define observed sta
 	Message      string      `json:"message,omitempty"`
 	ModifiedDate metav1.Time `json:"modified,omitempty"`
 	Port         string      `json:"port,omitempty"`
+
+	// Backends is the health of the backends reported by Netris.
+	// It is never omitted, so that the status merge patch clears the removed backends.
+	// +optional
+	Backends []L4LBBackendStatus `json:"backends"`
+	// BackendsHealthy is the number of healthy backends out of all, e.g. "2/3"
+	BackendsHealthy string `json:"backendsHealthy,omitempty"`
+}
+
+// Backend health values of the L4LB status
+const (
+	L4LBBackendHealthy   = "Healthy"
+	L4LBBackendUnhealthy = "Unhealthy"
+	L4LBBackendUnknown   = "Unknown"
+)
+
+// L4LBBackendStatus is the health of a single backend
+type L4LBBackendStatus struct {
+	Backend L4LBBackend `json:"backend"`
+	// Health is Healthy or Unhealthy as reported by the Netris health check, Unknown otherwise
+	// +kubebuilder:validation:Enum=Healthy;Unhealthy;Unknown
+	Health   string `json:"health"`
+	Status   string `json:"status,omitempty"`
+	Response string `json:"response,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -82,6 +107,7 @@ type L4LBStatus struct { // INSERT ADDITIONAL STATUS FIELD - define observed sta
 // +kubebuilder:printcolumn:name="Site",type=string,JSONPath=".spec.site"
 // +kubebuilder:printcolumn:name="Tenant",type=string,JSONPath=`.spec.ownerTenant`
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+// +kubebuilder:printcolumn:name="Healthy",type=string,JSONPath=`.status.backendsHealthy`
 // +kubebuilder:printcolumn:name="Modified",type=date,JSONPath=`.status.modified`,priority=1
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
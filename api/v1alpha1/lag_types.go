@@ -0,0 +1,88 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LAGSpec defines the desired state of LAG
+type LAGSpec struct {
+	// Tenant is the tenant that owns the member ports
+	// +kubebuilder:validation:Required
+	Tenant string `json:"tenant"`
+	// Description of the aggregated port
+	Description string `json:"description,omitempty"`
+	// Ports is the list of member ports in format "portName@switchName".
+	// Ports may span the two switches of an MC-LAG pair.
+	// +kubebuilder:validation:MinItems=1
+	Ports []LAGPort `json:"ports"`
+	// LACP enables or disables LACP on the aggregated port
+	// +kubebuilder:validation:Enum=on;off
+	LACP string `json:"lacp,omitempty"`
+	// MTU of the aggregated port
+	// +kubebuilder:validation:Minimum=68
+	// +kubebuilder:validation:Maximum=9216
+	MTU int `json:"mtu,omitempty"`
+	// MCLAGID is the MC-LAG ID, required when ports belong to different switches
+	// +kubebuilder:validation:Minimum=1
+	MCLAGID *int `json:"mclagId,omitempty"`
+}
+
+// LAGPort is a switch port in format "portName@switchName"
+// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9]+@[a-zA-Z0-9-]+$`
+type LAGPort string
+
+// LAGStatus defines the observed state of LAG
+type LAGStatus struct {
+	// Status is the provisioning status (OK, Failure)
+	Status string `json:"status,omitempty"`
+	// Message contains additional status information
+	Message string `json:"message,omitempty"`
+	// AggregatedPort is the name of the aggregated port in Netris
+	AggregatedPort string `json:"aggregatedPort,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Tenant",type=string,JSONPath=`.spec.tenant`
+//+kubebuilder:printcolumn:name="LACP",type=string,JSONPath=`.spec.lacp`
+//+kubebuilder:printcolumn:name="Aggregated Port",type=string,JSONPath=`.status.aggregatedPort`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// LAG is the Schema for the lags API
+type LAG struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LAGSpec   `json:"spec,omitempty"`
+	Status LAGStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// LAGList contains a list of LAG
+type LAGList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LAG `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LAG{}, &LAGList{})
+}
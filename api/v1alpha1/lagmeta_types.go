@@ -0,0 +1,85 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LAGMetaPort represents a resolved member port of the LAG
+type LAGMetaPort struct {
+	// ID is the resolved port ID
+	ID int `json:"id"`
+	// Name is the port name in format "portName@switchName"
+	Name string `json:"name"`
+}
+
+// LAGMetaSpec defines the desired state of LAGMeta
+type LAGMetaSpec struct {
+	// Imported indicates if this resource was imported from existing Netris
+	Imported bool `json:"imported"`
+	// Reclaim indicates if the resource should be retained when the CR is deleted
+	Reclaim bool `json:"reclaimPolicy"`
+	// LAGCRGeneration tracks the generation of the parent CR
+	LAGCRGeneration int64 `json:"lagGeneration"`
+	// ID is the Netris API ID of the aggregated port
+	ID int `json:"id"`
+	// LAGName is the name of the parent CR
+	LAGName string `json:"lagName"`
+	// TenantID is the resolved tenant ID
+	TenantID int `json:"tenantId"`
+	// TenantName is the tenant name
+	TenantName string `json:"tenantName"`
+	// Description of the aggregated port
+	Description string `json:"description,omitempty"`
+	// Ports is the list of member ports with resolved IDs
+	Ports []LAGMetaPort `json:"ports"`
+	// LACP is the LACP mode (on, off)
+	LACP string `json:"lacp"`
+	// MTU of the aggregated port
+	MTU int `json:"mtu"`
+	// MCLAGID is the MC-LAG ID
+	MCLAGID *int `json:"mclagId,omitempty"`
+}
+
+// LAGMetaStatus defines the observed state of LAGMeta
+type LAGMetaStatus struct{}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// LAGMeta is the Schema for the lagsmeta API
+type LAGMeta struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LAGMetaSpec   `json:"spec,omitempty"`
+	Status LAGMetaStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// LAGMetaList contains a list of LAGMeta
+type LAGMetaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LAGMeta `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LAGMeta{}, &LAGMetaList{})
+}
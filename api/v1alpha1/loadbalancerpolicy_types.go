@@ -0,0 +1,72 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LoadBalancerPolicySpec defines the L4LB defaults of the Services selected by the policy.
+// A LoadBalancerPolicy applies to the Services in its own namespace only.
+type LoadBalancerPolicySpec struct {
+	// ServiceSelector selects the Services by labels. If not set, all Services are selected.
+	ServiceSelector *metav1.LabelSelector `json:"serviceSelector,omitempty"`
+	// Priority decides between several policies selecting the same Service, the highest wins
+	Priority int `json:"priority,omitempty"`
+
+	// Site is the default site of the L4LBs
+	Site string `json:"site,omitempty"`
+	// Tenant is the default owner tenant of the L4LBs
+	Tenant string `json:"tenant,omitempty"`
+	// VPC is the default VPC name of the L4LBs
+	VPC string `json:"vpc,omitempty"`
+	// Check is the default health check of the L4LBs
+	Check L4LBCheck `json:"check,omitempty"`
+	// FrontendSubnet is the subnet the frontend IPs of the L4LBs must belong to
+	// +kubebuilder:validation:Pattern=`^(([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\.){3}([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\/([0-9]|[12][0-9]|3[0-2])$|^[0-9a-fA-F]*:[0-9a-fA-F:.]+\/([0-9]|[1-9][0-9]|1[01][0-9]|12[0-8])$`
+	FrontendSubnet string `json:"frontendSubnet,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=lbpolicy
+//+kubebuilder:printcolumn:name="Priority",type=integer,JSONPath=`.spec.priority`
+//+kubebuilder:printcolumn:name="Site",type=string,JSONPath=`.spec.site`
+//+kubebuilder:printcolumn:name="Tenant",type=string,JSONPath=`.spec.tenant`
+//+kubebuilder:printcolumn:name="VPC",type=string,JSONPath=`.spec.vpc`
+//+kubebuilder:printcolumn:name="Subnet",type=string,JSONPath=`.spec.frontendSubnet`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// LoadBalancerPolicy is the Schema for the loadbalancerpolicies API
+type LoadBalancerPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec LoadBalancerPolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// LoadBalancerPolicyList contains a list of LoadBalancerPolicy
+type LoadBalancerPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LoadBalancerPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LoadBalancerPolicy{}, &LoadBalancerPolicyList{})
+}
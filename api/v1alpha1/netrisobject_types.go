@@ -0,0 +1,72 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NetrisObjectSpec defines the desired state of NetrisObject
+type NetrisObjectSpec struct {
+	// Path is the Netris API collection path (e.g. "/api/v2/vnet").
+	// Objects are created with POST to the path, updated with PUT and deleted with DELETE to "<path>/<id>".
+	// +kubebuilder:validation:Pattern=`^/api/[a-zA-Z0-9/_-]+$`
+	Path string `json:"path"`
+	// IdentityKey is the body field used to find the object in the collection (defaults to "name")
+	IdentityKey string `json:"identityKey,omitempty"`
+	// Body is the JSON object sent to the API
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Body runtime.RawExtension `json:"body"`
+}
+
+// NetrisObjectStatus defines the observed state of NetrisObject
+type NetrisObjectStatus struct {
+	// Status is the provisioning status (OK, Failure)
+	Status string `json:"status,omitempty"`
+	// Message contains additional status information
+	Message string `json:"message,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Path",type=string,JSONPath=`.spec.path`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NetrisObject is the Schema for the netrisobjects API
+type NetrisObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NetrisObjectSpec   `json:"spec,omitempty"`
+	Status NetrisObjectStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NetrisObjectList contains a list of NetrisObject
+type NetrisObjectList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NetrisObject `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NetrisObject{}, &NetrisObjectList{})
+}
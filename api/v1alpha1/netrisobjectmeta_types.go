@@ -0,0 +1,72 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NetrisObjectMetaSpec defines the desired state of NetrisObjectMeta
+type NetrisObjectMetaSpec struct {
+	// Imported indicates if this resource was imported from existing Netris
+	Imported bool `json:"imported"`
+	// Reclaim indicates if the resource should be retained when the CR is deleted
+	Reclaim bool `json:"reclaimPolicy"`
+	// NetrisObjectCRGeneration tracks the generation of the parent CR
+	NetrisObjectCRGeneration int64 `json:"netrisObjectGeneration"`
+	// ID is the Netris API ID
+	ID int `json:"id"`
+	// NetrisObjectName is the name of the parent CR
+	NetrisObjectName string `json:"netrisObjectName"`
+	// Path is the Netris API collection path
+	Path string `json:"path"`
+	// IdentityKey is the body field used to find the object in the collection
+	IdentityKey string `json:"identityKey"`
+	// Body is the JSON object sent to the API
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Body runtime.RawExtension `json:"body"`
+}
+
+// NetrisObjectMetaStatus defines the observed state of NetrisObjectMeta
+type NetrisObjectMetaStatus struct{}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// NetrisObjectMeta is the Schema for the netrisobjectmeta API
+type NetrisObjectMeta struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NetrisObjectMetaSpec   `json:"spec,omitempty"`
+	Status NetrisObjectMetaStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NetrisObjectMetaList contains a list of NetrisObjectMeta
+type NetrisObjectMetaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NetrisObjectMeta `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NetrisObjectMeta{}, &NetrisObjectMetaList{})
+}
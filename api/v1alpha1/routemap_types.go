@@ -0,0 +1,101 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RouteMapSpec defines the desired state of RouteMap
+type RouteMapSpec struct {
+	// Sequences is the ordered list of route-map entries
+	// +kubebuilder:validation:MinItems=1
+	Sequences []RouteMapSequence `json:"sequences"`
+}
+
+// RouteMapSequence is a single route-map entry
+type RouteMapSequence struct {
+	// Number is the sequence number
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Number int `json:"number"`
+	// Policy is the sequence policy
+	// +kubebuilder:validation:Enum=permit;deny
+	Policy string `json:"policy"`
+	// Description of the sequence
+	Description string `json:"description,omitempty"`
+	// Matches is the list of match clauses
+	Matches []RouteMapMatch `json:"matches,omitempty"`
+	// Actions is the list of set clauses
+	Actions []RouteMapAction `json:"actions,omitempty"`
+}
+
+// RouteMapMatch is a route-map match clause
+type RouteMapMatch struct {
+	// Type is the match type
+	// +kubebuilder:validation:Enum=as_path;community;extended_community;large_community;ipv4_prefix_list;ipv6_prefix_list;ipv4_next_hop;ipv6_next_hop;route_source
+	Type string `json:"type"`
+	// BGPObject is the name of the BGPObject to match against
+	BGPObject string `json:"bgpObject,omitempty"`
+	// Value is used for match types that do not reference a BGPObject
+	Value string `json:"value,omitempty"`
+}
+
+// RouteMapAction is a route-map set clause
+type RouteMapAction struct {
+	// Type is the action type (e.g. set, add, remove)
+	Type string `json:"type"`
+	// Parameter is the attribute the action applies to (e.g. local_preference, community, as_path)
+	Parameter string `json:"parameter"`
+	// Value of the action
+	Value string `json:"value"`
+}
+
+// RouteMapStatus defines the observed state of RouteMap
+type RouteMapStatus struct {
+	// Status is the provisioning status (OK, Failure)
+	Status string `json:"status,omitempty"`
+	// Message contains additional status information
+	Message string `json:"message,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// RouteMap is the Schema for the routemaps API
+type RouteMap struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RouteMapSpec   `json:"spec,omitempty"`
+	Status RouteMapStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RouteMapList contains a list of RouteMap
+type RouteMapList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RouteMap `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RouteMap{}, &RouteMapList{})
+}
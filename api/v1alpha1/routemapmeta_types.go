@@ -0,0 +1,91 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RouteMapMetaSequence is a route-map entry with resolved BGP object IDs
+type RouteMapMetaSequence struct {
+	// Number is the sequence number
+	Number int `json:"number"`
+	// Policy is the sequence policy
+	Policy string `json:"policy"`
+	// Description of the sequence
+	Description string `json:"description,omitempty"`
+	// Matches is the list of match clauses
+	Matches []RouteMapMetaMatch `json:"matches"`
+	// Actions is the list of set clauses
+	Actions []RouteMapAction `json:"actions"`
+}
+
+// RouteMapMetaMatch is a match clause with resolved BGP object ID
+type RouteMapMetaMatch struct {
+	// Type is the match type
+	Type string `json:"type"`
+	// BGPObjectID is the resolved BGP object ID
+	BGPObjectID int `json:"bgpObjectId,omitempty"`
+	// BGPObjectType is the type of the referenced BGP object
+	BGPObjectType string `json:"bgpObjectType,omitempty"`
+	// Value is used for match types that do not reference a BGPObject
+	Value string `json:"value,omitempty"`
+}
+
+// RouteMapMetaSpec defines the desired state of RouteMapMeta
+type RouteMapMetaSpec struct {
+	// Imported indicates if this resource was imported from existing Netris
+	Imported bool `json:"imported"`
+	// Reclaim indicates if the resource should be retained when the CR is deleted
+	Reclaim bool `json:"reclaimPolicy"`
+	// RouteMapCRGeneration tracks the generation of the parent CR
+	RouteMapCRGeneration int64 `json:"routeMapGeneration"`
+	// ID is the Netris API ID
+	ID int `json:"id"`
+	// RouteMapName is the name of the parent CR
+	RouteMapName string `json:"routeMapName"`
+	// Sequences is the ordered list of route-map entries
+	Sequences []RouteMapMetaSequence `json:"sequences"`
+}
+
+// RouteMapMetaStatus defines the observed state of RouteMapMeta
+type RouteMapMetaStatus struct{}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// RouteMapMeta is the Schema for the routemapmeta API
+type RouteMapMeta struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RouteMapMetaSpec   `json:"spec,omitempty"`
+	Status RouteMapMetaStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RouteMapMetaList contains a list of RouteMapMeta
+type RouteMapMetaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RouteMapMeta `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RouteMapMeta{}, &RouteMapMetaList{})
+}
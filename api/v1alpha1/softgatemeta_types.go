@@ -40,6 +40,8 @@ type SoftgateMetaSpec struct {
 	ProfileID   int    `json:"profileid,omitempty"`
 	MainIP      string `json:"mainIp,omitempty"`
 	MgmtIP      string `json:"mgmtIp,omitempty"`
+
+	Tags []string `json:"tags,omitempty"`
 }
 
 // SoftgateMetaStatus defines the observed state of SoftgateMeta
@@ -44,6 +44,7 @@ type SwitchMetaSpec struct {
 	MgmtIP      string        `json:"mgmtIp,omitempty"`
 	PortsCount  int           `json:"portsCount,omitempty"`
 	MacAddress  string        `json:"macAddress,omitempty"`
+	Tags        []string      `json:"tags,omitempty"`
 }
 
 // SwitchMetaStatus defines the observed state of SwitchMeta
@@ -112,7 +112,10 @@ type VNetGateway struct {
 // VNetSwitchPort .
 type VNetSwitchPort struct {
 	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9]+@[a-zA-Z0-9-]+$`
-	Name string `json:"name"`
+	Name string `json:"name,omitempty"`
+
+	// LAG is the name of a LAG resource in the same namespace, used instead of Name. Exactly one of Name and LAG must be set
+	LAG string `json:"lag,omitempty"`
 
 	// +kubebuilder:validation:Minimum=2
 	// +kubebuilder:validation:Maximum=4094
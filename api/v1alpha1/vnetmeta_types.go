@@ -42,6 +42,7 @@ type VNetMetaSpec struct {
 	VaNativeVLAN     int               `json:"vaNativeVlan"`
 	VaVLANs          string            `json:"vaVlans"`
 	VlanID           string            `json:"vlanid"`
+	Tags             []string          `json:"tags,omitempty"`
 }
 
 // VNetMetaSite .
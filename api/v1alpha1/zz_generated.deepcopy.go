@@ -24,7 +24,8 @@ package v1alpha1
 import (
 	"github.com/netrisai/netriswebapi/v2/types/inventory"
 	"github.com/netrisai/netriswebapi/v2/types/servercluster"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -269,7 +270,7 @@ func (in *BGPMeta) DeepCopyInto(out *BGPMeta) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
 
@@ -326,6 +327,11 @@ func (in *BGPMetaList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BGPMetaSpec) DeepCopyInto(out *BGPMetaSpec) {
 	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPMetaSpec.
@@ -369,89 +375,26 @@ func (in *BGPMultihop) DeepCopy() *BGPMultihop {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BGPSpec) DeepCopyInto(out *BGPSpec) {
-	*out = *in
-	out.Transport = in.Transport
-	out.Multihop = in.Multihop
-	if in.PrefixListInbound != nil {
-		in, out := &in.PrefixListInbound, &out.PrefixListInbound
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.PrefixListOutbound != nil {
-		in, out := &in.PrefixListOutbound, &out.PrefixListOutbound
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.SendBGPCommunity != nil {
-		in, out := &in.SendBGPCommunity, &out.SendBGPCommunity
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPSpec.
-func (in *BGPSpec) DeepCopy() *BGPSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(BGPSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BGPStatus) DeepCopyInto(out *BGPStatus) {
-	*out = *in
-	in.ModifiedDate.DeepCopyInto(&out.ModifiedDate)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPStatus.
-func (in *BGPStatus) DeepCopy() *BGPStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(BGPStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BGPTransport) DeepCopyInto(out *BGPTransport) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPTransport.
-func (in *BGPTransport) DeepCopy() *BGPTransport {
-	if in == nil {
-		return nil
-	}
-	out := new(BGPTransport)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Controller) DeepCopyInto(out *Controller) {
+func (in *BGPObject) DeepCopyInto(out *BGPObject) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Controller.
-func (in *Controller) DeepCopy() *Controller {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPObject.
+func (in *BGPObject) DeepCopy() *BGPObject {
 	if in == nil {
 		return nil
 	}
-	out := new(Controller)
+	out := new(BGPObject)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Controller) DeepCopyObject() runtime.Object {
+func (in *BGPObject) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -459,31 +402,31 @@ func (in *Controller) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllerList) DeepCopyInto(out *ControllerList) {
+func (in *BGPObjectList) DeepCopyInto(out *BGPObjectList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Controller, len(*in))
+		*out = make([]BGPObject, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerList.
-func (in *ControllerList) DeepCopy() *ControllerList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPObjectList.
+func (in *BGPObjectList) DeepCopy() *BGPObjectList {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllerList)
+	out := new(BGPObjectList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ControllerList) DeepCopyObject() runtime.Object {
+func (in *BGPObjectList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -491,7 +434,7 @@ func (in *ControllerList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllerMeta) DeepCopyInto(out *ControllerMeta) {
+func (in *BGPObjectMeta) DeepCopyInto(out *BGPObjectMeta) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -499,18 +442,18 @@ func (in *ControllerMeta) DeepCopyInto(out *ControllerMeta) {
 	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerMeta.
-func (in *ControllerMeta) DeepCopy() *ControllerMeta {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPObjectMeta.
+func (in *BGPObjectMeta) DeepCopy() *BGPObjectMeta {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllerMeta)
+	out := new(BGPObjectMeta)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ControllerMeta) DeepCopyObject() runtime.Object {
+func (in *BGPObjectMeta) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -518,31 +461,31 @@ func (in *ControllerMeta) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllerMetaList) DeepCopyInto(out *ControllerMetaList) {
+func (in *BGPObjectMetaList) DeepCopyInto(out *BGPObjectMetaList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ControllerMeta, len(*in))
+		*out = make([]BGPObjectMeta, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerMetaList.
-func (in *ControllerMetaList) DeepCopy() *ControllerMetaList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPObjectMetaList.
+func (in *BGPObjectMetaList) DeepCopy() *BGPObjectMetaList {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllerMetaList)
+	out := new(BGPObjectMetaList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ControllerMetaList) DeepCopyObject() runtime.Object {
+func (in *BGPObjectMetaList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -550,160 +493,189 @@ func (in *ControllerMetaList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllerMetaSpec) DeepCopyInto(out *ControllerMetaSpec) {
+func (in *BGPObjectMetaSpec) DeepCopyInto(out *BGPObjectMetaSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerMetaSpec.
-func (in *ControllerMetaSpec) DeepCopy() *ControllerMetaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPObjectMetaSpec.
+func (in *BGPObjectMetaSpec) DeepCopy() *BGPObjectMetaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllerMetaSpec)
+	out := new(BGPObjectMetaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllerMetaStatus) DeepCopyInto(out *ControllerMetaStatus) {
+func (in *BGPObjectMetaStatus) DeepCopyInto(out *BGPObjectMetaStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerMetaStatus.
-func (in *ControllerMetaStatus) DeepCopy() *ControllerMetaStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPObjectMetaStatus.
+func (in *BGPObjectMetaStatus) DeepCopy() *BGPObjectMetaStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllerMetaStatus)
+	out := new(BGPObjectMetaStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllerSpec) DeepCopyInto(out *ControllerSpec) {
+func (in *BGPObjectSpec) DeepCopyInto(out *BGPObjectSpec) {
 	*out = *in
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerSpec.
-func (in *ControllerSpec) DeepCopy() *ControllerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPObjectSpec.
+func (in *BGPObjectSpec) DeepCopy() *BGPObjectSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllerSpec)
+	out := new(BGPObjectSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllerStatus) DeepCopyInto(out *ControllerStatus) {
+func (in *BGPObjectStatus) DeepCopyInto(out *BGPObjectStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerStatus.
-func (in *ControllerStatus) DeepCopy() *ControllerStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPObjectStatus.
+func (in *BGPObjectStatus) DeepCopy() *BGPObjectStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllerStatus)
+	out := new(BGPObjectStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InventoryProfile) DeepCopyInto(out *InventoryProfile) {
+func (in *BGPSpec) DeepCopyInto(out *BGPSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	out.Transport = in.Transport
+	out.Multihop = in.Multihop
+	if in.PrefixListInbound != nil {
+		in, out := &in.PrefixListInbound, &out.PrefixListInbound
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrefixListOutbound != nil {
+		in, out := &in.PrefixListOutbound, &out.PrefixListOutbound
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SendBGPCommunity != nil {
+		in, out := &in.SendBGPCommunity, &out.SendBGPCommunity
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryProfile.
-func (in *InventoryProfile) DeepCopy() *InventoryProfile {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPSpec.
+func (in *BGPSpec) DeepCopy() *BGPSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InventoryProfile)
+	out := new(BGPSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *InventoryProfile) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPStatus) DeepCopyInto(out *BGPStatus) {
+	*out = *in
+	in.ModifiedDate.DeepCopyInto(&out.ModifiedDate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPStatus.
+func (in *BGPStatus) DeepCopy() *BGPStatus {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(BGPStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InventoryProfileCustomRule) DeepCopyInto(out *InventoryProfileCustomRule) {
+func (in *BGPTransport) DeepCopyInto(out *BGPTransport) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryProfileCustomRule.
-func (in *InventoryProfileCustomRule) DeepCopy() *InventoryProfileCustomRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPTransport.
+func (in *BGPTransport) DeepCopy() *BGPTransport {
 	if in == nil {
 		return nil
 	}
-	out := new(InventoryProfileCustomRule)
+	out := new(BGPTransport)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InventoryProfileList) DeepCopyInto(out *InventoryProfileList) {
+func (in *CalicoBGPOptions) DeepCopyInto(out *CalicoBGPOptions) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]InventoryProfile, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(CalicoBGPPassword)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryProfileList.
-func (in *InventoryProfileList) DeepCopy() *InventoryProfileList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoBGPOptions.
+func (in *CalicoBGPOptions) DeepCopy() *CalicoBGPOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(InventoryProfileList)
+	out := new(CalicoBGPOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *InventoryProfileList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CalicoBGPPassword) DeepCopyInto(out *CalicoBGPPassword) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoBGPPassword.
+func (in *CalicoBGPPassword) DeepCopy() *CalicoBGPPassword {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(CalicoBGPPassword)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InventoryProfileMeta) DeepCopyInto(out *InventoryProfileMeta) {
+func (in *CalicoIntegration) DeepCopyInto(out *CalicoIntegration) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryProfileMeta.
-func (in *InventoryProfileMeta) DeepCopy() *InventoryProfileMeta {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoIntegration.
+func (in *CalicoIntegration) DeepCopy() *CalicoIntegration {
 	if in == nil {
 		return nil
 	}
-	out := new(InventoryProfileMeta)
+	out := new(CalicoIntegration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *InventoryProfileMeta) DeepCopyObject() runtime.Object {
+func (in *CalicoIntegration) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -711,31 +683,31 @@ func (in *InventoryProfileMeta) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InventoryProfileMetaList) DeepCopyInto(out *InventoryProfileMetaList) {
+func (in *CalicoIntegrationList) DeepCopyInto(out *CalicoIntegrationList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]InventoryProfileMeta, len(*in))
+		*out = make([]CalicoIntegration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryProfileMetaList.
-func (in *InventoryProfileMetaList) DeepCopy() *InventoryProfileMetaList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoIntegrationList.
+func (in *CalicoIntegrationList) DeepCopy() *CalicoIntegrationList {
 	if in == nil {
 		return nil
 	}
-	out := new(InventoryProfileMetaList)
+	out := new(CalicoIntegrationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *InventoryProfileMetaList) DeepCopyObject() runtime.Object {
+func (in *CalicoIntegrationList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -743,183 +715,186 @@ func (in *InventoryProfileMetaList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InventoryProfileMetaSpec) DeepCopyInto(out *InventoryProfileMetaSpec) {
+func (in *CalicoIntegrationSpec) DeepCopyInto(out *CalicoIntegrationSpec) {
 	*out = *in
-	if in.AllowSSHFromIPv4 != nil {
-		in, out := &in.AllowSSHFromIPv4, &out.AllowSSHFromIPv4
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.ASNReclaimAfter != nil {
+		in, out := &in.ASNReclaimAfter, &out.ASNReclaimAfter
+		*out = new(v1.Duration)
+		**out = **in
 	}
-	if in.AllowSSHFromIPv6 != nil {
-		in, out := &in.AllowSSHFromIPv6, &out.AllowSSHFromIPv6
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.NTPServers != nil {
-		in, out := &in.NTPServers, &out.NTPServers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	in.PrefixListPolicy.DeepCopyInto(&out.PrefixListPolicy)
+	in.BGP.DeepCopyInto(&out.BGP)
+	if in.MeshDisableDelay != nil {
+		in, out := &in.MeshDisableDelay, &out.MeshDisableDelay
+		*out = new(v1.Duration)
+		**out = **in
 	}
-	if in.DNSServers != nil {
-		in, out := &in.DNSServers, &out.DNSServers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.MeshEnableDelay != nil {
+		in, out := &in.MeshEnableDelay, &out.MeshEnableDelay
+		*out = new(v1.Duration)
+		**out = **in
 	}
-	if in.CustomRules != nil {
-		in, out := &in.CustomRules, &out.CustomRules
-		*out = make([]InventoryProfileCustomRule, len(*in))
-		copy(*out, *in)
+	if in.RouteReflectors != nil {
+		in, out := &in.RouteReflectors, &out.RouteReflectors
+		*out = new(CalicoRouteReflectors)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryProfileMetaSpec.
-func (in *InventoryProfileMetaSpec) DeepCopy() *InventoryProfileMetaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoIntegrationSpec.
+func (in *CalicoIntegrationSpec) DeepCopy() *CalicoIntegrationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InventoryProfileMetaSpec)
+	out := new(CalicoIntegrationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InventoryProfileMetaStatus) DeepCopyInto(out *InventoryProfileMetaStatus) {
+func (in *CalicoIntegrationStatus) DeepCopyInto(out *CalicoIntegrationStatus) {
 	*out = *in
+	in.ModifiedDate.DeepCopyInto(&out.ModifiedDate)
+	if in.Sessions != nil {
+		in, out := &in.Sessions, &out.Sessions
+		*out = make([]CalicoSessionStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryProfileMetaStatus.
-func (in *InventoryProfileMetaStatus) DeepCopy() *InventoryProfileMetaStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoIntegrationStatus.
+func (in *CalicoIntegrationStatus) DeepCopy() *CalicoIntegrationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(InventoryProfileMetaStatus)
+	out := new(CalicoIntegrationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InventoryProfileSpec) DeepCopyInto(out *InventoryProfileSpec) {
+func (in *CalicoPrefixListPolicy) DeepCopyInto(out *CalicoPrefixListPolicy) {
 	*out = *in
-	if in.AllowSSHFromIPv4 != nil {
-		in, out := &in.AllowSSHFromIPv4, &out.AllowSSHFromIPv4
+	if in.DefaultRoute != nil {
+		in, out := &in.DefaultRoute, &out.DefaultRoute
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ServiceCIDRs != nil {
+		in, out := &in.ServiceCIDRs, &out.ServiceCIDRs
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Inbound != nil {
+		in, out := &in.Inbound, &out.Inbound
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.AllowSSHFromIPv6 != nil {
-		in, out := &in.AllowSSHFromIPv6, &out.AllowSSHFromIPv6
+	if in.Outbound != nil {
+		in, out := &in.Outbound, &out.Outbound
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.NTPServers != nil {
-		in, out := &in.NTPServers, &out.NTPServers
-		*out = make([]NTPServer, len(*in))
-		copy(*out, *in)
-	}
-	if in.DNSServers != nil {
-		in, out := &in.DNSServers, &out.DNSServers
-		*out = make([]DNSServer, len(*in))
-		copy(*out, *in)
-	}
-	if in.CustomRules != nil {
-		in, out := &in.CustomRules, &out.CustomRules
-		*out = make([]InventoryProfileCustomRule, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryProfileSpec.
-func (in *InventoryProfileSpec) DeepCopy() *InventoryProfileSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoPrefixListPolicy.
+func (in *CalicoPrefixListPolicy) DeepCopy() *CalicoPrefixListPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(InventoryProfileSpec)
+	out := new(CalicoPrefixListPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InventoryProfileStatus) DeepCopyInto(out *InventoryProfileStatus) {
+func (in *CalicoRouteReflectors) DeepCopyInto(out *CalicoRouteReflectors) {
 	*out = *in
+	in.NodeSelector.DeepCopyInto(&out.NodeSelector)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryProfileStatus.
-func (in *InventoryProfileStatus) DeepCopy() *InventoryProfileStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoRouteReflectors.
+func (in *CalicoRouteReflectors) DeepCopy() *CalicoRouteReflectors {
 	if in == nil {
 		return nil
 	}
-	out := new(InventoryProfileStatus)
+	out := new(CalicoRouteReflectors)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InventoryServer) DeepCopyInto(out *InventoryServer) {
+func (in *CalicoSessionStatus) DeepCopyInto(out *CalicoSessionStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryServer.
-func (in *InventoryServer) DeepCopy() *InventoryServer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoSessionStatus.
+func (in *CalicoSessionStatus) DeepCopy() *CalicoSessionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(InventoryServer)
+	out := new(CalicoSessionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *InventoryServer) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InventoryServerLink) DeepCopyInto(out *InventoryServerLink) {
+func (in *ClusterLoadBalancerPolicy) DeepCopyInto(out *ClusterLoadBalancerPolicy) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryServerLink.
-func (in *InventoryServerLink) DeepCopy() *InventoryServerLink {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLoadBalancerPolicy.
+func (in *ClusterLoadBalancerPolicy) DeepCopy() *ClusterLoadBalancerPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(InventoryServerLink)
+	out := new(ClusterLoadBalancerPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterLoadBalancerPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InventoryServerList) DeepCopyInto(out *InventoryServerList) {
+func (in *ClusterLoadBalancerPolicyList) DeepCopyInto(out *ClusterLoadBalancerPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]InventoryServer, len(*in))
+		*out = make([]ClusterLoadBalancerPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryServerList.
-func (in *InventoryServerList) DeepCopy() *InventoryServerList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLoadBalancerPolicyList.
+func (in *ClusterLoadBalancerPolicyList) DeepCopy() *ClusterLoadBalancerPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(InventoryServerList)
+	out := new(ClusterLoadBalancerPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *InventoryServerList) DeepCopyObject() runtime.Object {
+func (in *ClusterLoadBalancerPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -927,26 +902,47 @@ func (in *InventoryServerList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InventoryServerMeta) DeepCopyInto(out *InventoryServerMeta) {
+func (in *ClusterLoadBalancerPolicySpec) DeepCopyInto(out *ClusterLoadBalancerPolicySpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.LoadBalancerPolicySpec.DeepCopyInto(&out.LoadBalancerPolicySpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLoadBalancerPolicySpec.
+func (in *ClusterLoadBalancerPolicySpec) DeepCopy() *ClusterLoadBalancerPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterLoadBalancerPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Controller) DeepCopyInto(out *Controller) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
 	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryServerMeta.
-func (in *InventoryServerMeta) DeepCopy() *InventoryServerMeta {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Controller.
+func (in *Controller) DeepCopy() *Controller {
 	if in == nil {
 		return nil
 	}
-	out := new(InventoryServerMeta)
+	out := new(Controller)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *InventoryServerMeta) DeepCopyObject() runtime.Object {
+func (in *Controller) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -954,31 +950,31 @@ func (in *InventoryServerMeta) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InventoryServerMetaList) DeepCopyInto(out *InventoryServerMetaList) {
+func (in *ControllerList) DeepCopyInto(out *ControllerList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]InventoryServerMeta, len(*in))
+		*out = make([]Controller, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryServerMetaList.
-func (in *InventoryServerMetaList) DeepCopy() *InventoryServerMetaList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerList.
+func (in *ControllerList) DeepCopy() *ControllerList {
 	if in == nil {
 		return nil
 	}
-	out := new(InventoryServerMetaList)
+	out := new(ControllerList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *InventoryServerMetaList) DeepCopyObject() runtime.Object {
+func (in *ControllerList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -986,168 +982,192 @@ func (in *InventoryServerMetaList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InventoryServerMetaSpec) DeepCopyInto(out *InventoryServerMetaSpec) {
+func (in *ControllerMeta) DeepCopyInto(out *ControllerMeta) {
 	*out = *in
-	if in.Links != nil {
-		in, out := &in.Links, &out.Links
-		*out = make([]inventory.HWLink, len(*in))
-		copy(*out, *in)
-	}
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryServerMetaSpec.
-func (in *InventoryServerMetaSpec) DeepCopy() *InventoryServerMetaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerMeta.
+func (in *ControllerMeta) DeepCopy() *ControllerMeta {
 	if in == nil {
 		return nil
 	}
-	out := new(InventoryServerMetaSpec)
+	out := new(ControllerMeta)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ControllerMeta) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InventoryServerMetaStatus) DeepCopyInto(out *InventoryServerMetaStatus) {
+func (in *ControllerMetaList) DeepCopyInto(out *ControllerMetaList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ControllerMeta, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryServerMetaStatus.
-func (in *InventoryServerMetaStatus) DeepCopy() *InventoryServerMetaStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerMetaList.
+func (in *ControllerMetaList) DeepCopy() *ControllerMetaList {
 	if in == nil {
 		return nil
 	}
-	out := new(InventoryServerMetaStatus)
+	out := new(ControllerMetaList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ControllerMetaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InventoryServerSpec) DeepCopyInto(out *InventoryServerSpec) {
+func (in *ControllerMetaSpec) DeepCopyInto(out *ControllerMetaSpec) {
 	*out = *in
-	if in.Links != nil {
-		in, out := &in.Links, &out.Links
-		*out = make([]InventoryServerLink, len(*in))
-		copy(*out, *in)
-	}
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryServerSpec.
-func (in *InventoryServerSpec) DeepCopy() *InventoryServerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerMetaSpec.
+func (in *ControllerMetaSpec) DeepCopy() *ControllerMetaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InventoryServerSpec)
+	out := new(ControllerMetaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InventoryServerStatus) DeepCopyInto(out *InventoryServerStatus) {
+func (in *ControllerMetaStatus) DeepCopyInto(out *ControllerMetaStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryServerStatus.
-func (in *InventoryServerStatus) DeepCopy() *InventoryServerStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerMetaStatus.
+func (in *ControllerMetaStatus) DeepCopy() *ControllerMetaStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(InventoryServerStatus)
+	out := new(ControllerMetaStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L4LB) DeepCopyInto(out *L4LB) {
+func (in *ControllerSpec) DeepCopyInto(out *ControllerSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LB.
-func (in *L4LB) DeepCopy() *L4LB {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerSpec.
+func (in *ControllerSpec) DeepCopy() *ControllerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(L4LB)
+	out := new(ControllerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *L4LB) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerStatus) DeepCopyInto(out *ControllerStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerStatus.
+func (in *ControllerStatus) DeepCopy() *ControllerStatus {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(ControllerStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L4LBCheck) DeepCopyInto(out *L4LBCheck) {
+func (in *DHCPOption) DeepCopyInto(out *DHCPOption) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBCheck.
-func (in *L4LBCheck) DeepCopy() *L4LBCheck {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPOption.
+func (in *DHCPOption) DeepCopy() *DHCPOption {
 	if in == nil {
 		return nil
 	}
-	out := new(L4LBCheck)
+	out := new(DHCPOption)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L4LBFrontend) DeepCopyInto(out *L4LBFrontend) {
+func (in *DHCPOptionSet) DeepCopyInto(out *DHCPOptionSet) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBFrontend.
-func (in *L4LBFrontend) DeepCopy() *L4LBFrontend {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPOptionSet.
+func (in *DHCPOptionSet) DeepCopy() *DHCPOptionSet {
 	if in == nil {
 		return nil
 	}
-	out := new(L4LBFrontend)
+	out := new(DHCPOptionSet)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DHCPOptionSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L4LBList) DeepCopyInto(out *L4LBList) {
+func (in *DHCPOptionSetList) DeepCopyInto(out *DHCPOptionSetList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]L4LB, len(*in))
+		*out = make([]DHCPOptionSet, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBList.
-func (in *L4LBList) DeepCopy() *L4LBList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPOptionSetList.
+func (in *DHCPOptionSetList) DeepCopy() *DHCPOptionSetList {
 	if in == nil {
 		return nil
 	}
-	out := new(L4LBList)
+	out := new(DHCPOptionSetList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *L4LBList) DeepCopyObject() runtime.Object {
+func (in *DHCPOptionSetList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1155,7 +1175,7 @@ func (in *L4LBList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L4LBMeta) DeepCopyInto(out *L4LBMeta) {
+func (in *DHCPOptionSetMeta) DeepCopyInto(out *DHCPOptionSetMeta) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1163,18 +1183,18 @@ func (in *L4LBMeta) DeepCopyInto(out *L4LBMeta) {
 	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBMeta.
-func (in *L4LBMeta) DeepCopy() *L4LBMeta {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPOptionSetMeta.
+func (in *DHCPOptionSetMeta) DeepCopy() *DHCPOptionSetMeta {
 	if in == nil {
 		return nil
 	}
-	out := new(L4LBMeta)
+	out := new(DHCPOptionSetMeta)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *L4LBMeta) DeepCopyObject() runtime.Object {
+func (in *DHCPOptionSetMeta) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1182,28 +1202,737 @@ func (in *L4LBMeta) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L4LBMetaBackend) DeepCopyInto(out *L4LBMetaBackend) {
+func (in *DHCPOptionSetMetaList) DeepCopyInto(out *DHCPOptionSetMetaList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DHCPOptionSetMeta, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBMetaBackend.
-func (in *L4LBMetaBackend) DeepCopy() *L4LBMetaBackend {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPOptionSetMetaList.
+func (in *DHCPOptionSetMetaList) DeepCopy() *DHCPOptionSetMetaList {
 	if in == nil {
 		return nil
 	}
-	out := new(L4LBMetaBackend)
+	out := new(DHCPOptionSetMetaList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DHCPOptionSetMetaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L4LBMetaHealthCheck) DeepCopyInto(out *L4LBMetaHealthCheck) {
+func (in *DHCPOptionSetMetaSpec) DeepCopyInto(out *DHCPOptionSetMetaSpec) {
 	*out = *in
-	if in.TCP != nil {
-		in, out := &in.TCP, &out.TCP
-		*out = new(L4LBMetaHealthCheckTCP)
-		**out = **in
-	}
+	if in.DNSServers != nil {
+		in, out := &in.DNSServers, &out.DNSServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NTPServers != nil {
+		in, out := &in.NTPServers, &out.NTPServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalOptions != nil {
+		in, out := &in.AdditionalOptions, &out.AdditionalOptions
+		*out = make([]DHCPOption, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPOptionSetMetaSpec.
+func (in *DHCPOptionSetMetaSpec) DeepCopy() *DHCPOptionSetMetaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPOptionSetMetaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPOptionSetMetaStatus) DeepCopyInto(out *DHCPOptionSetMetaStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPOptionSetMetaStatus.
+func (in *DHCPOptionSetMetaStatus) DeepCopy() *DHCPOptionSetMetaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPOptionSetMetaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPOptionSetSpec) DeepCopyInto(out *DHCPOptionSetSpec) {
+	*out = *in
+	if in.DNSServers != nil {
+		in, out := &in.DNSServers, &out.DNSServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NTPServers != nil {
+		in, out := &in.NTPServers, &out.NTPServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalOptions != nil {
+		in, out := &in.AdditionalOptions, &out.AdditionalOptions
+		*out = make([]DHCPOption, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPOptionSetSpec.
+func (in *DHCPOptionSetSpec) DeepCopy() *DHCPOptionSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPOptionSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPOptionSetStatus) DeepCopyInto(out *DHCPOptionSetStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPOptionSetStatus.
+func (in *DHCPOptionSetStatus) DeepCopy() *DHCPOptionSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPOptionSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryProfile) DeepCopyInto(out *InventoryProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryProfile.
+func (in *InventoryProfile) DeepCopy() *InventoryProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InventoryProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryProfileCustomRule) DeepCopyInto(out *InventoryProfileCustomRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryProfileCustomRule.
+func (in *InventoryProfileCustomRule) DeepCopy() *InventoryProfileCustomRule {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryProfileCustomRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryProfileList) DeepCopyInto(out *InventoryProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]InventoryProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryProfileList.
+func (in *InventoryProfileList) DeepCopy() *InventoryProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InventoryProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryProfileMeta) DeepCopyInto(out *InventoryProfileMeta) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryProfileMeta.
+func (in *InventoryProfileMeta) DeepCopy() *InventoryProfileMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryProfileMeta)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InventoryProfileMeta) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryProfileMetaList) DeepCopyInto(out *InventoryProfileMetaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]InventoryProfileMeta, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryProfileMetaList.
+func (in *InventoryProfileMetaList) DeepCopy() *InventoryProfileMetaList {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryProfileMetaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InventoryProfileMetaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryProfileMetaSpec) DeepCopyInto(out *InventoryProfileMetaSpec) {
+	*out = *in
+	if in.AllowSSHFromIPv4 != nil {
+		in, out := &in.AllowSSHFromIPv4, &out.AllowSSHFromIPv4
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowSSHFromIPv6 != nil {
+		in, out := &in.AllowSSHFromIPv6, &out.AllowSSHFromIPv6
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NTPServers != nil {
+		in, out := &in.NTPServers, &out.NTPServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DNSServers != nil {
+		in, out := &in.DNSServers, &out.DNSServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CustomRules != nil {
+		in, out := &in.CustomRules, &out.CustomRules
+		*out = make([]InventoryProfileCustomRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryProfileMetaSpec.
+func (in *InventoryProfileMetaSpec) DeepCopy() *InventoryProfileMetaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryProfileMetaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryProfileMetaStatus) DeepCopyInto(out *InventoryProfileMetaStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryProfileMetaStatus.
+func (in *InventoryProfileMetaStatus) DeepCopy() *InventoryProfileMetaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryProfileMetaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryProfileSpec) DeepCopyInto(out *InventoryProfileSpec) {
+	*out = *in
+	if in.AllowSSHFromIPv4 != nil {
+		in, out := &in.AllowSSHFromIPv4, &out.AllowSSHFromIPv4
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowSSHFromIPv6 != nil {
+		in, out := &in.AllowSSHFromIPv6, &out.AllowSSHFromIPv6
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NTPServers != nil {
+		in, out := &in.NTPServers, &out.NTPServers
+		*out = make([]NTPServer, len(*in))
+		copy(*out, *in)
+	}
+	if in.DNSServers != nil {
+		in, out := &in.DNSServers, &out.DNSServers
+		*out = make([]DNSServer, len(*in))
+		copy(*out, *in)
+	}
+	if in.CustomRules != nil {
+		in, out := &in.CustomRules, &out.CustomRules
+		*out = make([]InventoryProfileCustomRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryProfileSpec.
+func (in *InventoryProfileSpec) DeepCopy() *InventoryProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryProfileStatus) DeepCopyInto(out *InventoryProfileStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryProfileStatus.
+func (in *InventoryProfileStatus) DeepCopy() *InventoryProfileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryProfileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryServer) DeepCopyInto(out *InventoryServer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryServer.
+func (in *InventoryServer) DeepCopy() *InventoryServer {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InventoryServer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryServerLink) DeepCopyInto(out *InventoryServerLink) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryServerLink.
+func (in *InventoryServerLink) DeepCopy() *InventoryServerLink {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryServerLink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryServerList) DeepCopyInto(out *InventoryServerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]InventoryServer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryServerList.
+func (in *InventoryServerList) DeepCopy() *InventoryServerList {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryServerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InventoryServerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryServerMeta) DeepCopyInto(out *InventoryServerMeta) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryServerMeta.
+func (in *InventoryServerMeta) DeepCopy() *InventoryServerMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryServerMeta)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InventoryServerMeta) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryServerMetaList) DeepCopyInto(out *InventoryServerMetaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]InventoryServerMeta, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryServerMetaList.
+func (in *InventoryServerMetaList) DeepCopy() *InventoryServerMetaList {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryServerMetaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InventoryServerMetaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryServerMetaSpec) DeepCopyInto(out *InventoryServerMetaSpec) {
+	*out = *in
+	if in.Links != nil {
+		in, out := &in.Links, &out.Links
+		*out = make([]inventory.HWLink, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryServerMetaSpec.
+func (in *InventoryServerMetaSpec) DeepCopy() *InventoryServerMetaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryServerMetaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryServerMetaStatus) DeepCopyInto(out *InventoryServerMetaStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryServerMetaStatus.
+func (in *InventoryServerMetaStatus) DeepCopy() *InventoryServerMetaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryServerMetaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryServerSpec) DeepCopyInto(out *InventoryServerSpec) {
+	*out = *in
+	if in.Links != nil {
+		in, out := &in.Links, &out.Links
+		*out = make([]InventoryServerLink, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryServerSpec.
+func (in *InventoryServerSpec) DeepCopy() *InventoryServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryServerStatus) DeepCopyInto(out *InventoryServerStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryServerStatus.
+func (in *InventoryServerStatus) DeepCopy() *InventoryServerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryServerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L4LB) DeepCopyInto(out *L4LB) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LB.
+func (in *L4LB) DeepCopy() *L4LB {
+	if in == nil {
+		return nil
+	}
+	out := new(L4LB)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *L4LB) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L4LBBackendStatus) DeepCopyInto(out *L4LBBackendStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBBackendStatus.
+func (in *L4LBBackendStatus) DeepCopy() *L4LBBackendStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(L4LBBackendStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L4LBCheck) DeepCopyInto(out *L4LBCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBCheck.
+func (in *L4LBCheck) DeepCopy() *L4LBCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(L4LBCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L4LBFrontend) DeepCopyInto(out *L4LBFrontend) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBFrontend.
+func (in *L4LBFrontend) DeepCopy() *L4LBFrontend {
+	if in == nil {
+		return nil
+	}
+	out := new(L4LBFrontend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L4LBList) DeepCopyInto(out *L4LBList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]L4LB, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBList.
+func (in *L4LBList) DeepCopy() *L4LBList {
+	if in == nil {
+		return nil
+	}
+	out := new(L4LBList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *L4LBList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L4LBMeta) DeepCopyInto(out *L4LBMeta) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBMeta.
+func (in *L4LBMeta) DeepCopy() *L4LBMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(L4LBMeta)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *L4LBMeta) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L4LBMetaBackend) DeepCopyInto(out *L4LBMetaBackend) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBMetaBackend.
+func (in *L4LBMetaBackend) DeepCopy() *L4LBMetaBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(L4LBMetaBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L4LBMetaHealthCheck) DeepCopyInto(out *L4LBMetaHealthCheck) {
+	*out = *in
+	if in.TCP != nil {
+		in, out := &in.TCP, &out.TCP
+		*out = new(L4LBMetaHealthCheckTCP)
+		**out = **in
+	}
 	if in.HTTP != nil {
 		in, out := &in.HTTP, &out.HTTP
 		*out = new(L4LBMetaHealthCheckHTTP)
@@ -1211,72 +1940,748 @@ func (in *L4LBMetaHealthCheck) DeepCopyInto(out *L4LBMetaHealthCheck) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBMetaHealthCheck.
-func (in *L4LBMetaHealthCheck) DeepCopy() *L4LBMetaHealthCheck {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBMetaHealthCheck.
+func (in *L4LBMetaHealthCheck) DeepCopy() *L4LBMetaHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(L4LBMetaHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L4LBMetaHealthCheckHTTP) DeepCopyInto(out *L4LBMetaHealthCheckHTTP) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBMetaHealthCheckHTTP.
+func (in *L4LBMetaHealthCheckHTTP) DeepCopy() *L4LBMetaHealthCheckHTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(L4LBMetaHealthCheckHTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L4LBMetaHealthCheckTCP) DeepCopyInto(out *L4LBMetaHealthCheckTCP) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBMetaHealthCheckTCP.
+func (in *L4LBMetaHealthCheckTCP) DeepCopy() *L4LBMetaHealthCheckTCP {
+	if in == nil {
+		return nil
+	}
+	out := new(L4LBMetaHealthCheckTCP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L4LBMetaList) DeepCopyInto(out *L4LBMetaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]L4LBMeta, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBMetaList.
+func (in *L4LBMetaList) DeepCopy() *L4LBMetaList {
+	if in == nil {
+		return nil
+	}
+	out := new(L4LBMetaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *L4LBMetaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L4LBMetaSpec) DeepCopyInto(out *L4LBMetaSpec) {
+	*out = *in
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(L4LBMetaHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backend != nil {
+		in, out := &in.Backend, &out.Backend
+		*out = make([]L4LBMetaBackend, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBMetaSpec.
+func (in *L4LBMetaSpec) DeepCopy() *L4LBMetaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(L4LBMetaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L4LBMetaStatus) DeepCopyInto(out *L4LBMetaStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBMetaStatus.
+func (in *L4LBMetaStatus) DeepCopy() *L4LBMetaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(L4LBMetaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L4LBSpec) DeepCopyInto(out *L4LBSpec) {
+	*out = *in
+	out.Check = in.Check
+	out.Frontend = in.Frontend
+	if in.Backend != nil {
+		in, out := &in.Backend, &out.Backend
+		*out = make([]L4LBBackend, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBSpec.
+func (in *L4LBSpec) DeepCopy() *L4LBSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(L4LBSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *L4LBStatus) DeepCopyInto(out *L4LBStatus) {
+	*out = *in
+	in.ModifiedDate.DeepCopyInto(&out.ModifiedDate)
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]L4LBBackendStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBStatus.
+func (in *L4LBStatus) DeepCopy() *L4LBStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(L4LBStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LAG) DeepCopyInto(out *LAG) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LAG.
+func (in *LAG) DeepCopy() *LAG {
+	if in == nil {
+		return nil
+	}
+	out := new(LAG)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LAG) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LAGList) DeepCopyInto(out *LAGList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LAG, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LAGList.
+func (in *LAGList) DeepCopy() *LAGList {
+	if in == nil {
+		return nil
+	}
+	out := new(LAGList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LAGList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LAGMeta) DeepCopyInto(out *LAGMeta) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LAGMeta.
+func (in *LAGMeta) DeepCopy() *LAGMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(LAGMeta)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LAGMeta) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LAGMetaList) DeepCopyInto(out *LAGMetaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LAGMeta, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LAGMetaList.
+func (in *LAGMetaList) DeepCopy() *LAGMetaList {
+	if in == nil {
+		return nil
+	}
+	out := new(LAGMetaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LAGMetaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LAGMetaPort) DeepCopyInto(out *LAGMetaPort) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LAGMetaPort.
+func (in *LAGMetaPort) DeepCopy() *LAGMetaPort {
+	if in == nil {
+		return nil
+	}
+	out := new(LAGMetaPort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LAGMetaSpec) DeepCopyInto(out *LAGMetaSpec) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]LAGMetaPort, len(*in))
+		copy(*out, *in)
+	}
+	if in.MCLAGID != nil {
+		in, out := &in.MCLAGID, &out.MCLAGID
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LAGMetaSpec.
+func (in *LAGMetaSpec) DeepCopy() *LAGMetaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LAGMetaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LAGMetaStatus) DeepCopyInto(out *LAGMetaStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LAGMetaStatus.
+func (in *LAGMetaStatus) DeepCopy() *LAGMetaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LAGMetaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LAGSpec) DeepCopyInto(out *LAGSpec) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]LAGPort, len(*in))
+		copy(*out, *in)
+	}
+	if in.MCLAGID != nil {
+		in, out := &in.MCLAGID, &out.MCLAGID
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LAGSpec.
+func (in *LAGSpec) DeepCopy() *LAGSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LAGSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LAGStatus) DeepCopyInto(out *LAGStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LAGStatus.
+func (in *LAGStatus) DeepCopy() *LAGStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LAGStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Link) DeepCopyInto(out *Link) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Link.
+func (in *Link) DeepCopy() *Link {
+	if in == nil {
+		return nil
+	}
+	out := new(Link)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Link) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkList) DeepCopyInto(out *LinkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Link, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkList.
+func (in *LinkList) DeepCopy() *LinkList {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LinkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkMeta) DeepCopyInto(out *LinkMeta) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkMeta.
+func (in *LinkMeta) DeepCopy() *LinkMeta {
 	if in == nil {
 		return nil
 	}
-	out := new(L4LBMetaHealthCheck)
+	out := new(LinkMeta)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LinkMeta) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L4LBMetaHealthCheckHTTP) DeepCopyInto(out *L4LBMetaHealthCheckHTTP) {
+func (in *LinkMetaList) DeepCopyInto(out *LinkMetaList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LinkMeta, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBMetaHealthCheckHTTP.
-func (in *L4LBMetaHealthCheckHTTP) DeepCopy() *L4LBMetaHealthCheckHTTP {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkMetaList.
+func (in *LinkMetaList) DeepCopy() *LinkMetaList {
 	if in == nil {
 		return nil
 	}
-	out := new(L4LBMetaHealthCheckHTTP)
+	out := new(LinkMetaList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LinkMetaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L4LBMetaHealthCheckTCP) DeepCopyInto(out *L4LBMetaHealthCheckTCP) {
+func (in *LinkMetaSpec) DeepCopyInto(out *LinkMetaSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBMetaHealthCheckTCP.
-func (in *L4LBMetaHealthCheckTCP) DeepCopy() *L4LBMetaHealthCheckTCP {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkMetaSpec.
+func (in *LinkMetaSpec) DeepCopy() *LinkMetaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkMetaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkMetaStatus) DeepCopyInto(out *LinkMetaStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkMetaStatus.
+func (in *LinkMetaStatus) DeepCopy() *LinkMetaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkMetaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkSpec) DeepCopyInto(out *LinkSpec) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]LinkSpecPort, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkSpec.
+func (in *LinkSpec) DeepCopy() *LinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinkStatus) DeepCopyInto(out *LinkStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkStatus.
+func (in *LinkStatus) DeepCopy() *LinkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LinkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerPolicy) DeepCopyInto(out *LoadBalancerPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerPolicy.
+func (in *LoadBalancerPolicy) DeepCopy() *LoadBalancerPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LoadBalancerPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerPolicyList) DeepCopyInto(out *LoadBalancerPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LoadBalancerPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerPolicyList.
+func (in *LoadBalancerPolicyList) DeepCopy() *LoadBalancerPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LoadBalancerPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerPolicySpec) DeepCopyInto(out *LoadBalancerPolicySpec) {
+	*out = *in
+	if in.ServiceSelector != nil {
+		in, out := &in.ServiceSelector, &out.ServiceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Check = in.Check
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerPolicySpec.
+func (in *LoadBalancerPolicySpec) DeepCopy() *LoadBalancerPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Nat) DeepCopyInto(out *Nat) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Nat.
+func (in *Nat) DeepCopy() *Nat {
+	if in == nil {
+		return nil
+	}
+	out := new(Nat)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Nat) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NatList) DeepCopyInto(out *NatList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Nat, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatList.
+func (in *NatList) DeepCopy() *NatList {
+	if in == nil {
+		return nil
+	}
+	out := new(NatList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NatList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NatMeta) DeepCopyInto(out *NatMeta) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatMeta.
+func (in *NatMeta) DeepCopy() *NatMeta {
 	if in == nil {
 		return nil
 	}
-	out := new(L4LBMetaHealthCheckTCP)
+	out := new(NatMeta)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NatMeta) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L4LBMetaList) DeepCopyInto(out *L4LBMetaList) {
+func (in *NatMetaList) DeepCopyInto(out *NatMetaList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]L4LBMeta, len(*in))
+		*out = make([]NatMeta, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBMetaList.
-func (in *L4LBMetaList) DeepCopy() *L4LBMetaList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatMetaList.
+func (in *NatMetaList) DeepCopy() *NatMetaList {
 	if in == nil {
 		return nil
 	}
-	out := new(L4LBMetaList)
+	out := new(NatMetaList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *L4LBMetaList) DeepCopyObject() runtime.Object {
+func (in *NatMetaList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1284,85 +2689,67 @@ func (in *L4LBMetaList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L4LBMetaSpec) DeepCopyInto(out *L4LBMetaSpec) {
+func (in *NatMetaSpec) DeepCopyInto(out *NatMetaSpec) {
 	*out = *in
-	if in.HealthCheck != nil {
-		in, out := &in.HealthCheck, &out.HealthCheck
-		*out = new(L4LBMetaHealthCheck)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Backend != nil {
-		in, out := &in.Backend, &out.Backend
-		*out = make([]L4LBMetaBackend, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBMetaSpec.
-func (in *L4LBMetaSpec) DeepCopy() *L4LBMetaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatMetaSpec.
+func (in *NatMetaSpec) DeepCopy() *NatMetaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(L4LBMetaSpec)
+	out := new(NatMetaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L4LBMetaStatus) DeepCopyInto(out *L4LBMetaStatus) {
+func (in *NatMetaStatus) DeepCopyInto(out *NatMetaStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBMetaStatus.
-func (in *L4LBMetaStatus) DeepCopy() *L4LBMetaStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatMetaStatus.
+func (in *NatMetaStatus) DeepCopy() *NatMetaStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(L4LBMetaStatus)
+	out := new(NatMetaStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L4LBSpec) DeepCopyInto(out *L4LBSpec) {
+func (in *NatSpec) DeepCopyInto(out *NatSpec) {
 	*out = *in
-	out.Check = in.Check
-	out.Frontend = in.Frontend
-	if in.Backend != nil {
-		in, out := &in.Backend, &out.Backend
-		*out = make([]L4LBBackend, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBSpec.
-func (in *L4LBSpec) DeepCopy() *L4LBSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatSpec.
+func (in *NatSpec) DeepCopy() *NatSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(L4LBSpec)
+	out := new(NatSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *L4LBStatus) DeepCopyInto(out *L4LBStatus) {
+func (in *NatStatus) DeepCopyInto(out *NatStatus) {
 	*out = *in
-	in.ModifiedDate.DeepCopyInto(&out.ModifiedDate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L4LBStatus.
-func (in *L4LBStatus) DeepCopy() *L4LBStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatStatus.
+func (in *NatStatus) DeepCopy() *NatStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(L4LBStatus)
+	out := new(NatStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Link) DeepCopyInto(out *Link) {
+func (in *NetrisObject) DeepCopyInto(out *NetrisObject) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1370,18 +2757,18 @@ func (in *Link) DeepCopyInto(out *Link) {
 	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Link.
-func (in *Link) DeepCopy() *Link {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetrisObject.
+func (in *NetrisObject) DeepCopy() *NetrisObject {
 	if in == nil {
 		return nil
 	}
-	out := new(Link)
+	out := new(NetrisObject)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Link) DeepCopyObject() runtime.Object {
+func (in *NetrisObject) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1389,31 +2776,31 @@ func (in *Link) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LinkList) DeepCopyInto(out *LinkList) {
+func (in *NetrisObjectList) DeepCopyInto(out *NetrisObjectList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Link, len(*in))
+		*out = make([]NetrisObject, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkList.
-func (in *LinkList) DeepCopy() *LinkList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetrisObjectList.
+func (in *NetrisObjectList) DeepCopy() *NetrisObjectList {
 	if in == nil {
 		return nil
 	}
-	out := new(LinkList)
+	out := new(NetrisObjectList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LinkList) DeepCopyObject() runtime.Object {
+func (in *NetrisObjectList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1421,26 +2808,26 @@ func (in *LinkList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LinkMeta) DeepCopyInto(out *LinkMeta) {
+func (in *NetrisObjectMeta) DeepCopyInto(out *NetrisObjectMeta) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkMeta.
-func (in *LinkMeta) DeepCopy() *LinkMeta {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetrisObjectMeta.
+func (in *NetrisObjectMeta) DeepCopy() *NetrisObjectMeta {
 	if in == nil {
 		return nil
 	}
-	out := new(LinkMeta)
+	out := new(NetrisObjectMeta)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LinkMeta) DeepCopyObject() runtime.Object {
+func (in *NetrisObjectMeta) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1448,31 +2835,31 @@ func (in *LinkMeta) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LinkMetaList) DeepCopyInto(out *LinkMetaList) {
+func (in *NetrisObjectMetaList) DeepCopyInto(out *NetrisObjectMetaList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]LinkMeta, len(*in))
+		*out = make([]NetrisObjectMeta, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkMetaList.
-func (in *LinkMetaList) DeepCopy() *LinkMetaList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetrisObjectMetaList.
+func (in *NetrisObjectMetaList) DeepCopy() *NetrisObjectMetaList {
 	if in == nil {
 		return nil
 	}
-	out := new(LinkMetaList)
+	out := new(NetrisObjectMetaList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LinkMetaList) DeepCopyObject() runtime.Object {
+func (in *NetrisObjectMetaList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1480,91 +2867,88 @@ func (in *LinkMetaList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LinkMetaSpec) DeepCopyInto(out *LinkMetaSpec) {
+func (in *NetrisObjectMetaSpec) DeepCopyInto(out *NetrisObjectMetaSpec) {
 	*out = *in
+	in.Body.DeepCopyInto(&out.Body)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkMetaSpec.
-func (in *LinkMetaSpec) DeepCopy() *LinkMetaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetrisObjectMetaSpec.
+func (in *NetrisObjectMetaSpec) DeepCopy() *NetrisObjectMetaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LinkMetaSpec)
+	out := new(NetrisObjectMetaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LinkMetaStatus) DeepCopyInto(out *LinkMetaStatus) {
+func (in *NetrisObjectMetaStatus) DeepCopyInto(out *NetrisObjectMetaStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkMetaStatus.
-func (in *LinkMetaStatus) DeepCopy() *LinkMetaStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetrisObjectMetaStatus.
+func (in *NetrisObjectMetaStatus) DeepCopy() *NetrisObjectMetaStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(LinkMetaStatus)
+	out := new(NetrisObjectMetaStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LinkSpec) DeepCopyInto(out *LinkSpec) {
+func (in *NetrisObjectSpec) DeepCopyInto(out *NetrisObjectSpec) {
 	*out = *in
-	if in.Ports != nil {
-		in, out := &in.Ports, &out.Ports
-		*out = make([]LinkSpecPort, len(*in))
-		copy(*out, *in)
-	}
+	in.Body.DeepCopyInto(&out.Body)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkSpec.
-func (in *LinkSpec) DeepCopy() *LinkSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetrisObjectSpec.
+func (in *NetrisObjectSpec) DeepCopy() *NetrisObjectSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LinkSpec)
+	out := new(NetrisObjectSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LinkStatus) DeepCopyInto(out *LinkStatus) {
+func (in *NetrisObjectStatus) DeepCopyInto(out *NetrisObjectStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinkStatus.
-func (in *LinkStatus) DeepCopy() *LinkStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetrisObjectStatus.
+func (in *NetrisObjectStatus) DeepCopy() *NetrisObjectStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(LinkStatus)
+	out := new(NetrisObjectStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Nat) DeepCopyInto(out *Nat) {
+func (in *RouteMap) DeepCopyInto(out *RouteMap) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Nat.
-func (in *Nat) DeepCopy() *Nat {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMap.
+func (in *RouteMap) DeepCopy() *RouteMap {
 	if in == nil {
 		return nil
 	}
-	out := new(Nat)
+	out := new(RouteMap)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Nat) DeepCopyObject() runtime.Object {
+func (in *RouteMap) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1572,31 +2956,46 @@ func (in *Nat) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NatList) DeepCopyInto(out *NatList) {
+func (in *RouteMapAction) DeepCopyInto(out *RouteMapAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMapAction.
+func (in *RouteMapAction) DeepCopy() *RouteMapAction {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMapAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMapList) DeepCopyInto(out *RouteMapList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Nat, len(*in))
+		*out = make([]RouteMap, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatList.
-func (in *NatList) DeepCopy() *NatList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMapList.
+func (in *RouteMapList) DeepCopy() *RouteMapList {
 	if in == nil {
 		return nil
 	}
-	out := new(NatList)
+	out := new(RouteMapList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NatList) DeepCopyObject() runtime.Object {
+func (in *RouteMapList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1604,26 +3003,41 @@ func (in *NatList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NatMeta) DeepCopyInto(out *NatMeta) {
+func (in *RouteMapMatch) DeepCopyInto(out *RouteMapMatch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMapMatch.
+func (in *RouteMapMatch) DeepCopy() *RouteMapMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMapMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMapMeta) DeepCopyInto(out *RouteMapMeta) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatMeta.
-func (in *NatMeta) DeepCopy() *NatMeta {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMapMeta.
+func (in *RouteMapMeta) DeepCopy() *RouteMapMeta {
 	if in == nil {
 		return nil
 	}
-	out := new(NatMeta)
+	out := new(RouteMapMeta)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NatMeta) DeepCopyObject() runtime.Object {
+func (in *RouteMapMeta) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1631,31 +3045,31 @@ func (in *NatMeta) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NatMetaList) DeepCopyInto(out *NatMetaList) {
+func (in *RouteMapMetaList) DeepCopyInto(out *RouteMapMetaList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]NatMeta, len(*in))
+		*out = make([]RouteMapMeta, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatMetaList.
-func (in *NatMetaList) DeepCopy() *NatMetaList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMapMetaList.
+func (in *RouteMapMetaList) DeepCopy() *RouteMapMetaList {
 	if in == nil {
 		return nil
 	}
-	out := new(NatMetaList)
+	out := new(RouteMapMetaList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NatMetaList) DeepCopyObject() runtime.Object {
+func (in *RouteMapMetaList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1663,61 +3077,140 @@ func (in *NatMetaList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NatMetaSpec) DeepCopyInto(out *NatMetaSpec) {
+func (in *RouteMapMetaMatch) DeepCopyInto(out *RouteMapMetaMatch) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatMetaSpec.
-func (in *NatMetaSpec) DeepCopy() *NatMetaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMapMetaMatch.
+func (in *RouteMapMetaMatch) DeepCopy() *RouteMapMetaMatch {
 	if in == nil {
 		return nil
 	}
-	out := new(NatMetaSpec)
+	out := new(RouteMapMetaMatch)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NatMetaStatus) DeepCopyInto(out *NatMetaStatus) {
+func (in *RouteMapMetaSequence) DeepCopyInto(out *RouteMapMetaSequence) {
 	*out = *in
+	if in.Matches != nil {
+		in, out := &in.Matches, &out.Matches
+		*out = make([]RouteMapMetaMatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]RouteMapAction, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatMetaStatus.
-func (in *NatMetaStatus) DeepCopy() *NatMetaStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMapMetaSequence.
+func (in *RouteMapMetaSequence) DeepCopy() *RouteMapMetaSequence {
 	if in == nil {
 		return nil
 	}
-	out := new(NatMetaStatus)
+	out := new(RouteMapMetaSequence)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NatSpec) DeepCopyInto(out *NatSpec) {
+func (in *RouteMapMetaSpec) DeepCopyInto(out *RouteMapMetaSpec) {
 	*out = *in
+	if in.Sequences != nil {
+		in, out := &in.Sequences, &out.Sequences
+		*out = make([]RouteMapMetaSequence, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatSpec.
-func (in *NatSpec) DeepCopy() *NatSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMapMetaSpec.
+func (in *RouteMapMetaSpec) DeepCopy() *RouteMapMetaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NatSpec)
+	out := new(RouteMapMetaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NatStatus) DeepCopyInto(out *NatStatus) {
+func (in *RouteMapMetaStatus) DeepCopyInto(out *RouteMapMetaStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatStatus.
-func (in *NatStatus) DeepCopy() *NatStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMapMetaStatus.
+func (in *RouteMapMetaStatus) DeepCopy() *RouteMapMetaStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NatStatus)
+	out := new(RouteMapMetaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMapSequence) DeepCopyInto(out *RouteMapSequence) {
+	*out = *in
+	if in.Matches != nil {
+		in, out := &in.Matches, &out.Matches
+		*out = make([]RouteMapMatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]RouteMapAction, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMapSequence.
+func (in *RouteMapSequence) DeepCopy() *RouteMapSequence {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMapSequence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMapSpec) DeepCopyInto(out *RouteMapSpec) {
+	*out = *in
+	if in.Sequences != nil {
+		in, out := &in.Sequences, &out.Sequences
+		*out = make([]RouteMapSequence, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMapSpec.
+func (in *RouteMapSpec) DeepCopy() *RouteMapSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMapSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMapStatus) DeepCopyInto(out *RouteMapStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMapStatus.
+func (in *RouteMapStatus) DeepCopy() *RouteMapStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMapStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2506,7 +3999,7 @@ func (in *SoftgateMeta) DeepCopyInto(out *SoftgateMeta) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
 
@@ -2563,6 +4056,11 @@ func (in *SoftgateMetaList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SoftgateMetaSpec) DeepCopyInto(out *SoftgateMetaSpec) {
 	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SoftgateMetaSpec.
@@ -2746,6 +4244,11 @@ func (in *SubnetMetaSpec) DeepCopyInto(out *SubnetMetaSpec) {
 		*out = make([]int, len(*in))
 		copy(*out, *in)
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetMetaSpec.
@@ -2872,7 +4375,7 @@ func (in *SwitchMeta) DeepCopyInto(out *SwitchMeta) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
 
@@ -2930,6 +4433,11 @@ func (in *SwitchMetaList) DeepCopyObject() runtime.Object {
 func (in *SwitchMetaSpec) DeepCopyInto(out *SwitchMetaSpec) {
 	*out = *in
 	out.NOS = in.NOS
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchMetaSpec.
@@ -3188,6 +4696,11 @@ func (in *VNetMetaSpec) DeepCopyInto(out *VNetMetaSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VNetMetaSpec.
@@ -0,0 +1,61 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package calicowatcher
+
+import (
+	"github.com/netrisai/netris-operator/watchercore"
+	v1 "k8s.io/api/core/v1"
+)
+
+// asnLedgerName is the ConfigMap recording the AS numbers assigned to the nodes.
+const asnLedgerName = "netris-operator-calico-asns"
+
+// defaultASNReclaimAfter is how long the AS number of a removed node is kept for a node re-created with the same name.
+const defaultASNReclaimAfter = watchercore.DefaultASNReclaimAfter
+
+func (w *Watcher) asnLedgerNamespace() string {
+	if w.Options.Namespace != "" {
+		return w.Options.Namespace
+	}
+	return "default"
+}
+
+// asnLedger returns the ledger of the AS numbers assigned to the nodes, which Calico reads from the node annotation.
+func (w *Watcher) asnLedger() *watchercore.ASNLedger {
+	return &watchercore.ASNLedger{
+		Clientset:      w.clientset,
+		Logger:         logger,
+		Name:           asnLedgerName,
+		Namespace:      w.asnLedgerNamespace(),
+		Annotation:     "projectcalico.org/ASNumber",
+		ContextTimeout: contextTimeout,
+	}
+}
+
+// fillNodesASNs assigns the AS numbers to the nodes through the ledger and annotates the nodes with them.
+func (w *Watcher) fillNodesASNs() error {
+	nodes := []*v1.Node{}
+	for i := range w.data.nodes.Items {
+		nodes = append(nodes, &w.data.nodes.Items[i])
+	}
+	return w.asnLedger().Assign(nodes, w.data.asnStart, w.data.asnEnd, w.data.integration.asnReclaimAfter)
+}
+
+// deleteASNLedger removes the ledger once the integration is disabled.
+func (w *Watcher) deleteASNLedger() error {
+	return w.asnLedger().Delete()
+}
@@ -24,9 +24,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/rest"
 )
 
 // BGPConfiguration .
@@ -71,63 +68,51 @@ type ServiceClusterIPBlock struct {
 	CIDR string `json:"cidr,omitempty" validate:"omitempty,net"`
 }
 
-// IsMissingResource error message parser for missing calico case.
-func IsMissingResource(err error) bool {
-	return err.Error() == "the server could not find the requested resource"
-}
-
 // GetBGPConfiguration .
-func (c *Calico) GetBGPConfiguration(config *rest.Config) ([]*BGPConfiguration, error) {
+func (c *Calico) GetBGPConfiguration() ([]*BGPConfiguration, error) {
 	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
 	defer cancel()
-	dynClient, err := dynamic.NewForConfig(config)
-	if err != nil {
-		return nil, fmt.Errorf("{getBGPConfiguration} %s", err)
-	}
-
-	bgpConfigurationResource := schema.GroupVersionResource{
-		Group:    "crd.projectcalico.org",
-		Version:  "v1",
-		Resource: "bgpconfigurations",
-	}
 
-	list, err := dynClient.Resource(bgpConfigurationResource).List(ctx, metav1.ListOptions{})
+	list, err := c.client.Resource(BGPConfigurationResource).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
 
 	var bgpConfigurations []*BGPConfiguration
-	for _, peer := range list.Items {
-		js, err := peer.MarshalJSON()
+	for i := range list.Items {
+		bgpConfiguration, err := ParseBGPConfiguration(&list.Items[i])
 		if err != nil {
-			return nil, fmt.Errorf("{getBGPConfiguration} %s", err)
+			return nil, err
 		}
-
-		var bgpConfiguration *BGPConfiguration
-		err = json.Unmarshal(js, &bgpConfiguration)
-		if err != nil {
-			return nil, fmt.Errorf("{getBGPConfiguration} %s", err)
-		}
-		bgpConfiguration.Name = bgpConfiguration.Metadata.Name
 		bgpConfigurations = append(bgpConfigurations, bgpConfiguration)
 	}
 	return bgpConfigurations, nil
 }
 
-// UpdateBGPConfiguration .
-func (c *Calico) UpdateBGPConfiguration(bgpConf *BGPConfiguration, config *rest.Config) error {
-	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
-	defer cancel()
-	dynClient, err := dynamic.NewForConfig(config)
+// ParseBGPConfiguration converts the BGPConfiguration got from the dynamic client or its informer.
+func ParseBGPConfiguration(obj runtime.Object) (*BGPConfiguration, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("{ParseBGPConfiguration} unexpected object %T", obj)
+	}
+	js, err := u.MarshalJSON()
 	if err != nil {
-		return fmt.Errorf("{UpdateBGPConfiguration} %s", err)
+		return nil, fmt.Errorf("{ParseBGPConfiguration} %s", err)
 	}
 
-	bgpPeerResource := schema.GroupVersionResource{
-		Group:    "crd.projectcalico.org",
-		Version:  "v1",
-		Resource: "bgpconfigurations",
+	var bgpConfiguration *BGPConfiguration
+	err = json.Unmarshal(js, &bgpConfiguration)
+	if err != nil {
+		return nil, fmt.Errorf("{ParseBGPConfiguration} %s", err)
 	}
+	bgpConfiguration.Name = bgpConfiguration.Metadata.Name
+	return bgpConfiguration, nil
+}
+
+// UpdateBGPConfiguration .
+func (c *Calico) UpdateBGPConfiguration(bgpConf *BGPConfiguration) error {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
 
 	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(bgpConf)
 	if err != nil {
@@ -138,7 +123,7 @@ func (c *Calico) UpdateBGPConfiguration(bgpConf *BGPConfiguration, config *rest.
 		Object: m,
 	}
 
-	_, err = dynClient.Resource(bgpPeerResource).Update(ctx, obj, metav1.UpdateOptions{})
+	_, err = c.client.Resource(BGPConfigurationResource).Update(ctx, obj, metav1.UpdateOptions{})
 	if err != nil {
 		return fmt.Errorf("{UpdateBGPConfiguration} %s", err)
 	}
@@ -26,8 +26,6 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/rest"
 )
 
 // BGPPeer contains information about a BGP peer resource that is a peer of a Calico
@@ -46,19 +44,33 @@ type BGPPeer struct {
 // BGPPeerSpec contains the specification for a BGPPeer resource.
 type BGPPeerSpec struct {
 	// The AS Number of the peer.
-	ASNumber int `json:"asNumber"`
+	ASNumber int `json:"asNumber,omitempty"`
 	// The IP address of the peer.
-	PeerIP string `json:"peerIP" validate:"omitempty"`
+	PeerIP string `json:"peerIP,omitempty" validate:"omitempty"`
+	// Selector for the nodes that should have this peering. When unset, all nodes peer.
+	NodeSelector string `json:"nodeSelector,omitempty"`
+	// Selector for the remote nodes to peer with. When set, PeerIP and ASNumber must be empty.
+	PeerSelector string `json:"peerSelector,omitempty"`
+	// Optional BGP password for the peerings generated by this BGPPeer resource.
+	Password *BGPPassword `json:"password,omitempty"`
+}
+
+// BGPPassword contains ways to specify a BGP password.
+type BGPPassword struct {
+	// Selects a key of a secret in the namespace calico-node is running in.
+	SecretKeyRef *SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// SecretKeySelector selects a key of a secret.
+type SecretKeySelector struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
 }
 
 // GetBGPPeers .
-func (c *Calico) GetBGPPeers(config *rest.Config) ([]*BGPPeer, error) {
+func (c *Calico) GetBGPPeers() ([]*BGPPeer, error) {
 	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
 	defer cancel()
-	dynClient, err := dynamic.NewForConfig(config)
-	if err != nil {
-		return nil, fmt.Errorf("{GetBGPPeer} %s", err)
-	}
 
 	bgpPeerResource := schema.GroupVersionResource{
 		Group:    "crd.projectcalico.org",
@@ -66,7 +78,7 @@ func (c *Calico) GetBGPPeers(config *rest.Config) ([]*BGPPeer, error) {
 		Resource: "bgppeers",
 	}
 
-	list, err := dynClient.Resource(bgpPeerResource).List(ctx, metav1.ListOptions{})
+	list, err := c.client.Resource(bgpPeerResource).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("{GetBGPPeer} %s", err)
 	}
@@ -90,13 +102,9 @@ func (c *Calico) GetBGPPeers(config *rest.Config) ([]*BGPPeer, error) {
 }
 
 // GetBGPPeer .
-func (c *Calico) GetBGPPeer(name string, config *rest.Config) (*BGPPeer, error) {
+func (c *Calico) GetBGPPeer(name string) (*BGPPeer, error) {
 	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
 	defer cancel()
-	dynClient, err := dynamic.NewForConfig(config)
-	if err != nil {
-		return nil, fmt.Errorf("{GetBGPPeer} %s", err)
-	}
 
 	bgpPeerResource := schema.GroupVersionResource{
 		Group:    "crd.projectcalico.org",
@@ -104,7 +112,7 @@ func (c *Calico) GetBGPPeer(name string, config *rest.Config) (*BGPPeer, error)
 		Resource: "bgppeers",
 	}
 
-	peer, err := dynClient.Resource(bgpPeerResource).Get(ctx, name, metav1.GetOptions{})
+	peer, err := c.client.Resource(bgpPeerResource).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return nil, nil
@@ -127,13 +135,9 @@ func (c *Calico) GetBGPPeer(name string, config *rest.Config) (*BGPPeer, error)
 }
 
 // DeleteBGPPeer .
-func (c *Calico) DeleteBGPPeer(peer *BGPPeer, config *rest.Config) error {
+func (c *Calico) DeleteBGPPeer(peer *BGPPeer) error {
 	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
 	defer cancel()
-	dynClient, err := dynamic.NewForConfig(config)
-	if err != nil {
-		return fmt.Errorf("{DeleteBGPPeer} %s", err)
-	}
 
 	bgpPeerResource := schema.GroupVersionResource{
 		Group:    "crd.projectcalico.org",
@@ -141,7 +145,7 @@ func (c *Calico) DeleteBGPPeer(peer *BGPPeer, config *rest.Config) error {
 		Resource: "bgppeers",
 	}
 
-	err = dynClient.Resource(bgpPeerResource).Delete(ctx, peer.Name, metav1.DeleteOptions{})
+	err := c.client.Resource(bgpPeerResource).Delete(ctx, peer.Name, metav1.DeleteOptions{})
 	if err != nil {
 		return fmt.Errorf("{DeleteBGPPeer} %s", err)
 	}
@@ -149,13 +153,9 @@ func (c *Calico) DeleteBGPPeer(peer *BGPPeer, config *rest.Config) error {
 }
 
 // CreateBGPPeer .
-func (c *Calico) CreateBGPPeer(peer *BGPPeer, config *rest.Config) error {
+func (c *Calico) CreateBGPPeer(peer *BGPPeer) error {
 	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
 	defer cancel()
-	dynClient, err := dynamic.NewForConfig(config)
-	if err != nil {
-		return fmt.Errorf("{CreateBGPPeer} %s", err)
-	}
 
 	bgpPeerResource := schema.GroupVersionResource{
 		Group:    "crd.projectcalico.org",
@@ -172,7 +172,7 @@ func (c *Calico) CreateBGPPeer(peer *BGPPeer, config *rest.Config) error {
 		Object: m,
 	}
 
-	_, err = dynClient.Resource(bgpPeerResource).Create(ctx, obj, metav1.CreateOptions{})
+	_, err = c.client.Resource(bgpPeerResource).Create(ctx, obj, metav1.CreateOptions{})
 	if err != nil {
 		return fmt.Errorf("{CreateBGPPeer} %s", err)
 	}
@@ -180,13 +180,9 @@ func (c *Calico) CreateBGPPeer(peer *BGPPeer, config *rest.Config) error {
 }
 
 // UpdateBGPPeer .
-func (c *Calico) UpdateBGPPeer(peer *BGPPeer, config *rest.Config) error {
+func (c *Calico) UpdateBGPPeer(peer *BGPPeer) error {
 	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
 	defer cancel()
-	dynClient, err := dynamic.NewForConfig(config)
-	if err != nil {
-		return fmt.Errorf("{UpdateBGPPeer} %s", err)
-	}
 
 	bgpPeerResource := schema.GroupVersionResource{
 		Group:    "crd.projectcalico.org",
@@ -203,7 +199,7 @@ func (c *Calico) UpdateBGPPeer(peer *BGPPeer, config *rest.Config) error {
 		Object: m,
 	}
 
-	_, err = dynClient.Resource(bgpPeerResource).Update(ctx, obj, metav1.UpdateOptions{})
+	_, err = c.client.Resource(bgpPeerResource).Update(ctx, obj, metav1.UpdateOptions{})
 	if err != nil {
 		return fmt.Errorf("{UpdateBGPPeer} %s", err)
 	}
@@ -22,9 +22,8 @@ import (
 	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/rest"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // IPIPConfiguration .
@@ -92,39 +91,42 @@ type VXLANMode string
 type IPIPMode string
 
 // GetIPPool .
-func (c *Calico) GetIPPool(config *rest.Config) ([]*IPPool, error) {
+func (c *Calico) GetIPPool() ([]*IPPool, error) {
 	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
 	defer cancel()
-	dynClient, err := dynamic.NewForConfig(config)
-	if err != nil {
-		return nil, fmt.Errorf("{GetIPPool} %s", err)
-	}
-
-	bgpConfigurationResource := schema.GroupVersionResource{
-		Group:    "crd.projectcalico.org",
-		Version:  "v1",
-		Resource: "ippools",
-	}
 
-	list, err := dynClient.Resource(bgpConfigurationResource).List(ctx, metav1.ListOptions{})
+	list, err := c.client.Resource(IPPoolResource).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("{GetIPPool} %s", err)
 	}
 
 	var ipPools []*IPPool
-	for _, peer := range list.Items {
-		js, err := peer.MarshalJSON()
-		if err != nil {
-			return nil, fmt.Errorf("{GetIPPool} %s", err)
-		}
-
-		var ipPool *IPPool
-		err = json.Unmarshal(js, &ipPool)
+	for i := range list.Items {
+		ipPool, err := ParseIPPool(&list.Items[i])
 		if err != nil {
-			return nil, fmt.Errorf("{GetIPPool} %s", err)
+			return nil, err
 		}
-		ipPool.Name = ipPool.ObjectMeta.Name
 		ipPools = append(ipPools, ipPool)
 	}
 	return ipPools, nil
 }
+
+// ParseIPPool converts the IPPool got from the dynamic client or its informer.
+func ParseIPPool(obj runtime.Object) (*IPPool, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("{ParseIPPool} unexpected object %T", obj)
+	}
+	js, err := u.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("{ParseIPPool} %s", err)
+	}
+
+	var ipPool *IPPool
+	err = json.Unmarshal(js, &ipPool)
+	if err != nil {
+		return nil, fmt.Errorf("{ParseIPPool} %s", err)
+	}
+	ipPool.Name = ipPool.ObjectMeta.Name
+	return ipPool, nil
+}
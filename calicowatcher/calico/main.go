@@ -18,7 +18,13 @@ package calico
 
 import (
 	"context"
+	"fmt"
 	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 )
 
 var (
@@ -26,9 +32,26 @@ var (
 	contextTimeout = time.Duration(10 * time.Second)
 )
 
+var (
+	// BGPConfigurationResource is the Calico BGPConfiguration resource.
+	BGPConfigurationResource = schema.GroupVersionResource{
+		Group:    "crd.projectcalico.org",
+		Version:  "v1",
+		Resource: "bgpconfigurations",
+	}
+
+	// IPPoolResource is the Calico IPPool resource.
+	IPPoolResource = schema.GroupVersionResource{
+		Group:    "crd.projectcalico.org",
+		Version:  "v1",
+		Resource: "ippools",
+	}
+)
+
 // Calico .
 type Calico struct {
 	options Options
+	client  dynamic.Interface
 }
 
 // Options .
@@ -37,11 +60,33 @@ type Options struct {
 }
 
 // New creates the new calico client.
-func New(options Options) *Calico {
+func New(client dynamic.Interface, options Options) *Calico {
 	if options.ContextTimeout > 0 {
 		contextTimeout = time.Duration(time.Duration(options.ContextTimeout) * time.Second)
 	}
 	return &Calico{
 		options: options,
+		client:  client,
+	}
+}
+
+// IsMissingResource reports whether the error is caused by the Calico CRDs not being installed.
+func IsMissingResource(err error) bool {
+	if err == nil {
+		return false
+	}
+	return apierrors.IsNotFound(err) || err.Error() == "the server could not find the requested resource"
+}
+
+// Installed reports whether the Calico CRDs are served by the API server.
+func (c *Calico) Installed() (bool, error) {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	if _, err := c.client.Resource(BGPConfigurationResource).List(ctx, metav1.ListOptions{Limit: 1}); err != nil {
+		if IsMissingResource(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("{Installed} %s", err)
 	}
+	return true, nil
 }
@@ -0,0 +1,122 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package calico
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Node contains information about a Calico node resource.
+type Node struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              NodeSpec `json:"spec,omitempty"`
+}
+
+// NodeSpec contains the specification for a Calico node resource.
+type NodeSpec struct {
+	// BGP configuration for this node.
+	BGP *NodeBGPSpec `json:"bgp,omitempty"`
+}
+
+// NodeBGPSpec contains the specification for the Node BGP configuration.
+type NodeBGPSpec struct {
+	// The AS Number of the node.
+	ASNumber string `json:"asNumber,omitempty"`
+	// IPv4Address is the IPv4 address and network of this node.
+	IPv4Address string `json:"ipv4Address,omitempty"`
+	// IPv6Address is the IPv6 address and network of this node.
+	IPv6Address string `json:"ipv6Address,omitempty"`
+	// RouteReflectorClusterID enables this node as a route reflector within the given cluster.
+	RouteReflectorClusterID string `json:"routeReflectorClusterID,omitempty"`
+}
+
+var nodeResource = schema.GroupVersionResource{
+	Group:    "crd.projectcalico.org",
+	Version:  "v1",
+	Resource: "nodes",
+}
+
+// GetNodes .
+func (c *Calico) GetNodes() ([]*Node, error) {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+
+	list, err := c.client.Resource(nodeResource).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("{GetNodes} %s", err)
+	}
+
+	var nodes []*Node
+	for _, item := range list.Items {
+		js, err := item.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("{GetNodes} %s", err)
+		}
+
+		var node *Node
+		err = json.Unmarshal(js, &node)
+		if err != nil {
+			return nil, fmt.Errorf("{GetNodes} %s", err)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// SetNodeRouteReflector sets the route reflector cluster ID of the node together with the given annotations.
+// An empty cluster ID makes the node a regular one, annotations with empty values are removed.
+func (c *Calico) SetNodeRouteReflector(name, clusterID string, annotations map[string]string) error {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+
+	var id interface{}
+	if clusterID != "" {
+		id = clusterID
+	}
+	anns := make(map[string]interface{})
+	for key, value := range annotations {
+		if value == "" {
+			anns[key] = nil
+		} else {
+			anns[key] = value
+		}
+	}
+
+	payload := map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": anns},
+		"spec": map[string]interface{}{
+			"bgp": map[string]interface{}{"routeReflectorClusterID": id},
+		},
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("{SetNodeRouteReflector} %s", err)
+	}
+
+	_, err = c.client.Resource(nodeResource).Patch(ctx, name, types.MergePatchType, payloadBytes, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("{SetNodeRouteReflector} %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package calico
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Selector is a parsed Calico label selector, as used in IPPool and BGPPeer nodeSelector.
+type Selector interface {
+	Matches(labels map[string]string) bool
+}
+
+type selectorFunc func(labels map[string]string) bool
+
+func (f selectorFunc) Matches(labels map[string]string) bool {
+	return f(labels)
+}
+
+// ParseSelector parses the subset of the Calico selector syntax used for node selection:
+// all(), has(k), k == 'v', k != 'v', k in {'a', 'b'}, k not in {...}, !, &&, || and parentheses.
+// An empty selector matches all labels.
+func ParseSelector(s string) (Selector, error) {
+	tokens, err := tokenizeSelector(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return selectorFunc(func(map[string]string) bool { return true }), nil
+	}
+	p := &selectorParser{tokens: tokens}
+	sel, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector %q: %s", s, err)
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("invalid selector %q: unexpected %q", s, p.tokens[p.pos])
+	}
+	return sel, nil
+}
+
+func tokenizeSelector(s string) ([]string, error) {
+	tokens := []string{}
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case strings.HasPrefix(s[i:], "&&") || strings.HasPrefix(s[i:], "||") || strings.HasPrefix(s[i:], "==") || strings.HasPrefix(s[i:], "!="):
+			tokens = append(tokens, s[i:i+2])
+			i += 2
+		case strings.ContainsRune("!(){},", rune(c)):
+			tokens = append(tokens, string(c))
+			i++
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("invalid selector %q: unterminated string", s)
+			}
+			tokens = append(tokens, s[i:i+end+2])
+			i += end + 2
+		case isSelectorIdentChar(rune(c)):
+			start := i
+			for i < len(s) && isSelectorIdentChar(rune(s[i])) {
+				i++
+			}
+			tokens = append(tokens, s[start:i])
+		default:
+			return nil, fmt.Errorf("invalid selector %q: unexpected character %q", s, c)
+		}
+	}
+	return tokens, nil
+}
+
+func isSelectorIdentChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_./-", r)
+}
+
+type selectorParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *selectorParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *selectorParser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *selectorParser) expect(t string) error {
+	if got := p.next(); got != t {
+		return fmt.Errorf("expected %q, got %q", t, got)
+	}
+	return nil
+}
+
+func (p *selectorParser) parseOr() (Selector, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "||" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = selectorFunc(func(labels map[string]string) bool { return l.Matches(labels) || right.Matches(labels) })
+	}
+	return left, nil
+}
+
+func (p *selectorParser) parseAnd() (Selector, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "&&" {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = selectorFunc(func(labels map[string]string) bool { return l.Matches(labels) && right.Matches(labels) })
+	}
+	return left, nil
+}
+
+func (p *selectorParser) parseUnary() (Selector, error) {
+	if p.peek() == "!" {
+		p.next()
+		sel, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return selectorFunc(func(labels map[string]string) bool { return !sel.Matches(labels) }), nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *selectorParser) parsePrimary() (Selector, error) {
+	t := p.next()
+	switch t {
+	case "":
+		return nil, fmt.Errorf("unexpected end")
+	case "(":
+		sel, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return sel, p.expect(")")
+	case "all":
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		return selectorFunc(func(map[string]string) bool { return true }), p.expect(")")
+	case "has":
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		key := p.next()
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return selectorFunc(func(labels map[string]string) bool {
+			_, ok := labels[key]
+			return ok
+		}), nil
+	}
+
+	key := t
+	if !isSelectorIdentChar(rune(key[0])) {
+		return nil, fmt.Errorf("unexpected %q", key)
+	}
+	switch op := p.next(); op {
+	case "==", "!=":
+		value, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		equal := op == "=="
+		return selectorFunc(func(labels map[string]string) bool {
+			v, ok := labels[key]
+			return (ok && v == value) == equal
+		}), nil
+	case "in":
+		values, err := p.parseSet()
+		if err != nil {
+			return nil, err
+		}
+		return selectorFunc(func(labels map[string]string) bool {
+			v, ok := labels[key]
+			return ok && values[v]
+		}), nil
+	case "not":
+		if err := p.expect("in"); err != nil {
+			return nil, err
+		}
+		values, err := p.parseSet()
+		if err != nil {
+			return nil, err
+		}
+		return selectorFunc(func(labels map[string]string) bool {
+			v, ok := labels[key]
+			return !ok || !values[v]
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported operator %q", op)
+	}
+}
+
+func (p *selectorParser) parseString() (string, error) {
+	t := p.next()
+	if len(t) < 2 || (t[0] != '\'' && t[0] != '"') {
+		return "", fmt.Errorf("expected string, got %q", t)
+	}
+	return t[1 : len(t)-1], nil
+}
+
+func (p *selectorParser) parseSet() (map[string]bool, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	values := map[string]bool{}
+	for p.peek() != "}" {
+		v, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		values[v] = true
+		if p.peek() == "," {
+			p.next()
+		}
+	}
+	p.next()
+	return values, nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package calico
+
+import (
+	"testing"
+)
+
+func TestParseSelector(t *testing.T) {
+	worker := map[string]string{"role": "worker", "zone": "a", "kubernetes.io/os": "linux"}
+	master := map[string]string{"role": "master", "zone": "b"}
+	empty := map[string]string{}
+
+	tests := []struct {
+		selector string
+		labels   map[string]string
+		want     bool
+	}{
+		{"", worker, true},
+		{"", empty, true},
+		{"all()", worker, true},
+		{"all()", empty, true},
+		{" all ( ) ", empty, true},
+
+		{"has(role)", worker, true},
+		{"has(role)", empty, false},
+		{"has(kubernetes.io/os)", worker, true},
+		{"has(kubernetes.io/os)", master, false},
+		{"!has(role)", empty, true},
+		{"!has(role)", worker, false},
+
+		{"role == 'worker'", worker, true},
+		{"role == 'worker'", master, false},
+		{"role == 'worker'", empty, false},
+		{"role != 'worker'", worker, false},
+		{"role != 'worker'", master, true},
+		{"role != 'worker'", empty, true},
+		{`role == "worker"`, worker, true},
+		{"kubernetes.io/os == 'linux'", worker, true},
+		{"role == ''", empty, false},
+		{"role == 'a b'", map[string]string{"role": "a b"}, true},
+		{`role == 'say "hi"'`, map[string]string{"role": `say "hi"`}, true},
+		{`role == "it's"`, map[string]string{"role": "it's"}, true},
+
+		{"zone in {'a', 'c'}", worker, true},
+		{"zone in {'a', 'c'}", master, false},
+		{"zone in {'a', 'c'}", empty, false},
+		{"zone in {}", worker, false},
+		{"zone in {'a' 'c'}", worker, true},
+		{"zone not in {'a', 'c'}", worker, false},
+		{"zone not in {'a', 'c'}", master, true},
+		{"zone not in {'a', 'c'}", empty, true},
+
+		{"!role == 'worker'", worker, false},
+		{"!role == 'worker'", master, true},
+		{"!!has(role)", worker, true},
+
+		{"role == 'worker' && zone == 'a'", worker, true},
+		{"role == 'worker' && zone == 'b'", worker, false},
+		{"role == 'master' || zone == 'a'", worker, true},
+		{"role == 'master' || zone == 'c'", worker, false},
+		{"role == 'master' || role == 'worker' && zone == 'b'", worker, false},
+		{"role == 'master' || role == 'worker' && zone == 'b'", master, true},
+		{"role == 'worker' && zone == 'b' || role == 'master'", master, true},
+		{"(role == 'master' || role == 'worker') && zone == 'b'", worker, false},
+		{"(role == 'master' || role == 'worker') && zone == 'b'", master, true},
+		{"!(role == 'master' || zone == 'b') && has(role)", worker, true},
+		{"!(role == 'master' || zone == 'b') && has(role)", master, false},
+		{"((has(zone)))", master, true},
+	}
+
+	for _, tt := range tests {
+		sel, err := ParseSelector(tt.selector)
+		if err != nil {
+			t.Errorf("ParseSelector(%q) error: %s", tt.selector, err)
+			continue
+		}
+		if got := sel.Matches(tt.labels); got != tt.want {
+			t.Errorf("ParseSelector(%q).Matches(%v) = %v, want %v", tt.selector, tt.labels, got, tt.want)
+		}
+	}
+}
+
+func TestParseSelectorErrors(t *testing.T) {
+	tests := []string{
+		"role == 'worker",
+		"role == worker",
+		"role = 'worker'",
+		"role",
+		"role ==",
+		"has(role",
+		"has role",
+		"all(",
+		"(role == 'a'",
+		"role == 'a')",
+		"role == 'a' &&",
+		"|| role == 'a'",
+		"zone in 'a'",
+		"zone in {'a', 'b'",
+		"zone not {'a'}",
+		"role == 'a' role == 'b'",
+		"role ~ 'a'",
+		"!",
+	}
+
+	for _, selector := range tests {
+		if _, err := ParseSelector(selector); err == nil {
+			t.Errorf("ParseSelector(%q) succeeded, want error", selector)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package calicowatcher
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/calicowatcher/calico"
+	"github.com/r3labs/diff/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// nodeAnnotations are the Calico annotations of the node the cluster-wide processing depends on.
+var nodeAnnotations = []string{
+	"projectcalico.org/IPv4Address",
+	"projectcalico.org/IPv6Address",
+	"projectcalico.org/ASNumber",
+}
+
+// setupInformers registers event handlers for Nodes, CalicoIntegrations and the watcher BGPs and waits until their
+// caches are synced. Nothing is resynced, the processing is driven by the events of these and the Calico resources.
+// The Calico informers are set up once Calico is detected, see setupCalicoInformers.
+func (w *Watcher) setupInformers(stop <-chan struct{}) error {
+	factory := informers.NewSharedInformerFactory(w.clientset, 0)
+
+	nodeInformer := factory.Core().V1().Nodes()
+	nodeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			w.queue.Add(clusterKey)
+			w.enqueueNode(obj)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			if nodeChanged(old, obj) {
+				w.queue.Add(clusterKey)
+			}
+			w.enqueueNode(obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			w.queue.Add(clusterKey)
+			w.enqueueNode(obj)
+		},
+	})
+	w.nodeLister = nodeInformer.Lister()
+
+	if w.MGR != nil {
+		itgInformer, err := w.MGR.GetCache().GetInformer(cntxt, &v1alpha1.CalicoIntegration{})
+		if err != nil {
+			return fmt.Errorf("{setupInformers} %s", err)
+		}
+		itgInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(_ interface{}) { w.queue.Add(clusterKey) },
+			UpdateFunc: func(old, obj interface{}) {
+				// The status is written by the watcher itself.
+				oldItg, ok1 := old.(*v1alpha1.CalicoIntegration)
+				itg, ok2 := obj.(*v1alpha1.CalicoIntegration)
+				if !ok1 || !ok2 || !reflect.DeepEqual(oldItg.Spec, itg.Spec) {
+					w.queue.Add(clusterKey)
+				}
+			},
+			DeleteFunc: func(_ interface{}) { w.queue.Add(clusterKey) },
+		})
+
+		bgpInformer, err := w.MGR.GetCache().GetInformer(cntxt, &v1alpha1.BGP{})
+		if err != nil {
+			return fmt.Errorf("{setupInformers} %s", err)
+		}
+		bgpInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(old, obj interface{}) {
+				// The mesh follows the state of the sessions, the rest of the status is ignored.
+				oldBGP, ok1 := old.(*v1alpha1.BGP)
+				bgp, ok2 := obj.(*v1alpha1.BGP)
+				if ok1 && ok2 && watcherBGP(bgp) && bgpEstablished(oldBGP) != bgpEstablished(bgp) {
+					w.queue.Add(clusterKey)
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				// A BGP removed by hand is recreated by its node.
+				if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				if bgp, ok := obj.(*v1alpha1.BGP); ok && watcherBGP(bgp) {
+					if node := bgp.GetAnnotations()[nodeAnnotation]; node != "" {
+						w.queue.Add(node)
+					}
+				}
+			},
+		})
+	}
+
+	factory.Start(stop)
+	for informer, synced := range factory.WaitForCacheSync(stop) {
+		if !synced {
+			return fmt.Errorf("{setupInformers} failed to sync %s informer", informer)
+		}
+	}
+	if w.MGR != nil && !w.MGR.GetCache().WaitForCacheSync(stop) {
+		return fmt.Errorf("{setupInformers} failed to sync CalicoIntegration informer")
+	}
+	return nil
+}
+
+// setupCalicoInformers registers event handlers for IPPools and BGPConfigurations and waits until their caches are synced.
+func (w *Watcher) setupCalicoInformers(stop <-chan struct{}) error {
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(w.dynamicClient, 0)
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(_ interface{}) { w.queue.Add(clusterKey) },
+		UpdateFunc: func(old, obj interface{}) {
+			if resourceVersionChanged(old, obj) {
+				w.queue.Add(clusterKey)
+			}
+		},
+		DeleteFunc: func(_ interface{}) { w.queue.Add(clusterKey) },
+	}
+
+	ipPoolInformer := factory.ForResource(calico.IPPoolResource)
+	ipPoolInformer.Informer().AddEventHandler(handler)
+
+	bgpConfInformer := factory.ForResource(calico.BGPConfigurationResource)
+	bgpConfInformer.Informer().AddEventHandler(handler)
+
+	factory.Start(stop)
+	for resource, synced := range factory.WaitForCacheSync(stop) {
+		if !synced {
+			return fmt.Errorf("{setupCalicoInformers} failed to sync %s informer", resource.Resource)
+		}
+	}
+	w.ipPoolLister = ipPoolInformer.Lister()
+	w.bgpConfLister = bgpConfInformer.Lister()
+	return nil
+}
+
+func (w *Watcher) enqueueNode(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		logger.Error(fmt.Errorf("{enqueueNode} %s", err), "")
+		return
+	}
+	w.queue.Add(key)
+}
+
+// enqueueNodes enqueues the nodes whose BGPs don't match the cluster-wide state anymore and the nodes
+// skipped for the missing network. The other nodes are only processed on their own events.
+func (w *Watcher) enqueueNodes() {
+	existing := make(map[string]*v1alpha1.BGP)
+	for _, bgp := range w.data.bgpList {
+		existing[bgpKey(bgp)] = bgp
+	}
+
+	nodes := make(map[string]bool)
+	generated := make(map[string]bool)
+	for _, bgp := range w.data.generatedBGPs {
+		generated[bgpKey(bgp)] = true
+		node := bgp.GetAnnotations()[nodeAnnotation]
+		current, ok := existing[bgpKey(bgp)]
+		if !ok || current.GetAnnotations()[nodeAnnotation] != node {
+			nodes[node] = true
+		} else if changelog, _ := diff.Diff(current.Spec, bgp.Spec); len(changelog) > 0 {
+			nodes[node] = true
+		}
+	}
+	for _, bgp := range w.data.bgpList {
+		if node, ok := bgp.GetAnnotations()[nodeAnnotation]; ok && !generated[bgpKey(bgp)] {
+			nodes[node] = true
+		}
+	}
+	for _, node := range w.data.skippedNodes {
+		nodes[node] = true
+	}
+
+	for node := range nodes {
+		w.queue.Add(node)
+	}
+}
+
+// watcherBGP reports whether the BGP is managed by the watcher.
+func watcherBGP(bgp *v1alpha1.BGP) bool {
+	return bgp.GetAnnotations()["k8s.netris.ai/calicowatcher"] == "true"
+}
+
+// nodeChanged reports whether the change of the node affects the cluster-wide processing.
+func nodeChanged(old, obj interface{}) bool {
+	oldNode, ok1 := old.(*v1.Node)
+	node, ok2 := obj.(*v1.Node)
+	if !ok1 || !ok2 {
+		return true
+	}
+	if !reflect.DeepEqual(oldNode.Labels, node.Labels) {
+		return true
+	}
+	for _, ann := range nodeAnnotations {
+		if oldNode.Annotations[ann] != node.Annotations[ann] {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceVersionChanged filters out the periodic resyncs of the informers.
+func resourceVersionChanged(old, obj interface{}) bool {
+	oldMeta, err1 := meta.Accessor(old)
+	objMeta, err2 := meta.Accessor(obj)
+	if err1 != nil || err2 != nil {
+		return true
+	}
+	return oldMeta.GetResourceVersion() != objMeta.GetResourceVersion()
+}
+
+func (w *Watcher) processNextItem() bool {
+	key, quit := w.queue.Get()
+	if quit {
+		return false
+	}
+	defer w.queue.Done(key)
+
+	var err error
+	if key == clusterKey {
+		err = w.mainProcessing()
+	} else {
+		err = w.nodeProcessing(key.(string))
+	}
+	if err != nil {
+		logger.Error(err, "", "key", key)
+		w.queue.AddRateLimited(key)
+		return true
+	}
+	w.queue.Forget(key)
+	return true
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package calicowatcher
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/netrisai/netris-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// integration is the effective configuration of the Calico integration. It comes from
+// the CalicoIntegration resource or, when there is none, from the manage.k8s.netris.ai/calico
+// annotation of the BGPConfiguration and the operator's config.
+type integration struct {
+	object *v1alpha1.CalicoIntegration
+
+	enabled      bool
+	namespace    string
+	nodeSelector labels.Selector
+	defaultRoute bool
+	serviceCIDRs bool
+	inbound      []string
+	outbound     []string
+	password     string
+	passwordRef  *v1alpha1.CalicoBGPPassword
+	multihop     int
+	bfd          bool
+	meshFallback string
+
+	asnReclaimAfter time.Duration
+
+	meshMinHealthy   int
+	meshDisableDelay time.Duration
+	meshEnableDelay  time.Duration
+
+	rrSelector  labels.Selector
+	rrClusterID string
+}
+
+func defaultIntegration() integration {
+	return integration{
+		namespace:    "default",
+		nodeSelector: labels.Everything(),
+		defaultRoute: true,
+		serviceCIDRs: true,
+		meshFallback: "auto",
+
+		asnReclaimAfter: defaultASNReclaimAfter,
+
+		meshMinHealthy:   100,
+		meshDisableDelay: 60 * time.Second,
+		meshEnableDelay:  30 * time.Second,
+	}
+}
+
+// getIntegration loads the CalicoIntegration. Several of them are not supported,
+// the first one by name is used.
+func (w *Watcher) getIntegration() error {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+
+	itg := defaultIntegration()
+
+	list := &v1alpha1.CalicoIntegrationList{}
+	if err := w.client.List(ctx, list, &client.ListOptions{}); err != nil && !meta.IsNoMatchError(err) {
+		return err
+	}
+	if len(list.Items) == 0 {
+		itg.enabled = w.checkBGPConfigurations()
+		w.data.integration = itg
+		return nil
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+	if len(list.Items) > 1 {
+		logger.Info("Multiple CalicoIntegrations found, using the first one", "name", list.Items[0].Name)
+	}
+
+	obj := list.Items[0].DeepCopy()
+	spec := obj.Spec
+	itg.object = obj
+	itg.enabled = spec.Enabled
+
+	if spec.ASNRange != "" {
+		a, b, err := w.validateASNRange(spec.ASNRange)
+		if err != nil {
+			return err
+		}
+		w.data.asnStart = a
+		w.data.asnEnd = b
+	}
+	if spec.ASNReclaimAfter != nil {
+		itg.asnReclaimAfter = spec.ASNReclaimAfter.Duration
+	}
+	if spec.Namespace != "" {
+		itg.namespace = spec.Namespace
+	}
+	if spec.NodeSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(spec.NodeSelector)
+		if err != nil {
+			return fmt.Errorf("invalid nodeSelector: %s", err)
+		}
+		itg.nodeSelector = selector
+	}
+	if spec.PrefixListPolicy.DefaultRoute != nil {
+		itg.defaultRoute = *spec.PrefixListPolicy.DefaultRoute
+	}
+	if spec.PrefixListPolicy.ServiceCIDRs != nil {
+		itg.serviceCIDRs = *spec.PrefixListPolicy.ServiceCIDRs
+	}
+	itg.inbound = spec.PrefixListPolicy.Inbound
+	itg.outbound = spec.PrefixListPolicy.Outbound
+	itg.multihop = spec.BGP.Multihop
+	itg.bfd = spec.BGP.BFD
+	if spec.MeshFallback != "" {
+		itg.meshFallback = spec.MeshFallback
+	}
+	if spec.MeshMinHealthyPercent > 0 {
+		itg.meshMinHealthy = spec.MeshMinHealthyPercent
+	}
+	if spec.MeshDisableDelay != nil {
+		itg.meshDisableDelay = spec.MeshDisableDelay.Duration
+	}
+	if spec.MeshEnableDelay != nil {
+		itg.meshEnableDelay = spec.MeshEnableDelay.Duration
+	}
+	if rr := spec.RouteReflectors; rr != nil {
+		selector, err := metav1.LabelSelectorAsSelector(&rr.NodeSelector)
+		if err != nil {
+			return fmt.Errorf("invalid routeReflectors nodeSelector: %s", err)
+		}
+		itg.rrSelector = selector
+		itg.rrClusterID = defaultRouteReflectorClusterID
+		if rr.ClusterID != "" {
+			itg.rrClusterID = rr.ClusterID
+		}
+	}
+
+	if spec.BGP.Password != nil && itg.enabled {
+		ref := spec.BGP.Password.DeepCopy()
+		if ref.Namespace == "" {
+			ref.Namespace = "calico-system"
+		}
+		// The BGPPeer references the secret by name only, Calico resolves it in its own namespace.
+		if ref.Namespace != "calico-system" && ref.Namespace != "kube-system" {
+			return fmt.Errorf("bgp password secret must be in the calico-node namespace calico-system or kube-system, got %s", ref.Namespace)
+		}
+		secret, err := w.clientset.CoreV1().Secrets(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		password, ok := secret.Data[ref.Key]
+		if !ok {
+			return fmt.Errorf("key %s is missing in secret %s/%s", ref.Key, ref.Namespace, ref.Name)
+		}
+		itg.password = string(password)
+		itg.passwordRef = ref
+	}
+
+	w.data.integration = itg
+	return nil
+}
+
+// prefixListFamily filters the prefix list entries by the IP family of their prefix.
+// Entries without a parsable prefix are kept for both families.
+func prefixListFamily(entries []string, ipv6 bool) []string {
+	filtered := []string{}
+	for _, entry := range entries {
+		fields := strings.Fields(entry)
+		if len(fields) > 1 {
+			if ip, _, err := net.ParseCIDR(fields[1]); err == nil && (ip.To4() == nil) != ipv6 {
+				continue
+			}
+		}
+		filtered = append(filtered, entry)
+	}
+	return filtered
+}
+
+// bgpEstablished reports whether the BGP session is up and receives prefixes.
+func bgpEstablished(bgp *v1alpha1.BGP) bool {
+	return (bgp.Status.BGPStatus == "Active" || bgp.Status.BGPStatus == "Established") && bgp.Status.BGPPrefixes > 0
+}
+
+// updateIntegrationStatus publishes the state of the node sessions to the CalicoIntegration.
+func (w *Watcher) updateIntegrationStatus(processErr error) {
+	obj := w.data.integration.object
+	if obj == nil {
+		return
+	}
+
+	status := v1alpha1.CalicoIntegrationStatus{Status: "OK"}
+	if !w.data.integration.enabled {
+		status.Status = "Disabled"
+	}
+	if processErr != nil {
+		status.Status = "Failure"
+		status.Message = processErr.Error()
+	}
+
+	if len(w.data.bgpConfs) > 0 && w.data.bgpConfs[0].Spec.NodeToNodeMeshEnabled != nil {
+		status.NodeToNodeMesh = "disabled"
+		if *w.data.bgpConfs[0].Spec.NodeToNodeMeshEnabled {
+			status.NodeToNodeMesh = "enabled"
+		}
+	}
+
+	existing := make(map[string]*v1alpha1.BGP)
+	for _, bgp := range w.data.bgpList {
+		existing[bgpKey(bgp)] = bgp
+	}
+
+	for _, genBGP := range w.data.generatedBGPs {
+		session := v1alpha1.CalicoSessionStatus{
+			Node:     w.data.bgpNodes[genBGP.Name],
+			BGP:      genBGP.Name,
+			RemoteIP: genBGP.Spec.RemoteIP,
+			State:    "Pending",
+		}
+		if bgp, ok := existing[bgpKey(genBGP)]; ok {
+			if bgp.Status.BGPStatus != "" {
+				session.State = bgp.Status.BGPStatus
+			}
+			session.Prefixes = bgp.Status.BGPPrefixes
+		}
+		status.Sessions = append(status.Sessions, session)
+	}
+	sort.Slice(status.Sessions, func(i, j int) bool {
+		if status.Sessions[i].Node != status.Sessions[j].Node {
+			return status.Sessions[i].Node < status.Sessions[j].Node
+		}
+		return status.Sessions[i].BGP < status.Sessions[j].BGP
+	})
+	if established, total := w.sessionsHealth(); total > 0 {
+		status.Established = fmt.Sprintf("%d/%d", established, total)
+	}
+
+	status.ModifiedDate = obj.Status.ModifiedDate
+	if reflect.DeepEqual(obj.Status, status) {
+		return
+	}
+	status.ModifiedDate = metav1.Now()
+	obj.Status = status
+
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	if err := w.client.Status().Update(ctx, obj); err != nil {
+		logger.Error(err, "couldn't update CalicoIntegration status", "name", obj.Name)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package calicowatcher
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/watchercore/watchercoretest"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPrefixListFamily(t *testing.T) {
+	entries := []string{
+		"permit 10.10.0.0/16 le 24",
+		"deny 192.0.2.0/24",
+		"permit fd00:10::/48 le 64",
+		"deny 2001:db8::/32",
+		"permit any",
+		"permit",
+	}
+
+	want4 := []string{"permit 10.10.0.0/16 le 24", "deny 192.0.2.0/24", "permit any", "permit"}
+	if got := prefixListFamily(entries, false); !reflect.DeepEqual(got, want4) {
+		t.Errorf("IPv4 entries = %v, want %v", got, want4)
+	}
+	want6 := []string{"permit fd00:10::/48 le 64", "deny 2001:db8::/32", "permit any", "permit"}
+	if got := prefixListFamily(entries, true); !reflect.DeepEqual(got, want6) {
+		t.Errorf("IPv6 entries = %v, want %v", got, want6)
+	}
+	if got := prefixListFamily(nil, false); len(got) != 0 {
+		t.Errorf("entries of an empty list = %v", got)
+	}
+}
+
+func TestGetIntegrationPassword(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		wantErr   string
+	}{
+		{name: "default namespace", namespace: ""},
+		{name: "manifest installation", namespace: "kube-system"},
+		{name: "other namespace", namespace: "default", wantErr: "calico-node namespace"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secretNamespace := tt.namespace
+			if secretNamespace == "" {
+				secretNamespace = "calico-system"
+			}
+			secret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "bgp", Namespace: secretNamespace},
+				Data:       map[string][]byte{"password": []byte("secret")},
+			}
+			itg := &v1alpha1.CalicoIntegration{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec: v1alpha1.CalicoIntegrationSpec{
+					Enabled: true,
+					BGP: v1alpha1.CalicoBGPOptions{
+						Password: &v1alpha1.CalicoBGPPassword{Namespace: tt.namespace, Name: "bgp", Key: "password"},
+					},
+				},
+			}
+			w := &Watcher{
+				client:    watchercoretest.NewClient(t, itg),
+				clientset: kubefake.NewSimpleClientset(secret),
+			}
+
+			err := w.getIntegration()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("getIntegration() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ref := w.data.integration.passwordRef; w.data.integration.password != "secret" || ref == nil || ref.Namespace != secretNamespace {
+				t.Errorf("password = %q, ref = %+v", w.data.integration.password, ref)
+			}
+		})
+	}
+}
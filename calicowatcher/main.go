@@ -22,24 +22,29 @@ import (
 	"fmt"
 	"net"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/go-logr/logr"
 	"github.com/netrisai/netris-operator/api/v1alpha1"
 	"github.com/netrisai/netris-operator/calicowatcher/calico"
-	"github.com/netrisai/netris-operator/configloader"
 	"github.com/netrisai/netris-operator/netrisstorage"
 	"github.com/netrisai/netriswebapi/v2/types/site"
 	"github.com/netrisai/netriswebapi/v2/types/vnet"
 	"github.com/r3labs/diff/v2"
 	"go.uber.org/zap/zapcore"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -48,50 +53,84 @@ import (
 
 var (
 	requeueInterval = time.Duration(10 * time.Second)
-	logger          logr.Logger
-	debugLogger     logr.InfoLogger
+	logger          = ctrl.Log.WithName("CalicoWatcher")
+	debugLogger     = logger.V(int(zapcore.WarnLevel))
 	cntxt           = context.Background()
 	contextTimeout  = requeueInterval
 )
 
+const (
+	// clusterKey is the queue key of the cluster-wide processing. It can't clash with a node name,
+	// node names never contain a slash.
+	clusterKey = "/cluster"
+
+	// nodeAnnotation records the node the BGP is generated for.
+	nodeAnnotation = "k8s.netris.ai/calico-node"
+)
+
 // Watcher is the main structure in order to manage calicowatcher
 type Watcher struct {
-	Options    Options
-	NStorage   *netrisstorage.Storage
-	MGR        manager.Manager
-	Calico     *calico.Calico
-	restClient *rest.Config
-	client     client.Client
-	clientset  *kubernetes.Clientset
-	data       data
-	stop       chan struct{}
+	Options  Options
+	NStorage *netrisstorage.Storage
+	MGR      manager.Manager
+	Calico   *calico.Calico
+
+	client        client.Client
+	clientset     kubernetes.Interface
+	dynamicClient dynamic.Interface
+	recorder      record.EventRecorder
+	queue         workqueue.RateLimitingInterface
+	nodeLister    corelisters.NodeLister
+	ipPoolLister  cache.GenericLister
+	bgpConfLister cache.GenericLister
+	stop          chan struct{}
+
+	data           data
+	meshHold       *meshHold
+	calicoNotFound bool
 }
 
 type data struct {
+	// loaded is set once the cluster-wide state the nodes are processed with is complete.
+	loaded        bool
 	deleteMode    bool
 	generatedBGPs []*v1alpha1.BGP
 	bgpList       []*v1alpha1.BGP
 	bgpConfs      []*calico.BGPConfiguration
+	integration   integration
+	bgpNodes      map[string]string
 
 	nodesMap   map[string]*nodeIP
-	vnetName   string
 	switchName string
+	// skippedNodes are the nodes whose site, VNet or gateway is not found in Netris.
+	skippedNodes []string
+	// expectedSessions is the number of sessions of all nodes that should peer with the fabric,
+	// including the nodes skipped because their VNet or gateway is not found.
+	expectedSessions int
 
 	nodes        *v1.NodeList
-	site         *site.Site
-	vnetGW       string
-	vnetGWIP     string
-	blockSize    int
-	clusterCIDR  string
+	ipPools      []ipPool
 	serviceCIDRs []string
 	asnStart     int
 	asnEnd       int
 }
 
+// ipPool is an enabled Calico IPPool with its parsed nodeSelector.
+type ipPool struct {
+	CIDR         string
+	IPv6         bool
+	BlockSize    int
+	NodeSelector calico.Selector
+}
+
 // Options is the main options struct.
 type Options struct {
 	RequeueInterval int
 	LogLevel        string
+	// ASNRange is the range the AS numbers of the nodes are assigned from.
+	ASNRange string
+	// Namespace is where the ASN ledger is kept.
+	Namespace string
 }
 
 // NewWatcher is the main initialization function.
@@ -104,44 +143,37 @@ func NewWatcher(nStorage *netrisstorage.Storage, mgr manager.Manager, options Op
 		NStorage: nStorage,
 		MGR:      mgr,
 		Options:  options,
-		Calico:   calico.New(calico.Options{ContextTimeout: options.RequeueInterval}),
 		stop:     make(chan struct{}),
 	}
 	return watcher, nil
 }
 
-func (w *Watcher) getRestConfig() *rest.Config {
-	return ctrl.GetConfigOrDie()
-}
-
-func (w *Watcher) start() {
-	w.restClient = w.getRestConfig()
-	w.client = w.MGR.GetClient()
-	clientset, err := kubernetes.NewForConfig(w.restClient)
-	if err != nil {
-		logger.Error(err, "")
-		return
+// initClients creates the clients which are not set yet, so the tests can provide fake ones.
+func (w *Watcher) initClients() error {
+	if w.client == nil {
+		w.client = w.MGR.GetClient()
 	}
-	w.clientset = clientset
-	// recorder, w, _ := eventRecorder(clientset)
-	// defer w.Stop()
-	w.data = data{}
-	if len(configloader.Root.CalicoASNRange) > 0 {
-		a, b, err := w.validateASNRange(configloader.Root.CalicoASNRange)
-		if err != nil {
-			logger.Error(err, "")
-			return
+	if w.clientset == nil || w.dynamicClient == nil {
+		restConfig := ctrl.GetConfigOrDie()
+		if w.clientset == nil {
+			clientset, err := kubernetes.NewForConfig(restConfig)
+			if err != nil {
+				return err
+			}
+			w.clientset = clientset
+		}
+		if w.dynamicClient == nil {
+			dynamicClient, err := dynamic.NewForConfig(restConfig)
+			if err != nil {
+				return err
+			}
+			w.dynamicClient = dynamicClient
 		}
-		w.data.asnStart = a
-		w.data.asnEnd = b
-	} else {
-		w.data.asnStart = 4230000000
-		w.data.asnEnd = 4239999999
 	}
-	err = w.mainProcessing()
-	if err != nil {
-		logger.Error(err, "")
+	if w.Calico == nil {
+		w.Calico = calico.New(w.dynamicClient, calico.Options{ContextTimeout: w.Options.RequeueInterval})
 	}
+	return nil
 }
 
 // Start .
@@ -160,17 +192,27 @@ func (w *Watcher) Start() {
 		contextTimeout = requeueInterval
 	}
 
-	ticker := time.NewTicker(requeueInterval)
-	w.start()
-	for {
-		select {
-		case <-ticker.C:
-			w.start()
-		case <-w.stop:
-			ticker.Stop()
-			close(w.stop)
-			return
-		}
+	if err := w.initClients(); err != nil {
+		logger.Error(err, "")
+		return
+	}
+	if w.recorder == nil {
+		w.recorder, _, _ = eventRecorder(w.clientset)
+	}
+	w.queue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "calicowatcher")
+	defer w.queue.ShutDown()
+	defer close(w.stop)
+
+	if err := w.setupInformers(w.stop); err != nil {
+		logger.Error(err, "")
+		return
+	}
+
+	// The events drive the processing from here on, only the detection of Calico installed after the operator
+	// and the mesh hold-down schedule the cluster-wide processing themselves.
+	w.queue.Add(clusterKey)
+
+	for w.processNextItem() {
 	}
 }
 
@@ -185,9 +227,17 @@ func (w *Watcher) process() error {
 		return err
 	}
 
-	debugLogger.Info("Filling Nodes AS numbers", "deleteMode", w.data.deleteMode)
-	if err := w.fillNodesASNs(); err != nil {
-		return err
+	if w.data.integration.rrSelector != nil {
+		// Route reflection works within a single AS, so the nodes fall back to the cluster-wide one.
+		debugLogger.Info("Deleting Nodes ASN annotation for route reflection", "deleteMode", w.data.deleteMode)
+		if err := w.deleteNodesASNs(); err != nil {
+			return err
+		}
+	} else {
+		debugLogger.Info("Filling Nodes AS numbers", "deleteMode", w.data.deleteMode)
+		if err := w.fillNodesASNs(); err != nil {
+			return err
+		}
 	}
 
 	debugLogger.Info("Nodes Processing", "deleteMode", w.data.deleteMode)
@@ -201,94 +251,33 @@ func (w *Watcher) process() error {
 	}
 
 	debugLogger.Info("Getting BGP list from k8s", "deleteMode", w.data.deleteMode)
-	bgps, err := w.getBGPs()
+	bgps, err := w.getWatcherBGPs()
 	if err != nil {
 		return err
 	}
+	w.data.bgpList = bgps
 
-	for _, bgp := range bgps.Items {
-		if ann, ok := bgp.GetAnnotations()["k8s.netris.ai/calicowatcher"]; ok && ann == "true" {
-			w.data.bgpList = append(w.data.bgpList, bgp.DeepCopy())
-		}
-	}
-
-	bgpsForCreate, bgpsForDelete, bgpsForUpdate := w.compareBGPs()
-
-	js, _ := json.Marshal(bgpsForCreate)
-	debugLogger.Info("BGPs for create", "List", string(js), "deleteMode", w.data.deleteMode)
-	js, _ = json.Marshal(bgpsForDelete)
-	debugLogger.Info("BGPs for delete", "List", string(js), "deleteMode", w.data.deleteMode)
-	js, _ = json.Marshal(bgpsForUpdate)
-	debugLogger.Info("BGPs for update", "List", string(js), "deleteMode", w.data.deleteMode)
+	w.data.loaded = true
 
-	var errors []error
-	errors = append(errors, w.deleteBGPs(bgpsForDelete)...)
-	errors = append(errors, w.updateBGPs(bgpsForUpdate)...)
-	errors = append(errors, w.createBGPs(bgpsForCreate)...)
-	if len(errors) > 0 {
-		fmt.Println(errors)
+	// The nodes process their own BGPs, the ones left by the nodes which are gone are removed here.
+	orphans := w.orphanBGPs()
+	js, _ := json.Marshal(orphans)
+	debugLogger.Info("Orphan BGPs for delete", "List", string(js), "deleteMode", w.data.deleteMode)
+	if errors := w.deleteBGPs(orphans); len(errors) > 0 {
+		return fmt.Errorf("{process} %v", errors)
 	}
+	w.enqueueNodes()
 
-	debugLogger.Info("Getting netris-controller peer", "deleteMode", w.data.deleteMode)
-	netrisPeer, err := w.Calico.GetBGPPeer("netris-controller", w.restClient)
-	if err != nil {
+	if err := w.netrisPeersProcessing(); err != nil {
 		return err
 	}
 
-	debugLogger.Info("Generating netris-controller peer", "deleteMode", w.data.deleteMode)
-	peer := w.Calico.GenerateBGPPeer("netris-controller", "", w.data.vnetGWIP, w.data.site.PublicAsn)
-
-	if netrisPeer == nil {
-		debugLogger.Info("Creating netris-controller peer", "deleteMode", w.data.deleteMode)
-		if err := w.Calico.CreateBGPPeer(peer, w.restClient); err != nil {
-			return err
-		}
-		logger.Info("netris-controller peer created", "deleteMode", w.data.deleteMode)
-	} else {
-		changelog, _ := diff.Diff(netrisPeer.Spec, peer.Spec)
-		if len(changelog) > 0 {
-			debugLogger.Info("Updating netris-controller peer", "deleteMode", w.data.deleteMode)
-			netrisPeer.Spec = peer.Spec
-			if err := w.Calico.UpdateBGPPeer(netrisPeer, w.restClient); err != nil {
-				return err
-			}
-			logger.Info("netris-controller peer updated", "deleteMode", w.data.deleteMode)
-		}
-	}
-
-	bgpActive := len(w.data.bgpList) > 0
-	for _, bgp := range w.data.bgpList {
-		if !((bgp.Status.BGPStatus == "Active" || bgp.Status.BGPStatus == "Established") && bgp.Status.BGPPrefixes > 0) {
-			bgpActive = false
-			break
-		}
-	}
-
-	if w.data.bgpConfs[0].Spec.NodeToNodeMeshEnabled == nil {
-		w.data.bgpConfs[0].Spec.NodeToNodeMeshEnabled = &bgpActive
-	}
-
-	if bgpActive {
-		if *w.data.bgpConfs[0].Spec.NodeToNodeMeshEnabled {
-			debugLogger.Info("All BGPs are established", "deleteMode", w.data.deleteMode)
-			debugLogger.Info("Disabling NodeToNodeMesh in BGP Configuration", "deleteMode", w.data.deleteMode)
-			if err := w.updateBGPConfMesh(false); err != nil {
-				return err
-			}
-			logger.Info("NodeToNodeMesh disabled in BGP Configuration", "deleteMode", w.data.deleteMode)
-		}
-	} else {
-		if !*w.data.bgpConfs[0].Spec.NodeToNodeMeshEnabled {
-			debugLogger.Info("BGPs are not established", "deleteMode", w.data.deleteMode)
-			debugLogger.Info("Enabling NodeToNodeMesh in BGP Configuration", "deleteMode", w.data.deleteMode)
-			if err := w.updateBGPConfMesh(true); err != nil {
-				return err
-			}
-			logger.Info("NodeToNodeMesh enabled in BGP Configuration", "deleteMode", w.data.deleteMode)
-		}
+	if err := w.routeReflectorsProcessing(); err != nil {
+		return err
 	}
 
-	return nil
+	healthy, total := w.sessionsHealth()
+	return w.meshProcessing(healthy, total)
 }
 
 func (w *Watcher) deleteNodesProcessing() error {
@@ -301,6 +290,11 @@ func (w *Watcher) deleteNodesProcessing() error {
 	if err := w.deleteNodesASNs(); err != nil {
 		return err
 	}
+
+	debugLogger.Info("Deleting ASN ledger", "deleteMode", w.data.deleteMode)
+	if err := w.deleteASNLedger(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -331,15 +325,11 @@ func (w *Watcher) deleteNodesASNs() error {
 }
 
 func (w *Watcher) deleteProcess() error {
-	f := false
-	if w.data.bgpConfs[0].Spec.NodeToNodeMeshEnabled == nil {
-		w.data.bgpConfs[0].Spec.NodeToNodeMeshEnabled = &f
-	}
-	if !*w.data.bgpConfs[0].Spec.NodeToNodeMeshEnabled {
-		if err := w.updateBGPConfMesh(true); err != nil {
+	meshEnabled := w.data.bgpConfs[0].Spec.NodeToNodeMeshEnabled
+	if w.data.integration.meshFallback != "manual" && (meshEnabled == nil || !*meshEnabled) {
+		if err := w.setMesh(true, "MeshEnabled", "Calico integration is disabled"); err != nil {
 			return err
 		}
-		logger.Info("NodeToNodeMesh enabled in BGP Configuration", "deleteMode", w.data.deleteMode)
 	}
 
 	if err := w.deleteNodesProcessing(); err != nil {
@@ -349,18 +339,13 @@ func (w *Watcher) deleteProcess() error {
 	w.data.generatedBGPs = []*v1alpha1.BGP{}
 
 	debugLogger.Info("Geting BGPs from k8s", "deleteMode", w.data.deleteMode)
-	bgps, err := w.getBGPs()
+	bgps, err := w.getWatcherBGPs()
 	if err != nil {
 		return err
 	}
+	w.data.bgpList = bgps
 
-	for _, bgp := range bgps.Items {
-		if ann, ok := bgp.GetAnnotations()["k8s.netris.ai/calicowatcher"]; ok && ann == "true" {
-			w.data.bgpList = append(w.data.bgpList, bgp.DeepCopy())
-		}
-	}
-
-	_, bgpsForDelete, _ := w.compareBGPs()
+	_, bgpsForDelete, _ := compareBGPs(w.data.generatedBGPs, w.data.bgpList)
 
 	js, _ := json.Marshal(bgpsForDelete)
 	debugLogger.Info("BGPs for delete", "List", string(js), "deleteMode", w.data.deleteMode)
@@ -371,33 +356,60 @@ func (w *Watcher) deleteProcess() error {
 		fmt.Println(errors)
 	}
 
-	debugLogger.Info("Geting netris-controller peer", "deleteMode", w.data.deleteMode)
-	netrisPeer, err := w.Calico.GetBGPPeer("netris-controller", w.restClient)
+	debugLogger.Info("Geting netris-controller peers", "deleteMode", w.data.deleteMode)
+	netrisPeers, err := w.getNetrisPeers()
 	if err != nil {
 		return err
 	}
 
-	if netrisPeer != nil {
-		debugLogger.Info("Deleting netris-controller peer", "deleteMode", w.data.deleteMode)
-		if err := w.Calico.DeleteBGPPeer(netrisPeer, w.restClient); err != nil {
+	for _, netrisPeer := range netrisPeers {
+		debugLogger.Info("Deleting netris-controller peer", "peer", netrisPeer.Name, "deleteMode", w.data.deleteMode)
+		if err := w.Calico.DeleteBGPPeer(netrisPeer); err != nil {
 			return err
 		}
-		logger.Info("Peers in netris-controller are deleted", "deleteMode", w.data.deleteMode)
+		logger.Info("Peer in netris-controller is deleted", "peer", netrisPeer.Name, "deleteMode", w.data.deleteMode)
 	}
 
-	return nil
+	return w.routeReflectorsProcessing()
 }
 
+// mainProcessing is the cluster-wide processing. It loads the state the nodes are processed with,
+// assigns the AS numbers and manages the Calico peers and the node-to-node mesh.
 func (w *Watcher) mainProcessing() error {
-	var err error
-	if w.data.bgpConfs, err = w.Calico.GetBGPConfiguration(w.restClient); err != nil {
-		if calico.IsMissingResource(err) {
-			logger.Info(err.Error())
-			logger.Info("Calico CNI not detected")
-			logger.Info("Calico Watcher Stopped")
-			w.stop <- struct{}{}
+	w.data = data{}
+	if w.bgpConfLister == nil {
+		installed, err := w.Calico.Installed()
+		if err != nil {
+			return err
+		}
+		if !installed {
+			if !w.calicoNotFound {
+				logger.Info("Calico CNI not detected, waiting for it to be installed")
+				w.calicoNotFound = true
+			}
+			// There are no Calico resources to watch yet, so the detection is polled.
+			w.queue.AddAfter(clusterKey, requeueInterval)
 			return nil
 		}
+		logger.Info("Calico CNI detected")
+		if err := w.setupCalicoInformers(w.stop); err != nil {
+			return err
+		}
+	}
+
+	w.data.asnStart = 4230000000
+	w.data.asnEnd = 4239999999
+	if len(w.Options.ASNRange) > 0 {
+		a, b, err := w.validateASNRange(w.Options.ASNRange)
+		if err != nil {
+			return err
+		}
+		w.data.asnStart = a
+		w.data.asnEnd = b
+	}
+
+	var err error
+	if w.data.bgpConfs, err = w.getBGPConfigurations(); err != nil {
 		return err
 	}
 
@@ -406,87 +418,148 @@ func (w *Watcher) mainProcessing() error {
 		return nil
 	}
 
-	if !w.checkBGPConfigurations() {
+	if err := w.getIntegration(); err != nil {
+		return err
+	}
+
+	if !w.data.integration.enabled {
 		w.data.deleteMode = true
 	}
 
 	if w.data.deleteMode {
-		debugLogger.Info("manage.k8s.netris.ai/calico is missing in BGP Configuration", "deleteMode", w.data.deleteMode)
+		debugLogger.Info("Calico integration is disabled", "deleteMode", w.data.deleteMode)
 		debugLogger.Info("Clearing Netris staff", "deleteMode", w.data.deleteMode)
-		return w.deleteProcess()
+		err = w.deleteProcess()
+	} else {
+		debugLogger.Info("Calico integration is enabled", "deleteMode", w.data.deleteMode)
+		debugLogger.Info("Creating Netris staff", "deleteMode", w.data.deleteMode)
+		err = w.process()
 	}
-	debugLogger.Info("manage.k8s.netris.ai/calico is present in BGP Configuration", "deleteMode", w.data.deleteMode)
-	debugLogger.Info("Creating Netris staff", "deleteMode", w.data.deleteMode)
-	return w.process()
+	w.updateIntegrationStatus(err)
+	return err
 }
 
 func (w *Watcher) updateBGPConfMesh(enabled bool) error {
 	if len(w.data.bgpConfs) > 0 {
 		bgpConf := w.data.bgpConfs[0]
-		*bgpConf.Spec.NodeToNodeMeshEnabled = enabled
-		return w.Calico.UpdateBGPConfiguration(bgpConf, w.restClient)
+		bgpConf.Spec.NodeToNodeMeshEnabled = &enabled
+		return w.Calico.UpdateBGPConfiguration(bgpConf)
 	}
 	return fmt.Errorf("BGPConfiguration is missing in calico")
 }
 
 func (w *Watcher) generateBGPs() error {
 	generatedBGPs := []*v1alpha1.BGP{}
+	bgpNodes := make(map[string]string)
 
-	nameReg, _ := regexp.Compile("[^a-z0-9.]+")
 	for name, node := range w.data.nodesMap {
-		asn, err := strconv.Atoi(node.ASN)
+		bgps, err := w.generateNodeBGPs(name, node)
 		if err != nil {
 			return err
 		}
+		for _, bgp := range bgps {
+			bgpNodes[bgp.Name] = name
+		}
+		generatedBGPs = append(generatedBGPs, bgps...)
+	}
+	w.data.generatedBGPs = generatedBGPs
+	w.data.bgpNodes = bgpNodes
+	return nil
+}
 
-		// Get the network address using node IP
-		_, ipipNetAddr, err := net.ParseCIDR(fmt.Sprintf("%s/%d", node.IPIP, w.data.blockSize))
-		if err != nil {
-			return fmt.Errorf("node ip: %s", err)
+// generateNodeBGPs generates the BGP sessions of the node, one for each IP family it is peered over.
+func (w *Watcher) generateNodeBGPs(name string, node *nodeIP) ([]*v1alpha1.BGP, error) {
+	asn, err := strconv.Atoi(node.ASN)
+	if err != nil {
+		return nil, err
+	}
+
+	bgps := []*v1alpha1.BGP{}
+	for _, ipv6 := range []bool{false, true} {
+		if (!ipv6 && (node.IP == "" || node.GW == "")) || (ipv6 && (node.IPv6 == "" || node.GW6 == "")) {
+			continue
 		}
+		bgps = append(bgps, w.generateBGP(name, node, asn, ipv6))
+	}
+	return bgps, nil
+}
+
+// generateBGP generates the IPv4 or IPv6 BGP session of the node with the VNet gateway.
+func (w *Watcher) generateBGP(name string, node *nodeIP, asn int, ipv6 bool) *v1alpha1.BGP {
+	nameReg, _ := regexp.Compile("[^a-z0-9.]+")
+	itg := w.data.integration
 
-		PrefixListInboundList := []string{fmt.Sprintf("permit %s", ipipNetAddr.String())}
-		for _, cidr := range w.data.serviceCIDRs {
-			PrefixListInboundList = append(PrefixListInboundList, fmt.Sprintf("permit %s le %d", cidr, 32))
+	remoteIP, localIP, vnetName, defaultRoute, maxLength := node.IP, node.GW, node.VNet, "0.0.0.0/0", 32
+	if ipv6 {
+		remoteIP, localIP, vnetName, defaultRoute, maxLength = node.IPv6, node.GW6, node.VNet6, "::/0", 128
+	}
+
+	// The node advertises the blocks it got from the pools selecting it,
+	// and all pool blocks are advertised back to it. Route reflectors
+	// advertise the blocks of all nodes.
+	PrefixListInboundList := []string{}
+	PrefixListOutboundList := []string{}
+	if itg.defaultRoute {
+		PrefixListOutboundList = append(PrefixListOutboundList, fmt.Sprintf("permit %s", defaultRoute))
+	}
+	for _, pool := range w.data.ipPools {
+		if pool.IPv6 != ipv6 {
+			continue
 		}
+		if pool.NodeSelector.Matches(node.Labels) || itg.rrSelector != nil {
+			PrefixListInboundList = append(PrefixListInboundList, fmt.Sprintf("permit %s ge %d le %d", pool.CIDR, pool.BlockSize, maxLength))
+		}
+		PrefixListOutboundList = append(PrefixListOutboundList, fmt.Sprintf("permit %s le %d", pool.CIDR, pool.BlockSize))
+	}
+	for _, cidr := range w.data.serviceCIDRs {
+		if !itg.serviceCIDRs || isIPv6CIDR(cidr) != ipv6 {
+			continue
+		}
+		PrefixListInboundList = append(PrefixListInboundList, fmt.Sprintf("permit %s le %d", cidr, maxLength))
+	}
+	PrefixListInboundList = append(PrefixListInboundList, prefixListFamily(itg.inbound, ipv6)...)
+	PrefixListOutboundList = append(PrefixListOutboundList, prefixListFamily(itg.outbound, ipv6)...)
 
-		name := fmt.Sprintf("%s-%s", name, strings.Split(node.IP, "/")[0])
+	bfd := ""
+	if itg.bfd {
+		bfd = "enabled"
+	}
 
-		bgp := &v1alpha1.BGP{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      nameReg.ReplaceAllString(name, "-"),
-				Namespace: "default",
-			},
-			TypeMeta: metav1.TypeMeta{
-				Kind:       "BGP",
-				APIVersion: "k8s.netris.ai/v1alpha1",
-			},
-			Spec: v1alpha1.BGPSpec{
-				Site:       w.data.site.Name,
-				NeighborAS: asn,
-				Hardware:   w.data.switchName,
-				Transport: v1alpha1.BGPTransport{
-					Type: "vnet",
-					Name: w.data.vnetName,
-				},
-				LocalIP:           w.data.vnetGW,
-				RemoteIP:          node.IP,
-				PrefixListInbound: PrefixListInboundList,
-				PrefixListOutbound: []string{
-					"permit 0.0.0.0/0",
-					fmt.Sprintf("deny %s", ipipNetAddr.String()),
-					fmt.Sprintf("permit %s le %d", w.data.clusterCIDR, w.data.blockSize),
-				},
+	nodeName := name
+	name = fmt.Sprintf("%s-%s", name, strings.Split(remoteIP, "/")[0])
+
+	bgp := &v1alpha1.BGP{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      strings.Trim(nameReg.ReplaceAllString(name, "-"), "-"),
+			Namespace: itg.namespace,
+		},
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "BGP",
+			APIVersion: "k8s.netris.ai/v1alpha1",
+		},
+		Spec: v1alpha1.BGPSpec{
+			Site:       node.Site.Name,
+			NeighborAS: asn,
+			Hardware:   w.data.switchName,
+			Transport: v1alpha1.BGPTransport{
+				Type: "vnet",
+				Name: vnetName,
 			},
-		}
-		anns := make(map[string]string)
-		anns["k8s.netris.ai/calicowatcher"] = "true"
-		anns["resource.k8s.netris.ai/import"] = "true"
-		bgp.SetAnnotations(anns)
-		generatedBGPs = append(generatedBGPs, bgp)
+			LocalIP:            localIP,
+			RemoteIP:           remoteIP,
+			PrefixListInbound:  PrefixListInboundList,
+			PrefixListOutbound: PrefixListOutboundList,
+			Multihop:           v1alpha1.BGPMultihop{Hops: itg.multihop},
+			BGPPassword:        itg.password,
+			BFD:                bfd,
+		},
 	}
-	w.data.generatedBGPs = generatedBGPs
-	return nil
+	anns := make(map[string]string)
+	anns["k8s.netris.ai/calicowatcher"] = "true"
+	anns["resource.k8s.netris.ai/import"] = "true"
+	anns[nodeAnnotation] = nodeName
+	bgp.SetAnnotations(anns)
+	return bgp
 }
 
 func (w *Watcher) createBGPs(BGPs []*v1alpha1.BGP) []error {
@@ -537,7 +610,7 @@ func (w *Watcher) deleteBGP(bgp *v1alpha1.BGP) error {
 	return w.client.Delete(ctx, bgp.DeepCopyObject(), &client.DeleteAllOfOptions{})
 }
 
-func (w *Watcher) compareBGPs() ([]*v1alpha1.BGP, []*v1alpha1.BGP, []*v1alpha1.BGP) {
+func compareBGPs(generatedBGPs, bgpList []*v1alpha1.BGP) ([]*v1alpha1.BGP, []*v1alpha1.BGP, []*v1alpha1.BGP) {
 	genBGPsMap := make(map[string]*v1alpha1.BGP)
 	BGPsMap := make(map[string]*v1alpha1.BGP)
 
@@ -545,28 +618,32 @@ func (w *Watcher) compareBGPs() ([]*v1alpha1.BGP, []*v1alpha1.BGP, []*v1alpha1.B
 	bgpsForDelete := []*v1alpha1.BGP{}
 	bgpsForUpdate := []*v1alpha1.BGP{}
 
-	for _, bgp := range w.data.generatedBGPs {
-		genBGPsMap[bgp.Name] = bgp
+	for _, bgp := range generatedBGPs {
+		genBGPsMap[bgpKey(bgp)] = bgp
 	}
 
-	for _, bgp := range w.data.bgpList {
-		BGPsMap[bgp.Name] = bgp
+	for _, bgp := range bgpList {
+		BGPsMap[bgpKey(bgp)] = bgp
 	}
 
-	for _, genBGP := range w.data.generatedBGPs {
-		if bgp, ok := BGPsMap[genBGP.Name]; !ok {
+	for _, genBGP := range generatedBGPs {
+		if bgp, ok := BGPsMap[bgpKey(genBGP)]; !ok {
 			bgpsForCreate = append(bgpsForCreate, genBGP)
 		} else {
 			changelog, _ := diff.Diff(bgp.Spec, genBGP.Spec)
-			if len(changelog) > 0 {
+			node := genBGP.GetAnnotations()[nodeAnnotation]
+			if len(changelog) > 0 || bgp.GetAnnotations()[nodeAnnotation] != node {
 				bgp.Spec = genBGP.Spec
+				anns := bgp.GetAnnotations()
+				anns[nodeAnnotation] = node
+				bgp.SetAnnotations(anns)
 				bgpsForUpdate = append(bgpsForUpdate, bgp)
 			}
 		}
 	}
 
-	for _, bgp := range w.data.bgpList {
-		if _, ok := genBGPsMap[bgp.Name]; !ok {
+	for _, bgp := range bgpList {
+		if _, ok := genBGPsMap[bgpKey(bgp)]; !ok {
 			bgpsForDelete = append(bgpsForDelete, bgp)
 		}
 	}
@@ -574,6 +651,11 @@ func (w *Watcher) compareBGPs() ([]*v1alpha1.BGP, []*v1alpha1.BGP, []*v1alpha1.B
 	return bgpsForCreate, bgpsForDelete, bgpsForUpdate
 }
 
+// bgpKey identifies the BGP by namespace and name, so moving the BGPs to another namespace recreates them.
+func bgpKey(bgp *v1alpha1.BGP) string {
+	return fmt.Sprintf("%s/%s", bgp.Namespace, bgp.Name)
+}
+
 func (w *Watcher) getBGPs() (*v1alpha1.BGPList, error) {
 	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
 	defer cancel()
@@ -585,10 +667,57 @@ func (w *Watcher) getBGPs() (*v1alpha1.BGPList, error) {
 	return bgps, nil
 }
 
+// getWatcherBGPs lists the BGPs managed by the watcher.
+func (w *Watcher) getWatcherBGPs() ([]*v1alpha1.BGP, error) {
+	bgps, err := w.getBGPs()
+	if err != nil {
+		return nil, err
+	}
+
+	bgpList := []*v1alpha1.BGP{}
+	for _, bgp := range bgps.Items {
+		if watcherBGP(&bgp) {
+			bgpList = append(bgpList, bgp.DeepCopy())
+		}
+	}
+	return bgpList, nil
+}
+
+// orphanBGPs are the BGPs of the nodes which are gone, and the BGPs without the node annotation
+// which are not generated anymore. The BGPs of the existing nodes are left to the nodes processing.
+func (w *Watcher) orphanBGPs() []*v1alpha1.BGP {
+	generated := make(map[string]bool)
+	for _, bgp := range w.data.generatedBGPs {
+		generated[bgpKey(bgp)] = true
+	}
+
+	orphans := []*v1alpha1.BGP{}
+	for _, bgp := range w.data.bgpList {
+		if node, ok := bgp.GetAnnotations()[nodeAnnotation]; ok {
+			if _, err := w.nodeLister.Get(node); !apierrors.IsNotFound(err) {
+				continue
+			}
+		} else if generated[bgpKey(bgp)] {
+			continue
+		}
+		orphans = append(orphans, bgp)
+	}
+	return orphans
+}
+
+// nodeIP is a Calico node with the site, VNet and gateway of each of its addresses.
 type nodeIP struct {
-	IP   string
-	IPIP string
-	ASN  string
+	IP       string
+	IPv6     string
+	ASN      string
+	Labels   map[string]string
+	Hostname string
+
+	Site  *site.Site
+	VNet  string
+	GW    string
+	VNet6 string
+	GW6   string
 }
 
 func (w *Watcher) checkBGPConfigurations() bool {
@@ -603,205 +732,351 @@ func (w *Watcher) checkBGPConfigurations() bool {
 }
 
 func (w *Watcher) getNodes() error {
-	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
-	defer cancel()
-	nodes, err := w.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	nodes, err := w.nodeLister.List(labels.Everything())
 	if err != nil {
 		return err
 	}
 
-	if len(nodes.Items) == 0 {
+	if len(nodes) == 0 {
 		return fmt.Errorf("nodes are missing")
 	}
-	w.data.nodes = nodes
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+
+	// The nodes are copied, the ones in the informer cache are shared.
+	nodeList := &v1.NodeList{}
+	for _, node := range nodes {
+		nodeList.Items = append(nodeList.Items, *node.DeepCopy())
+	}
+	w.data.nodes = nodeList
 	return nil
 }
 
+// getBGPConfigurations gets the BGPConfigurations from the informer cache, sorted by name.
+func (w *Watcher) getBGPConfigurations() ([]*calico.BGPConfiguration, error) {
+	objs, err := w.bgpConfLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	bgpConfs := []*calico.BGPConfiguration{}
+	for _, obj := range objs {
+		bgpConf, err := calico.ParseBGPConfiguration(obj)
+		if err != nil {
+			return nil, err
+		}
+		bgpConfs = append(bgpConfs, bgpConf)
+	}
+	sort.Slice(bgpConfs, func(i, j int) bool { return bgpConfs[i].Name < bgpConfs[j].Name })
+	return bgpConfs, nil
+}
+
 func (w *Watcher) getIPPools() ([]*calico.IPPool, error) {
-	ipPools, err := w.Calico.GetIPPool(w.restClient)
+	objs, err := w.ipPoolLister.List(labels.Everything())
 	if err != nil {
 		return nil, err
 	}
 
-	if len(ipPools) == 0 && ipPools[0] != nil {
+	ipPools := []*calico.IPPool{}
+	for _, obj := range objs {
+		ipPool, err := calico.ParseIPPool(obj)
+		if err != nil {
+			return nil, err
+		}
+		ipPools = append(ipPools, ipPool)
+	}
+
+	if len(ipPools) == 0 {
 		return nil, fmt.Errorf("IPPool is missing")
 	}
+	sort.Slice(ipPools, func(i, j int) bool { return ipPools[i].Name < ipPools[j].Name })
 	return ipPools, nil
 }
 
+// getIPInfo collects the enabled IPPools of both IP families and the service CIDRs.
 func (w *Watcher) getIPInfo() error {
-	var (
-		blockSize    int
-		clusterCIDR  string
-		serviceCIDRs []string
-	)
+	var serviceCIDRs []string
 
 	ipPools, err := w.getIPPools()
 	if err != nil {
 		return err
 	}
 
-	blockSize = ipPools[0].Spec.BlockSize
-	clusterCIDR = ipPools[0].Spec.CIDR
+	pools := []ipPool{}
+	for _, p := range ipPools {
+		if p.Spec.Disabled {
+			continue
+		}
+		ip, _, err := net.ParseCIDR(p.Spec.CIDR)
+		if err != nil {
+			logger.Info("Skipping IPPool with invalid CIDR", "ippool", p.Name, "cidr", p.Spec.CIDR)
+			continue
+		}
+		selector, err := calico.ParseSelector(p.Spec.NodeSelector)
+		if err != nil {
+			logger.Info("Skipping IPPool with unsupported nodeSelector", "ippool", p.Name, "error", err.Error())
+			continue
+		}
+		ipv6 := ip.To4() == nil
+		blockSize := p.Spec.BlockSize
+		if blockSize == 0 {
+			blockSize = 26
+			if ipv6 {
+				blockSize = 122
+			}
+		}
+		pools = append(pools, ipPool{CIDR: p.Spec.CIDR, IPv6: ipv6, BlockSize: blockSize, NodeSelector: selector})
+	}
+	if len(pools) == 0 {
+		return fmt.Errorf("enabled IPPool is missing")
+	}
+
 	for _, c := range w.data.bgpConfs[0].Spec.ServiceClusterIPs {
 		serviceCIDRs = append(serviceCIDRs, c.CIDR)
 	}
-	w.data.blockSize = blockSize
-	w.data.clusterCIDR = clusterCIDR
+	w.data.ipPools = pools
 	w.data.serviceCIDRs = serviceCIDRs
 	return nil
 }
 
-func (w *Watcher) fillNodesASNs() error {
-	asnMap := make(map[string]bool)
+func (w *Watcher) nodesProcessing() error {
+	nodesMap := make(map[string]*nodeIP)
+	expectedSessions := 0
 
-	for _, node := range w.data.nodes.Items {
-		anns := node.GetAnnotations()
-		if _, ok := anns["projectcalico.org/ASNumber"]; ok {
-			asnMap[anns["projectcalico.org/ASNumber"]] = true
+	for i := range w.data.nodes.Items {
+		node := &w.data.nodes.Items[i]
+		tmpNode, err := w.nodeIP(node)
+		if err != nil {
+			return err
 		}
-	}
-
-	for _, node := range w.data.nodes.Items {
-		anns := node.GetAnnotations()
-		if _, ok := anns["projectcalico.org/ASNumber"]; !ok {
-			for i := w.data.asnStart; i < w.data.asnEnd; i++ {
-				asn := strconv.Itoa(i)
-				if !asnMap[asn] {
-					anns["projectcalico.org/ASNumber"] = asn
-					node.SetAnnotations(anns)
-					payload := []patchStringValue{{
-						Op:    "replace",
-						Path:  "/metadata/annotations/projectcalico.org~1ASNumber",
-						Value: asn,
-					}}
-					payloadBytes, _ := json.Marshal(payload)
-					ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
-					_, err := w.clientset.CoreV1().Nodes().Patch(ctx, node.Name, types.JSONPatchType, payloadBytes, metav1.PatchOptions{})
-					if err != nil {
-						cancel()
-						return err
-					}
-					asnMap[asn] = true
-					cancel()
-					break
-				}
+		if tmpNode == nil {
+			continue
+		}
+		for _, addr := range []string{tmpNode.IP, tmpNode.IPv6} {
+			if addr != "" {
+				expectedSessions++
 			}
-		} else {
-			asnMap[anns["projectcalico.org/ASNumber"]] = true
 		}
+
+		if err := w.resolveNodeNetwork(tmpNode); err != nil {
+			logger.Info("Skipping node", "node", node.Name, "error", err.Error())
+			w.data.skippedNodes = append(w.data.skippedNodes, node.Name)
+			continue
+		}
+
+		nodesMap[node.Name] = tmpNode
 	}
+	w.data.expectedSessions = expectedSessions
+
+	if len(nodesMap) == 0 {
+		return fmt.Errorf("couldn't find site and vnet for any node")
+	}
+
+	w.data.nodesMap = nodesMap
+	w.data.switchName = ""
+
 	return nil
 }
 
-func (w *Watcher) nodesProcessing() error {
-	var (
-		siteName   string
-		site       *site.Site
-		vnetName   string
-		vnetGW     string
-		switchName string = ""
-		vnetGWIP   string
-	)
-
-	subnet := ""
-	vnet := &vnet.VNet{}
+// nodeIP gets the addresses and the AS number of the node. It returns nil for the nodes which are not peered
+// with the fabric, their network is resolved by the caller.
+func (w *Watcher) nodeIP(node *v1.Node) (*nodeIP, error) {
+	anns := node.GetAnnotations()
+
+	// Nodes are peered regardless of the encapsulation, so IPIP, VXLAN
+	// and no-encap clusters are handled the same way.
+	_, hasIPv4 := anns["projectcalico.org/IPv4Address"]
+	_, hasIPv6 := anns["projectcalico.org/IPv6Address"]
+	if !hasIPv4 && !hasIPv6 {
+		return nil, nil
+	}
 
-	nodesMap := make(map[string]*nodeIP)
+	if !w.data.integration.nodeSelector.Matches(labels.Set(node.GetLabels())) {
+		return nil, nil
+	}
 
-	for _, node := range w.data.nodes.Items {
-		anns := node.GetAnnotations()
+	asn, ok := anns["projectcalico.org/ASNumber"]
 
-		if _, ok := anns["projectcalico.org/IPv4Address"]; !ok {
-			continue
+	// In route reflector mode only the route reflectors peer with the fabric, in the cluster-wide AS.
+	if rrSelector := w.data.integration.rrSelector; rrSelector != nil {
+		if !rrSelector.Matches(labels.Set(node.GetLabels())) {
+			return nil, nil
 		}
-		if _, ok := anns["projectcalico.org/IPv4IPIPTunnelAddr"]; !ok {
-			continue
+		if !ok {
+			asn, ok = strconv.Itoa(w.clusterASN()), true
 		}
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("couldn't get as number for node %s", node.Name)
+	}
 
-		asn := ""
+	hostname := node.GetLabels()["kubernetes.io/hostname"]
+	if hostname == "" {
+		hostname = node.Name
+	}
 
-		if _, ok := anns["projectcalico.org/ASNumber"]; !ok {
-			return fmt.Errorf("couldn't get as number for node %s", node.Name)
-		}
+	return &nodeIP{
+		IP:       anns["projectcalico.org/IPv4Address"],
+		IPv6:     anns["projectcalico.org/IPv6Address"],
+		ASN:      asn,
+		Labels:   node.GetLabels(),
+		Hostname: hostname,
+	}, nil
+}
 
-		asn = anns["projectcalico.org/ASNumber"]
+// nodeProcessing creates, updates and deletes the BGPs of a single node, with the cluster-wide state
+// loaded by mainProcessing. Once the integration is disabled the BGPs are removed by deleteProcess.
+func (w *Watcher) nodeProcessing(name string) error {
+	if !w.data.loaded || w.data.deleteMode {
+		return nil
+	}
 
-		tmpNode := &nodeIP{
-			IP:   anns["projectcalico.org/IPv4Address"],
-			IPIP: anns["projectcalico.org/IPv4IPIPTunnelAddr"],
-			ASN:  asn,
+	generatedBGPs := []*v1alpha1.BGP{}
+	node, err := w.nodeLister.Get(name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		tmpNode, err := w.nodeIP(node)
+		if err != nil {
+			if _, ok := node.GetAnnotations()["projectcalico.org/ASNumber"]; ok {
+				return err
+			}
+			// The AS number is assigned by the cluster-wide processing, the node is requeued once it's annotated.
+			debugLogger.Info("Waiting for the AS number of the node", "node", name)
+			return nil
 		}
+		if tmpNode != nil {
+			if err := w.resolveNodeNetwork(tmpNode); err != nil {
+				// Netris changes don't come as events, the node is retried until its network shows up.
+				debugLogger.Info("Skipping node", "node", name, "error", err.Error())
+				w.queue.AddAfter(name, requeueInterval)
+			} else if generatedBGPs, err = w.generateNodeBGPs(name, tmpNode); err != nil {
+				return err
+			}
+		}
+	}
 
-		ip := strings.Split(anns["projectcalico.org/IPv4Address"], "/")[0]
-		if net.ParseIP(ip) == nil {
-			fmt.Println("Invalid IP:", anns["projectcalico.org/IPv4Address"])
-			continue
+	generated := make(map[string]bool)
+	for _, bgp := range generatedBGPs {
+		generated[bgpKey(bgp)] = true
+	}
+
+	bgps, err := w.getWatcherBGPs()
+	if err != nil {
+		return err
+	}
+
+	// The BGPs created before the node annotation are taken over by name.
+	nodeBGPs := []*v1alpha1.BGP{}
+	for _, bgp := range bgps {
+		if node, ok := bgp.GetAnnotations()[nodeAnnotation]; node == name || (!ok && generated[bgpKey(bgp)]) {
+			nodeBGPs = append(nodeBGPs, bgp)
 		}
+	}
 
-		if siteName == "" {
-			sbnt, err := findIPAMByIP(ip, w.NStorage.SubnetsStorage.GetAll())
-			if err != nil {
-				fmt.Println(err)
-				continue
-			}
+	bgpsForCreate, bgpsForDelete, bgpsForUpdate := compareBGPs(generatedBGPs, nodeBGPs)
 
-			_, ipNet, err := net.ParseCIDR(sbnt.Prefix)
-			if err != nil {
-				fmt.Println(err)
-				continue
-			}
+	js, _ := json.Marshal(bgpsForCreate)
+	debugLogger.Info("BGPs for create", "node", name, "List", string(js))
+	js, _ = json.Marshal(bgpsForDelete)
+	debugLogger.Info("BGPs for delete", "node", name, "List", string(js))
+	js, _ = json.Marshal(bgpsForUpdate)
+	debugLogger.Info("BGPs for update", "node", name, "List", string(js))
 
-			subnet = ipNet.String()
-			id := 0
+	var errors []error
+	errors = append(errors, w.deleteBGPs(bgpsForDelete)...)
+	errors = append(errors, w.updateBGPs(bgpsForUpdate)...)
+	errors = append(errors, w.createBGPs(bgpsForCreate)...)
+	if len(errors) > 0 {
+		return fmt.Errorf("{nodeProcessing} %v", errors)
+	}
+	return nil
+}
 
-			if len(sbnt.Sites) > 0 {
-				id = sbnt.Sites[0].ID
-			}
+// resolveNodeNetwork finds the site of the node and the VNet and gateway of each of its addresses,
+// so nodes of the same cluster can live in different VNets and sites.
+func (w *Watcher) resolveNodeNetwork(node *nodeIP) error {
+	var lastErr error
+	for _, addr := range []string{node.IP, node.IPv6} {
+		if addr == "" {
+			continue
+		}
+		ip := net.ParseIP(strings.Split(addr, "/")[0])
+		if ip == nil {
+			lastErr = fmt.Errorf("invalid IP %s", addr)
+			continue
+		}
 
-			var ok bool
-			if site, ok = w.NStorage.SitesStorage.FindByID(id); ok {
-				siteName = site.Name
-			}
-			if vn, ok := w.NStorage.VNetStorage.FindByGateway(subnet); ok {
-				vnet = vn
-			}
+		st, vn, gw, err := w.findNodeGateway(ip)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if node.Site == nil {
+			node.Site = st
+		} else if node.Site.ID != st.ID {
+			lastErr = fmt.Errorf("addresses of the node belong to different sites %s and %s", node.Site.Name, st.Name)
+			continue
 		}
 
-		nodesMap[node.Name] = tmpNode
+		if ip.To4() != nil {
+			node.VNet, node.GW = vn.Name, gw
+		} else {
+			node.VNet6, node.GW6 = vn.Name, gw
+		}
 	}
 
-	if siteName == "" {
-		return fmt.Errorf("couldn't find site")
+	if node.GW == "" && node.GW6 == "" {
+		if lastErr == nil {
+			lastErr = fmt.Errorf("couldn't find vnet gateway")
+		}
+		return lastErr
+	}
+	if lastErr != nil {
+		logger.Info("Peering node over a single IP family", "error", lastErr.Error())
 	}
+	return nil
+}
 
-	if vnet == nil {
-		return fmt.Errorf("couldn't find vnet")
+// findNodeGateway finds the site, the VNet and the VNet gateway of the node address.
+func (w *Watcher) findNodeGateway(ip net.IP) (*site.Site, *vnet.VNet, string, error) {
+	sbnt, err := findIPAMByIP(ip.String(), w.NStorage.SubnetsStorage.GetAll())
+	if err != nil {
+		return nil, nil, "", err
 	}
-	if vnet.ID == 0 {
-		return fmt.Errorf("couldn't find vnet")
+
+	_, ipNet, err := net.ParseCIDR(sbnt.Prefix)
+	if err != nil {
+		return nil, nil, "", err
 	}
+	subnet := ipNet.String()
 
-	vnetName = vnet.Name
-	for _, gw := range vnet.Gateways {
-		gateway := strings.Split(gw.Prefix, "/")[0]
+	id := 0
+	if len(sbnt.Sites) > 0 {
+		id = sbnt.Sites[0].ID
+	}
+
+	st, ok := w.NStorage.SitesStorage.FindByID(id)
+	if !ok {
+		return nil, nil, "", fmt.Errorf("couldn't find site for %s", ip.String())
+	}
+
+	vn, ok := w.NStorage.VNetStorage.FindByGateway(subnet)
+	if !ok || vn == nil || vn.ID == 0 {
+		return nil, nil, "", fmt.Errorf("couldn't find vnet for %s", ip.String())
+	}
+
+	for _, gw := range vn.Gateways {
 		_, gwNet, err := net.ParseCIDR(gw.Prefix)
 		if err != nil {
-			return fmt.Errorf("invalid vnet gateway %s", gw.Prefix)
+			return nil, nil, "", fmt.Errorf("invalid vnet gateway %s", gw.Prefix)
 		}
-		if gwNet.String() == subnet {
-			vnetGW = gw.Prefix
-			vnetGWIP = gateway
+		if gwNet.String() == subnet || gwNet.Contains(ip) {
+			return st, vn, gw.Prefix, nil
 		}
 	}
-	w.data.nodesMap = nodesMap
-	w.data.site = site
-	w.data.vnetName = vnetName
-	w.data.vnetGW = vnetGW
-	w.data.vnetGWIP = vnetGWIP
-	w.data.switchName = switchName
-
-	return nil
+	return nil, nil, "", fmt.Errorf("couldn't find gateway of vnet %s for %s", vn.Name, ip.String())
 }
 
 func (w *Watcher) validateASNRange(asns string) (int, int, error) {
@@ -0,0 +1,567 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package calicowatcher
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/calicowatcher/calico"
+	"github.com/netrisai/netris-operator/watchercore/watchercoretest"
+	"github.com/netrisai/netriswebapi/v2/types/site"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func testNode(name, ip string) *v1.Node {
+	return watchercoretest.Node(name, nil, map[string]string{"projectcalico.org/IPv4Address": ip})
+}
+
+func testBGPConfiguration(managed string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "crd.projectcalico.org/v1",
+		"kind":       "BGPConfiguration",
+		"metadata": map[string]interface{}{
+			"name":        "default",
+			"annotations": map[string]interface{}{"manage.k8s.netris.ai/calico": managed},
+		},
+		"spec": map[string]interface{}{},
+	}}
+}
+
+func testIPPool() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "crd.projectcalico.org/v1",
+		"kind":       "IPPool",
+		"metadata":   map[string]interface{}{"name": "default-ipv4-ippool"},
+		"spec":       map[string]interface{}{"cidr": "192.168.0.0/16", "blockSize": int64(26)},
+	}}
+}
+
+type testCluster struct {
+	watcher   *Watcher
+	dynClient *dynamicfake.FakeDynamicClient
+	clientset *kubefake.Clientset
+}
+
+func newTestCluster(t *testing.T, objs []runtime.Object, calicoObjs ...runtime.Object) *testCluster {
+	dynClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), calicoObjs...)
+	// The API server drops the namespace of the cluster-scoped BGPPeers, the fake client doesn't.
+	dynClient.PrependReactor("*", "bgppeers", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if a, ok := action.(clienttesting.CreateAction); ok {
+			if obj, err := meta.Accessor(a.GetObject()); err == nil {
+				obj.SetNamespace("")
+			}
+		}
+		return false, nil, nil
+	})
+	clientset := kubefake.NewSimpleClientset()
+	for _, obj := range objs {
+		if node, ok := obj.(*v1.Node); ok {
+			if _, err := clientset.CoreV1().Nodes().Create(context.Background(), node, metav1.CreateOptions{}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	w, err := NewWatcher(watchercoretest.Storage(t, "10.0.0.0/24"), nil, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.client = watchercoretest.NewClient(t, bgpObjects(objs)...)
+	w.clientset = clientset
+	w.dynamicClient = dynClient
+	if err := w.initClients(); err != nil {
+		t.Fatal(err)
+	}
+	w.queue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "calicowatcher")
+	t.Cleanup(func() {
+		w.queue.ShutDown()
+		close(w.stop)
+	})
+	if err := w.setupInformers(w.stop); err != nil {
+		t.Fatal(err)
+	}
+	return &testCluster{watcher: w, dynClient: dynClient, clientset: clientset}
+}
+
+func bgpObjects(objs []runtime.Object) []runtime.Object {
+	bgps := []runtime.Object{}
+	for _, obj := range objs {
+		if _, ok := obj.(*v1alpha1.BGP); ok {
+			bgps = append(bgps, obj)
+		}
+	}
+	return bgps
+}
+
+// sync runs the cluster-wide processing and the nodes processing until the queue settles.
+func (c *testCluster) sync(t *testing.T) {
+	t.Helper()
+	c.watcher.queue.Add(clusterKey)
+	watchercoretest.Settle(t, c.watcher.queue, c.watcher.processNextItem)
+}
+
+func (c *testCluster) bgps(t *testing.T) map[string]*v1alpha1.BGP {
+	t.Helper()
+	return watchercoretest.BGPs(t, c.watcher.client)
+}
+
+func TestProcess(t *testing.T) {
+	legacy := &v1alpha1.BGP{
+		TypeMeta: metav1.TypeMeta{Kind: "BGP", APIVersion: "k8s.netris.ai/v1alpha1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "node1-10.0.0.11",
+			Namespace:   "default",
+			Annotations: map[string]string{"k8s.netris.ai/calicowatcher": "true"},
+		},
+	}
+	c := newTestCluster(t,
+		[]runtime.Object{testNode("node1", "10.0.0.11/24"), testNode("node2", "10.0.0.12/24"), legacy},
+		testBGPConfiguration("true"), testIPPool(),
+	)
+	c.sync(t)
+
+	bgps := c.bgps(t)
+	if len(bgps) != 2 {
+		t.Fatalf("got %d BGPs, want 2", len(bgps))
+	}
+	for node, name := range map[string]string{"node1": "node1-10.0.0.11", "node2": "node2-10.0.0.12"} {
+		bgp, ok := bgps[name]
+		if !ok {
+			t.Fatalf("BGP %s is missing", name)
+		}
+		if got := bgp.GetAnnotations()[nodeAnnotation]; got != node {
+			t.Errorf("BGP %s node annotation = %q, want %q", name, got, node)
+		}
+		if bgp.Spec.NeighborAS < 4230000000 || bgp.Spec.LocalIP != "10.0.0.1/24" || bgp.Spec.Transport.Name != "vnet1" {
+			t.Errorf("BGP %s spec = %+v", name, bgp.Spec)
+		}
+	}
+	if bgps["node1-10.0.0.11"].Spec.NeighborAS == bgps["node2-10.0.0.12"].Spec.NeighborAS {
+		t.Errorf("nodes share the AS number %d", bgps["node1-10.0.0.11"].Spec.NeighborAS)
+	}
+
+	if err := c.clientset.CoreV1().Nodes().Delete(context.Background(), "node2", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	c.sync(t)
+	if bgps := c.bgps(t); len(bgps) != 1 || bgps["node1-10.0.0.11"] == nil {
+		t.Fatalf("BGPs after node removal = %v", bgps)
+	}
+
+	// The fake client doesn't bump the resourceVersion, the informers ignore the updates without it.
+	disabled := testBGPConfiguration("false")
+	disabled.SetResourceVersion("2")
+	if _, err := c.dynClient.Resource(calico.BGPConfigurationResource).Update(context.Background(), disabled, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	c.sync(t)
+	if bgps := c.bgps(t); len(bgps) != 0 {
+		t.Fatalf("BGPs after disabling = %v", bgps)
+	}
+	node, err := c.clientset.CoreV1().Nodes().Get(context.Background(), "node1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if asn, ok := node.Annotations["projectcalico.org/ASNumber"]; ok {
+		t.Errorf("node1 keeps AS number %s after disabling", asn)
+	}
+}
+
+func TestCalicoInstalledLater(t *testing.T) {
+	c := newTestCluster(t, []runtime.Object{testNode("node1", "10.0.0.11/24")})
+	installed := false
+	c.dynClient.PrependReactor("list", "bgpconfigurations", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if !installed {
+			return true, nil, apierrors.NewNotFound(calico.BGPConfigurationResource.GroupResource(), "")
+		}
+		return false, nil, nil
+	})
+
+	c.sync(t)
+	if c.watcher.bgpConfLister != nil {
+		t.Fatal("Calico informers started before Calico is installed")
+	}
+	if bgps := c.bgps(t); len(bgps) != 0 {
+		t.Fatalf("BGPs without Calico = %v", bgps)
+	}
+
+	installed = true
+	for _, obj := range []*unstructured.Unstructured{testBGPConfiguration("true"), testIPPool()} {
+		resource := calico.BGPConfigurationResource
+		if obj.GetKind() == "IPPool" {
+			resource = calico.IPPoolResource
+		}
+		if _, err := c.dynClient.Resource(resource).Create(context.Background(), obj, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.sync(t)
+	if bgps := c.bgps(t); len(bgps) != 1 || bgps["node1-10.0.0.11"] == nil {
+		t.Fatalf("BGPs once Calico is installed = %v", bgps)
+	}
+}
+
+func TestGenerateBGPPoolPrefixLists(t *testing.T) {
+	mustSelector := func(s string) calico.Selector {
+		sel, err := calico.ParseSelector(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sel
+	}
+
+	w := &Watcher{}
+	w.data.integration = defaultIntegration()
+	w.data.ipPools = []ipPool{
+		{CIDR: "192.168.0.0/16", BlockSize: 26, NodeSelector: mustSelector("all()")},
+		{CIDR: "172.16.0.0/16", BlockSize: 24, NodeSelector: mustSelector("zone == 'b'")},
+		{CIDR: "fd00:10::/48", IPv6: true, BlockSize: 122, NodeSelector: mustSelector("")},
+	}
+	w.data.serviceCIDRs = []string{"10.96.0.0/12", "fd00:96::/108"}
+
+	node := &nodeIP{
+		IP:     "10.0.0.10/24",
+		IPv6:   "fd00::10/64",
+		ASN:    "4200000000",
+		Labels: map[string]string{"zone": "a"},
+		Site:   &site.Site{Name: "site1"},
+		VNet:   "vnet1",
+		GW:     "10.0.0.1/24",
+		VNet6:  "vnet1",
+		GW6:    "fd00::1/64",
+	}
+
+	tests := []struct {
+		name         string
+		labels       map[string]string
+		ipv6         bool
+		routeReflect bool
+		inbound      []string
+		outbound     []string
+	}{
+		{
+			name:   "node selected by one IPv4 pool",
+			labels: map[string]string{"zone": "a"},
+			inbound: []string{
+				"permit 192.168.0.0/16 ge 26 le 32",
+				"permit 10.96.0.0/12 le 32",
+			},
+			outbound: []string{
+				"permit 0.0.0.0/0",
+				"permit 192.168.0.0/16 le 26",
+				"permit 172.16.0.0/16 le 24",
+			},
+		},
+		{
+			name:   "node selected by both IPv4 pools",
+			labels: map[string]string{"zone": "b"},
+			inbound: []string{
+				"permit 192.168.0.0/16 ge 26 le 32",
+				"permit 172.16.0.0/16 ge 24 le 32",
+				"permit 10.96.0.0/12 le 32",
+			},
+			outbound: []string{
+				"permit 0.0.0.0/0",
+				"permit 192.168.0.0/16 le 26",
+				"permit 172.16.0.0/16 le 24",
+			},
+		},
+		{
+			name:         "route reflector accepts the blocks of all pools",
+			labels:       map[string]string{"zone": "a"},
+			routeReflect: true,
+			inbound: []string{
+				"permit 192.168.0.0/16 ge 26 le 32",
+				"permit 172.16.0.0/16 ge 24 le 32",
+				"permit 10.96.0.0/12 le 32",
+			},
+			outbound: []string{
+				"permit 0.0.0.0/0",
+				"permit 192.168.0.0/16 le 26",
+				"permit 172.16.0.0/16 le 24",
+			},
+		},
+		{
+			name:   "IPv6 session gets the IPv6 pools only",
+			labels: map[string]string{"zone": "b"},
+			ipv6:   true,
+			inbound: []string{
+				"permit fd00:10::/48 ge 122 le 128",
+				"permit fd00:96::/108 le 128",
+			},
+			outbound: []string{
+				"permit ::/0",
+				"permit fd00:10::/48 le 122",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w.data.integration.rrSelector = nil
+			if tt.routeReflect {
+				w.data.integration.rrSelector = labels.Everything()
+			}
+			node.Labels = tt.labels
+			bgp := w.generateBGP("node1", node, 4200000000, tt.ipv6)
+			if !reflect.DeepEqual(bgp.Spec.PrefixListInbound, tt.inbound) {
+				t.Errorf("inbound = %v, want %v", bgp.Spec.PrefixListInbound, tt.inbound)
+			}
+			if !reflect.DeepEqual(bgp.Spec.PrefixListOutbound, tt.outbound) {
+				t.Errorf("outbound = %v, want %v", bgp.Spec.PrefixListOutbound, tt.outbound)
+			}
+		})
+	}
+}
+
+func TestMeshHealthCountsSkippedNodes(t *testing.T) {
+	// node3 is outside of the known subnets, so it's skipped and gets no BGP.
+	c := newTestCluster(t,
+		[]runtime.Object{testNode("node1", "10.0.0.11/24"), testNode("node2", "10.0.0.12/24"), testNode("node3", "10.9.0.13/24")},
+		testBGPConfiguration("true"), testIPPool(),
+	)
+	c.sync(t)
+
+	bgps := c.bgps(t)
+	if len(bgps) != 2 {
+		t.Fatalf("got %d BGPs, want 2", len(bgps))
+	}
+	for _, bgp := range bgps {
+		bgp.Status.BGPStatus = "Established"
+		bgp.Status.BGPPrefixes = 1
+		if err := c.watcher.client.Update(context.Background(), bgp); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.sync(t)
+
+	if healthy, total := c.watcher.sessionsHealth(); healthy != 2 || total != 3 {
+		t.Errorf("sessions health = %d/%d, want 2/3", healthy, total)
+	}
+	if hold := c.watcher.meshHold; hold == nil || !hold.state {
+		t.Errorf("mesh hold = %+v, want the mesh kept enabled", hold)
+	}
+}
+
+func TestMeshProcessing(t *testing.T) {
+	dynClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), testBGPConfiguration("true"))
+	calicoClient := calico.New(dynClient, calico.Options{})
+	enabled, disabled := true, false
+
+	type step struct {
+		healthy, total int
+		// elapsed is the time passed since the previous step.
+		elapsed time.Duration
+		want    *bool
+	}
+	tests := []struct {
+		name     string
+		fallback string
+		mesh     *bool
+		steps    []step
+	}{
+		{
+			name:     "auto disables the mesh after the disable delay",
+			fallback: "auto",
+			steps: []step{
+				{healthy: 3, total: 3, want: nil},
+				{healthy: 3, total: 3, elapsed: 30 * time.Second, want: nil},
+				{healthy: 3, total: 3, elapsed: 30 * time.Second, want: &disabled},
+			},
+		},
+		{
+			name:     "auto enables the mesh back after the enable delay",
+			fallback: "auto",
+			mesh:     &disabled,
+			steps: []step{
+				{healthy: 2, total: 3, want: &disabled},
+				{healthy: 2, total: 3, elapsed: 30 * time.Second, want: &enabled},
+			},
+		},
+		{
+			name:     "flapping session restarts the hold-down",
+			fallback: "auto",
+			mesh:     &disabled,
+			steps: []step{
+				{healthy: 2, total: 3, want: &disabled},
+				{healthy: 2, total: 3, elapsed: 20 * time.Second, want: &disabled},
+				{healthy: 3, total: 3, want: &disabled},
+				{healthy: 2, total: 3, want: &disabled},
+				{healthy: 2, total: 3, elapsed: 20 * time.Second, want: &disabled},
+				{healthy: 2, total: 3, elapsed: 10 * time.Second, want: &enabled},
+			},
+		},
+		{
+			name:     "auto keeps the mesh without sessions",
+			fallback: "auto",
+			mesh:     &enabled,
+			steps: []step{
+				{healthy: 0, total: 0, want: &enabled},
+				{healthy: 0, total: 0, elapsed: time.Hour, want: &enabled},
+			},
+		},
+		{
+			name:     "never doesn't enable the mesh back",
+			fallback: "never",
+			mesh:     &disabled,
+			steps: []step{
+				{healthy: 0, total: 3, want: &disabled},
+				{healthy: 0, total: 3, elapsed: time.Hour, want: &disabled},
+			},
+		},
+		{
+			name:     "never disables the mesh",
+			fallback: "never",
+			steps: []step{
+				{healthy: 3, total: 3, want: nil},
+				{healthy: 3, total: 3, elapsed: 60 * time.Second, want: &disabled},
+			},
+		},
+		{
+			name:     "keep enables the mesh right away",
+			fallback: "keep",
+			mesh:     &disabled,
+			steps: []step{
+				{healthy: 3, total: 3, want: &enabled},
+			},
+		},
+		{
+			name:     "manual doesn't touch the mesh",
+			fallback: "manual",
+			mesh:     &disabled,
+			steps: []step{
+				{healthy: 0, total: 3, want: &disabled},
+				{healthy: 0, total: 3, elapsed: time.Hour, want: &disabled},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := dynClient.Resource(calico.BGPConfigurationResource).Get(context.Background(), "default", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			conf, err := calico.ParseBGPConfiguration(obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			conf.Spec.NodeToNodeMeshEnabled = tt.mesh
+
+			queue := &delayRecorder{RateLimitingInterface: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())}
+			defer queue.ShutDown()
+			w := &Watcher{Calico: calicoClient, queue: queue}
+			w.data.bgpConfs = []*calico.BGPConfiguration{conf}
+			w.data.integration = defaultIntegration()
+			w.data.integration.meshFallback = tt.fallback
+
+			for i, s := range tt.steps {
+				if w.meshHold != nil {
+					w.meshHold.since = w.meshHold.since.Add(-s.elapsed)
+				}
+				if err := w.meshProcessing(s.healthy, s.total); err != nil {
+					t.Fatal(err)
+				}
+				got := conf.Spec.NodeToNodeMeshEnabled
+				if (got == nil) != (s.want == nil) || (got != nil && *got != *s.want) {
+					t.Errorf("step %d: nodeToNodeMeshEnabled = %s, want %s", i, boolString(got), boolString(s.want))
+				}
+				// A held down transition schedules the processing for the end of the hold-down.
+				if delay, ok := queue.after[clusterKey]; (w.meshHold != nil) != ok || delay < 0 || delay > time.Minute {
+					t.Errorf("step %d: mesh hold %+v with the processing scheduled after %v", i, w.meshHold, delay)
+				}
+				queue.after = nil
+			}
+		})
+	}
+}
+
+// delayRecorder records the items added with a delay instead of delaying them.
+type delayRecorder struct {
+	workqueue.RateLimitingInterface
+	after map[interface{}]time.Duration
+}
+
+func (q *delayRecorder) AddAfter(item interface{}, duration time.Duration) {
+	if q.after == nil {
+		q.after = make(map[interface{}]time.Duration)
+	}
+	q.after[item] = duration
+}
+
+func boolString(b *bool) string {
+	if b == nil {
+		return "nil"
+	}
+	return strconv.FormatBool(*b)
+}
+
+func TestEnqueueNodes(t *testing.T) {
+	bgp := func(name, node string, asn int) *v1alpha1.BGP {
+		b := &v1alpha1.BGP{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		b.SetAnnotations(map[string]string{"k8s.netris.ai/calicowatcher": "true", nodeAnnotation: node})
+		b.Spec.NeighborAS = asn
+		return b
+	}
+
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+	w := &Watcher{queue: queue}
+	w.data.generatedBGPs = []*v1alpha1.BGP{
+		bgp("node1-10.0.0.11", "node1", 4230000000),
+		bgp("node2-10.0.0.12", "node2", 4230000001),
+		bgp("node3-10.0.0.13", "node3", 4230000002),
+	}
+	w.data.bgpList = []*v1alpha1.BGP{
+		// node1 is up to date.
+		bgp("node1-10.0.0.11", "node1", 4230000000),
+		// node2 got a new AS number.
+		bgp("node2-10.0.0.12", "node2", 4230000005),
+		// node4 lost its address.
+		bgp("node4-10.0.0.14", "node4", 4230000003),
+	}
+	// node3 has no BGP yet and node5 waits for its VNet.
+	w.data.skippedNodes = []string{"node5"}
+
+	w.enqueueNodes()
+
+	got := map[string]bool{}
+	for queue.Len() > 0 {
+		item, _ := queue.Get()
+		got[item.(string)] = true
+		queue.Done(item)
+	}
+	want := map[string]bool{"node2": true, "node3": true, "node4": true, "node5": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("enqueued nodes = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package calicowatcher
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	meshEnabledGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "netris_operator_calico_node_to_node_mesh_enabled",
+		Help: "Whether the Calico node-to-node mesh is enabled (1) or disabled (0)",
+	})
+	meshTransitionsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "netris_operator_calico_node_to_node_mesh_transitions_total",
+		Help: "Number of Calico node-to-node mesh transitions made by the operator",
+	}, []string{"state"})
+	healthySessionsGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "netris_operator_calico_healthy_sessions_ratio",
+		Help: "Share of the node BGP sessions that are established",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(meshEnabledGauge, meshTransitionsCounter, healthySessionsGauge)
+}
+
+// meshHold tracks the pending node-to-node mesh transition between the loops.
+type meshHold struct {
+	state bool
+	since time.Time
+}
+
+func eventRecorder(kubeClient kubernetes.Interface) (record.EventRecorder, watch.Interface, record.EventBroadcaster) {
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartLogging(logrus.New().Debugf)
+	w := eventBroadcaster.StartRecordingToSink(
+		&typedcorev1.EventSinkImpl{
+			Interface: kubeClient.CoreV1().Events(""),
+		},
+	)
+
+	recorder := eventBroadcaster.NewRecorder(
+		scheme.Scheme,
+		v1.EventSource{Component: "netris-operator"},
+	)
+
+	return recorder, w, eventBroadcaster
+}
+
+// meshEventObject is the object the mesh events are recorded on: the CalicoIntegration,
+// or the BGPConfiguration when the integration is enabled by the annotation.
+func (w *Watcher) meshEventObject() *v1.ObjectReference {
+	if obj := w.data.integration.object; obj != nil {
+		return &v1.ObjectReference{
+			APIVersion: "k8s.netris.ai/v1alpha1",
+			Kind:       "CalicoIntegration",
+			Name:       obj.Name,
+			UID:        obj.UID,
+		}
+	}
+	conf := w.data.bgpConfs[0]
+	return &v1.ObjectReference{
+		APIVersion: "crd.projectcalico.org/v1",
+		Kind:       "BGPConfiguration",
+		Name:       conf.Metadata.Name,
+		UID:        conf.Metadata.UID,
+	}
+}
+
+// setMesh switches the node-to-node mesh and records the transition.
+func (w *Watcher) setMesh(enabled bool, reason, message string) error {
+	state := "disabled"
+	if enabled {
+		state = "enabled"
+	}
+
+	debugLogger.Info(fmt.Sprintf("Setting NodeToNodeMesh %s in BGP Configuration", state), "reason", message, "deleteMode", w.data.deleteMode)
+	if err := w.updateBGPConfMesh(enabled); err != nil {
+		return err
+	}
+	logger.Info(fmt.Sprintf("NodeToNodeMesh %s in BGP Configuration", state), "reason", message, "deleteMode", w.data.deleteMode)
+
+	meshTransitionsCounter.WithLabelValues(state).Inc()
+	if w.recorder != nil {
+		w.recorder.Event(w.meshEventObject(), v1.EventTypeNormal, reason, fmt.Sprintf("NodeToNodeMesh %s: %s", state, message))
+	}
+	return nil
+}
+
+// sessionsHealth returns the number of established sessions out of the sessions expected for all nodes
+// that should peer with the fabric. The sessions of skipped nodes and the ones not created yet count as unhealthy.
+func (w *Watcher) sessionsHealth() (int, int) {
+	generated := make(map[string]bool)
+	for _, bgp := range w.data.generatedBGPs {
+		generated[bgpKey(bgp)] = true
+	}
+
+	healthy := 0
+	for _, bgp := range w.data.bgpList {
+		if generated[bgpKey(bgp)] && bgpEstablished(bgp) {
+			healthy++
+		}
+	}
+
+	total := w.data.expectedSessions
+	if total < len(generated) {
+		total = len(generated)
+	}
+	return healthy, total
+}
+
+// meshProcessing enables the node-to-node mesh when the fabric sessions are not healthy enough and disables it
+// otherwise. A transition happens only once the sessions stay in the new state for the hold-down delay,
+// so a flapping session doesn't toggle the cluster-wide routing.
+func (w *Watcher) meshProcessing(healthy, total int) error {
+	itg := w.data.integration
+	conf := w.data.bgpConfs[0]
+
+	if total > 0 {
+		healthySessionsGauge.Set(float64(healthy) / float64(total))
+	} else {
+		healthySessionsGauge.Set(0)
+	}
+
+	// Calico runs the mesh unless it is explicitly disabled.
+	enabled := conf.Spec.NodeToNodeMeshEnabled == nil || *conf.Spec.NodeToNodeMeshEnabled
+	defer func() {
+		if conf.Spec.NodeToNodeMeshEnabled == nil || *conf.Spec.NodeToNodeMeshEnabled {
+			meshEnabledGauge.Set(1)
+		} else {
+			meshEnabledGauge.Set(0)
+		}
+	}()
+
+	if itg.meshFallback == "manual" {
+		w.meshHold = nil
+		return nil
+	}
+
+	healthyEnough := total > 0 && healthy*100 >= total*itg.meshMinHealthy
+	message := fmt.Sprintf("%d/%d sessions established", healthy, total)
+
+	want := !healthyEnough
+	delay := itg.meshEnableDelay
+	switch itg.meshFallback {
+	case "keep":
+		want, delay = true, 0
+	case "never":
+		// The mesh is only ever disabled, sessions going down don't bring it back.
+		want = want && enabled
+	}
+	if !want {
+		delay = itg.meshDisableDelay
+	}
+
+	if conf.Spec.NodeToNodeMeshEnabled != nil && want == enabled {
+		w.meshHold = nil
+		return nil
+	}
+
+	if w.meshHold == nil || w.meshHold.state != want {
+		w.meshHold = &meshHold{state: want, since: time.Now()}
+	}
+	if held := time.Since(w.meshHold.since); held < delay {
+		debugLogger.Info("Holding down NodeToNodeMesh transition", "enable", want, "held", held.String(), "delay", delay.String(), "sessions", message)
+		w.queue.AddAfter(clusterKey, delay-held)
+		return nil
+	}
+	w.meshHold = nil
+
+	reason := "MeshDisabled"
+	if want {
+		reason = "MeshEnabled"
+	}
+	return w.setMesh(want, reason, message)
+}
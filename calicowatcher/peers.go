@@ -0,0 +1,151 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package calicowatcher
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/netrisai/netris-operator/calicowatcher/calico"
+	"github.com/r3labs/diff/v2"
+)
+
+// netrisPeerPrefix is the name prefix of the Calico BGPPeers managed by the watcher.
+const netrisPeerPrefix = "netris-controller"
+
+// netrisPeerGroup is a VNet gateway together with the nodes peering with it.
+type netrisPeerGroup struct {
+	ip        string
+	asn       int
+	hostnames []string
+}
+
+// generateNetrisPeers generates one Calico BGPPeer per VNet gateway,
+// which selects only the nodes living in the gateway's subnet.
+func (w *Watcher) generateNetrisPeers() []*calico.BGPPeer {
+	nameReg, _ := regexp.Compile("[^a-z0-9.]+")
+	groups := make(map[string]*netrisPeerGroup)
+
+	addNode := func(vnetName, gw string, asn int, hostname string) {
+		if gw == "" {
+			return
+		}
+		ip := strings.Split(gw, "/")[0]
+		name := fmt.Sprintf("%s-%s-%s", netrisPeerPrefix, strings.ToLower(vnetName), ip)
+		name = strings.Trim(nameReg.ReplaceAllString(name, "-"), "-")
+		if _, ok := groups[name]; !ok {
+			groups[name] = &netrisPeerGroup{ip: ip, asn: asn}
+		}
+		groups[name].hostnames = append(groups[name].hostnames, hostname)
+	}
+
+	for _, node := range w.data.nodesMap {
+		if node.IP != "" {
+			addNode(node.VNet, node.GW, node.Site.PublicAsn, node.Hostname)
+		}
+		if node.IPv6 != "" {
+			addNode(node.VNet6, node.GW6, node.Site.PublicAsn, node.Hostname)
+		}
+	}
+
+	peers := []*calico.BGPPeer{}
+	for name, group := range groups {
+		sort.Strings(group.hostnames)
+		hostnames := make([]string, 0, len(group.hostnames))
+		for _, hostname := range group.hostnames {
+			hostnames = append(hostnames, fmt.Sprintf("'%s'", hostname))
+		}
+
+		peer := w.Calico.GenerateBGPPeer(name, "", group.ip, group.asn)
+		peer.Spec.NodeSelector = fmt.Sprintf("kubernetes.io/hostname in { %s }", strings.Join(hostnames, ", "))
+		if ref := w.data.integration.passwordRef; ref != nil {
+			peer.Spec.Password = &calico.BGPPassword{
+				SecretKeyRef: &calico.SecretKeySelector{Name: ref.Name, Key: ref.Key},
+			}
+		}
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
+// getNetrisPeers returns the Calico BGPPeers managed by the watcher.
+func (w *Watcher) getNetrisPeers() ([]*calico.BGPPeer, error) {
+	peers, err := w.Calico.GetBGPPeers()
+	if err != nil {
+		return nil, err
+	}
+
+	netrisPeers := []*calico.BGPPeer{}
+	for _, peer := range peers {
+		if strings.HasPrefix(peer.Name, netrisPeerPrefix) {
+			netrisPeers = append(netrisPeers, peer)
+		}
+	}
+	return netrisPeers, nil
+}
+
+// netrisPeersProcessing creates, updates and deletes the Calico BGPPeers of the VNet gateways.
+func (w *Watcher) netrisPeersProcessing() error {
+	debugLogger.Info("Getting netris-controller peers", "deleteMode", w.data.deleteMode)
+	netrisPeers, err := w.getNetrisPeers()
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]*calico.BGPPeer)
+	for _, peer := range netrisPeers {
+		existing[peer.Name] = peer
+	}
+
+	debugLogger.Info("Generating netris-controller peers", "deleteMode", w.data.deleteMode)
+	for _, peer := range w.generateNetrisPeers() {
+		netrisPeer, ok := existing[peer.Metadata.Name]
+		delete(existing, peer.Metadata.Name)
+
+		if !ok {
+			debugLogger.Info("Creating netris-controller peer", "peer", peer.Metadata.Name, "deleteMode", w.data.deleteMode)
+			if err := w.Calico.CreateBGPPeer(peer); err != nil {
+				return err
+			}
+			logger.Info("netris-controller peer created", "peer", peer.Metadata.Name, "deleteMode", w.data.deleteMode)
+			continue
+		}
+
+		changelog, _ := diff.Diff(netrisPeer.Spec, peer.Spec)
+		if len(changelog) > 0 {
+			debugLogger.Info("Updating netris-controller peer", "peer", peer.Metadata.Name, "deleteMode", w.data.deleteMode)
+			netrisPeer.Spec = peer.Spec
+			if err := w.Calico.UpdateBGPPeer(netrisPeer); err != nil {
+				return err
+			}
+			logger.Info("netris-controller peer updated", "peer", peer.Metadata.Name, "deleteMode", w.data.deleteMode)
+		}
+	}
+
+	// Peers of gateways without nodes, including the single cluster-wide
+	// peers of the previous versions, are removed after the new ones are in place.
+	for name, peer := range existing {
+		debugLogger.Info("Deleting netris-controller peer", "peer", name, "deleteMode", w.data.deleteMode)
+		if err := w.Calico.DeleteBGPPeer(peer); err != nil {
+			return err
+		}
+		logger.Info("netris-controller peer deleted", "peer", name, "deleteMode", w.data.deleteMode)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package calicowatcher
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/netrisai/netris-operator/calicowatcher/calico"
+	"github.com/r3labs/diff/v2"
+)
+
+const (
+	// routeReflectorsPeer is the Calico BGPPeer peering all nodes with the route reflectors.
+	routeReflectorsPeer = "netris-route-reflectors"
+	// routeReflectorAnnotation marks the Calico nodes the watcher made route reflectors.
+	routeReflectorAnnotation = "k8s.netris.ai/route-reflector"
+
+	defaultRouteReflectorClusterID = "244.0.0.1"
+	defaultClusterASN              = 64512
+)
+
+// clusterASN is the AS number of the nodes without their own, as configured in the BGPConfiguration.
+func (w *Watcher) clusterASN() int {
+	if len(w.data.bgpConfs) > 0 && w.data.bgpConfs[0].Spec.ASNumber > 0 {
+		return w.data.bgpConfs[0].Spec.ASNumber
+	}
+	return defaultClusterASN
+}
+
+// routeReflectorsProcessing makes the route reflector nodes peering with the fabric Calico route reflectors
+// and peers all nodes with them. Outside of route reflector mode it reverts what it has done.
+func (w *Watcher) routeReflectorsProcessing() error {
+	itg := w.data.integration
+	enabled := itg.rrSelector != nil && !w.data.deleteMode
+
+	reflectors := make(map[string]bool)
+	hostnames := []string{}
+	if enabled {
+		for name, node := range w.data.nodesMap {
+			reflectors[name] = true
+			hostnames = append(hostnames, fmt.Sprintf("'%s'", node.Hostname))
+		}
+		sort.Strings(hostnames)
+	}
+
+	debugLogger.Info("Getting Calico nodes", "deleteMode", w.data.deleteMode)
+	nodes, err := w.Calico.GetNodes()
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		_, marked := node.GetAnnotations()[routeReflectorAnnotation]
+		clusterID := ""
+		if node.Spec.BGP != nil {
+			clusterID = node.Spec.BGP.RouteReflectorClusterID
+		}
+
+		if reflectors[node.Name] {
+			if marked && clusterID == itg.rrClusterID {
+				continue
+			}
+			debugLogger.Info("Making Calico node a route reflector", "node", node.Name, "clusterID", itg.rrClusterID, "deleteMode", w.data.deleteMode)
+			if err := w.Calico.SetNodeRouteReflector(node.Name, itg.rrClusterID, map[string]string{routeReflectorAnnotation: "true"}); err != nil {
+				return err
+			}
+			logger.Info("Calico node is a route reflector", "node", node.Name, "clusterID", itg.rrClusterID, "deleteMode", w.data.deleteMode)
+		} else if marked {
+			debugLogger.Info("Making Calico node a regular node", "node", node.Name, "deleteMode", w.data.deleteMode)
+			if err := w.Calico.SetNodeRouteReflector(node.Name, "", map[string]string{routeReflectorAnnotation: ""}); err != nil {
+				return err
+			}
+			logger.Info("Calico node is not a route reflector anymore", "node", node.Name, "deleteMode", w.data.deleteMode)
+		}
+	}
+
+	debugLogger.Info("Getting route reflectors peer", "deleteMode", w.data.deleteMode)
+	rrPeer, err := w.Calico.GetBGPPeer(routeReflectorsPeer)
+	if err != nil {
+		return err
+	}
+
+	if !enabled || len(hostnames) == 0 {
+		if rrPeer != nil {
+			debugLogger.Info("Deleting route reflectors peer", "deleteMode", w.data.deleteMode)
+			if err := w.Calico.DeleteBGPPeer(rrPeer); err != nil {
+				return err
+			}
+			logger.Info("Route reflectors peer deleted", "deleteMode", w.data.deleteMode)
+		}
+		return nil
+	}
+
+	peer := w.Calico.GenerateBGPPeer(routeReflectorsPeer, "", "", 0)
+	peer.Spec = calico.BGPPeerSpec{
+		NodeSelector: "all()",
+		PeerSelector: fmt.Sprintf("kubernetes.io/hostname in { %s }", strings.Join(hostnames, ", ")),
+	}
+
+	if rrPeer == nil {
+		debugLogger.Info("Creating route reflectors peer", "deleteMode", w.data.deleteMode)
+		if err := w.Calico.CreateBGPPeer(peer); err != nil {
+			return err
+		}
+		logger.Info("Route reflectors peer created", "deleteMode", w.data.deleteMode)
+		return nil
+	}
+
+	if changelog, _ := diff.Diff(rrPeer.Spec, peer.Spec); len(changelog) > 0 {
+		debugLogger.Info("Updating route reflectors peer", "deleteMode", w.data.deleteMode)
+		rrPeer.Spec = peer.Spec
+		if err := w.Calico.UpdateBGPPeer(rrPeer); err != nil {
+			return err
+		}
+		logger.Info("Route reflectors peer updated", "deleteMode", w.data.deleteMode)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package calicowatcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/netrisai/netris-operator/calicowatcher/calico"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func testCalicoNode(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "crd.projectcalico.org/v1",
+		"kind":       "Node",
+		"metadata":   map[string]interface{}{"name": name},
+		"spec":       map[string]interface{}{"bgp": map[string]interface{}{"ipv4Address": "10.0.0.11/24"}},
+	}}
+}
+
+func TestRouteReflectorsProcessing(t *testing.T) {
+	nodeResource := schema.GroupVersionResource{Group: "crd.projectcalico.org", Version: "v1", Resource: "nodes"}
+	peerResource := schema.GroupVersionResource{Group: "crd.projectcalico.org", Version: "v1", Resource: "bgppeers"}
+
+	dynClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), testCalicoNode("node1"), testCalicoNode("node2"), testCalicoNode("node3"))
+	dynClient.PrependReactor("*", "bgppeers", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if a, ok := action.(clienttesting.CreateAction); ok {
+			if obj, err := meta.Accessor(a.GetObject()); err == nil {
+				obj.SetNamespace("")
+			}
+		}
+		return false, nil, nil
+	})
+
+	w := &Watcher{Calico: calico.New(dynClient, calico.Options{})}
+	w.data.integration = defaultIntegration()
+
+	reflectors := func(names ...string) map[string]*nodeIP {
+		nodes := make(map[string]*nodeIP)
+		for _, name := range names {
+			nodes[name] = &nodeIP{Hostname: name}
+		}
+		return nodes
+	}
+
+	check := func(step string, wantReflectors []string, wantPeerSelector string) {
+		t.Helper()
+		want := make(map[string]bool)
+		for _, name := range wantReflectors {
+			want[name] = true
+		}
+		for _, name := range []string{"node1", "node2", "node3"} {
+			node, err := dynClient.Resource(nodeResource).Get(context.Background(), name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			clusterID, _, _ := unstructured.NestedString(node.Object, "spec", "bgp", "routeReflectorClusterID")
+			_, marked := node.GetAnnotations()[routeReflectorAnnotation]
+			if want[name] && (clusterID != "244.0.0.1" || !marked) {
+				t.Errorf("%s: %s is not a route reflector, clusterID %q, annotated %v", step, name, clusterID, marked)
+			}
+			if !want[name] && (clusterID != "" || marked) {
+				t.Errorf("%s: %s is a route reflector, clusterID %q, annotated %v", step, name, clusterID, marked)
+			}
+			if ip, _, _ := unstructured.NestedString(node.Object, "spec", "bgp", "ipv4Address"); ip == "" {
+				t.Errorf("%s: %s lost its BGP address", step, name)
+			}
+		}
+
+		peer, err := dynClient.Resource(peerResource).Get(context.Background(), routeReflectorsPeer, metav1.GetOptions{})
+		if wantPeerSelector == "" {
+			if !apierrors.IsNotFound(err) {
+				t.Errorf("%s: route reflectors peer exists, error %v", step, err)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("%s: %s", step, err)
+		}
+		nodeSelector, _, _ := unstructured.NestedString(peer.Object, "spec", "nodeSelector")
+		peerSelector, _, _ := unstructured.NestedString(peer.Object, "spec", "peerSelector")
+		if nodeSelector != "all()" || peerSelector != wantPeerSelector {
+			t.Errorf("%s: peer selectors = %q, %q, want all(), %q", step, nodeSelector, peerSelector, wantPeerSelector)
+		}
+	}
+
+	w.data.integration.rrSelector = labels.Everything()
+	w.data.integration.rrClusterID = defaultRouteReflectorClusterID
+	w.data.nodesMap = reflectors("node1", "node2")
+	if err := w.routeReflectorsProcessing(); err != nil {
+		t.Fatal(err)
+	}
+	check("enabled", []string{"node1", "node2"}, "kubernetes.io/hostname in { 'node1', 'node2' }")
+
+	w.data.nodesMap = reflectors("node2", "node3")
+	if err := w.routeReflectorsProcessing(); err != nil {
+		t.Fatal(err)
+	}
+	check("reflectors changed", []string{"node2", "node3"}, "kubernetes.io/hostname in { 'node2', 'node3' }")
+
+	w.data.deleteMode = true
+	if err := w.routeReflectorsProcessing(); err != nil {
+		t.Fatal(err)
+	}
+	check("delete mode", nil, "")
+
+	w.data.deleteMode = false
+	if err := w.routeReflectorsProcessing(); err != nil {
+		t.Fatal(err)
+	}
+	check("enabled again", []string{"node2", "node3"}, "kubernetes.io/hostname in { 'node2', 'node3' }")
+
+	w.data.integration.rrSelector = nil
+	if err := w.routeReflectorsProcessing(); err != nil {
+		t.Fatal(err)
+	}
+	check("disabled", nil, "")
+}
@@ -50,3 +50,8 @@ func findIPAMByIP(ip string, subnets []*ipam.IPAM) (*ipam.IPAM, error) {
 func FindIPAMByIP(ip string, subnets []*ipam.IPAM) (*ipam.IPAM, error) {
 	return findIPAMByIP(ip, subnets)
 }
+
+func isIPv6CIDR(cidr string) bool {
+	ip, _, err := net.ParseCIDR(cidr)
+	return err == nil && ip.To4() == nil
+}
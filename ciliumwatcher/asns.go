@@ -0,0 +1,56 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ciliumwatcher
+
+import (
+	"github.com/netrisai/netris-operator/watchercore"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// asnAnnotation keeps the AS number assigned to the node.
+	asnAnnotation = "k8s.netris.ai/cilium-asn"
+	// asnLedgerName is the ConfigMap recording the AS numbers assigned to the nodes.
+	asnLedgerName = "netris-operator-cilium-asns"
+)
+
+func (w *Watcher) asnLedgerNamespace() string {
+	if w.Options.Namespace != "" {
+		return w.Options.Namespace
+	}
+	return "default"
+}
+
+// fillNodesASNs assigns the AS numbers to the Cilium nodes through the ledger and annotates the nodes with them.
+func (w *Watcher) fillNodesASNs() error {
+	nodes := []*v1.Node{}
+	for _, cNode := range w.data.ciliumNodes {
+		if node, ok := w.data.nodes[cNode.Name]; ok {
+			nodes = append(nodes, node)
+		}
+	}
+	return w.ledger.Assign(nodes, w.data.asnStart, w.data.asnEnd, watchercore.DefaultASNReclaimAfter)
+}
+
+// deleteNodesASNs removes the AS numbers of the range from the nodes and deletes the ledger.
+func (w *Watcher) deleteNodesASNs() error {
+	nodes := []*v1.Node{}
+	for _, node := range w.data.nodes {
+		nodes = append(nodes, node)
+	}
+	return w.ledger.Release(nodes, w.data.asnStart, w.data.asnEnd)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cilium
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// BGPPeeringPolicy is the CiliumBGPPeeringPolicy resource of the Cilium BGP control plane.
+type BGPPeeringPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              BGPPeeringPolicySpec `json:"spec"`
+}
+
+// BGPPeeringPolicySpec selects the nodes and describes their virtual routers.
+// Only the fields managed by the operator are kept, the defaults filled by the API server are dropped.
+type BGPPeeringPolicySpec struct {
+	NodeSelector   *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+	VirtualRouters []BGPVirtualRouter    `json:"virtualRouters"`
+}
+
+// BGPVirtualRouter is a BGP speaker of the node in the given AS.
+type BGPVirtualRouter struct {
+	LocalASN      int64         `json:"localASN"`
+	ExportPodCIDR bool          `json:"exportPodCIDR,omitempty"`
+	Neighbors     []BGPNeighbor `json:"neighbors"`
+}
+
+// BGPNeighbor is a BGP peer of the virtual router.
+type BGPNeighbor struct {
+	// PeerAddress is the address of the peer in CIDR notation, e.g. "10.0.0.1/32".
+	PeerAddress string `json:"peerAddress"`
+	PeerASN     int64  `json:"peerASN"`
+}
+
+var bgpPeeringPolicyResource = schema.GroupVersionResource{
+	Group:    "cilium.io",
+	Version:  "v2alpha1",
+	Resource: "ciliumbgppeeringpolicies",
+}
+
+// GetBGPPeeringPolicies returns the policies matching the label selector.
+func (c *Cilium) GetBGPPeeringPolicies(selector string) ([]*BGPPeeringPolicy, error) {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+
+	list, err := c.client.Resource(bgpPeeringPolicyResource).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []*BGPPeeringPolicy
+	for _, item := range list.Items {
+		js, err := item.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("{GetBGPPeeringPolicies} %s", err)
+		}
+
+		var policy *BGPPeeringPolicy
+		err = json.Unmarshal(js, &policy)
+		if err != nil {
+			return nil, fmt.Errorf("{GetBGPPeeringPolicies} %s", err)
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// CreateBGPPeeringPolicy .
+func (c *Cilium) CreateBGPPeeringPolicy(policy *BGPPeeringPolicy) error {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+
+	obj, err := policyToUnstructured(policy)
+	if err != nil {
+		return fmt.Errorf("{CreateBGPPeeringPolicy} %s", err)
+	}
+
+	_, err = c.client.Resource(bgpPeeringPolicyResource).Create(ctx, obj, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("{CreateBGPPeeringPolicy} %s", err)
+	}
+	return nil
+}
+
+// UpdateBGPPeeringPolicy .
+func (c *Cilium) UpdateBGPPeeringPolicy(policy *BGPPeeringPolicy) error {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+
+	obj, err := policyToUnstructured(policy)
+	if err != nil {
+		return fmt.Errorf("{UpdateBGPPeeringPolicy} %s", err)
+	}
+
+	_, err = c.client.Resource(bgpPeeringPolicyResource).Update(ctx, obj, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("{UpdateBGPPeeringPolicy} %s", err)
+	}
+	return nil
+}
+
+// DeleteBGPPeeringPolicy .
+func (c *Cilium) DeleteBGPPeeringPolicy(name string) error {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+
+	err := c.client.Resource(bgpPeeringPolicyResource).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("{DeleteBGPPeeringPolicy} %s", err)
+	}
+	return nil
+}
+
+func policyToUnstructured(policy *BGPPeeringPolicy) (*unstructured.Unstructured, error) {
+	policy.APIVersion = bgpPeeringPolicyResource.GroupVersion().String()
+	policy.Kind = "CiliumBGPPeeringPolicy"
+
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(policy)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: m}, nil
+}
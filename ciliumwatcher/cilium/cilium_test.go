@@ -0,0 +1,114 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cilium
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func ciliumNode(name string, addresses []interface{}, podCIDRs []interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cilium.io/v2",
+		"kind":       "CiliumNode",
+		"metadata":   map[string]interface{}{"name": name},
+		"spec": map[string]interface{}{
+			"addresses": addresses,
+			"ipam":      map[string]interface{}{"podCIDRs": podCIDRs},
+		},
+	}}
+}
+
+func TestGetNodes(t *testing.T) {
+	client := fake.NewSimpleDynamicClient(runtime.NewScheme(),
+		ciliumNode("node1",
+			[]interface{}{
+				map[string]interface{}{"type": "InternalIP", "ip": "10.0.0.11"},
+				map[string]interface{}{"type": "CiliumInternalIP", "ip": "10.244.0.1"},
+			},
+			[]interface{}{"10.244.0.0/24"},
+		),
+	)
+
+	nodes, err := New(client, Options{}).GetNodes()
+	if err != nil {
+		t.Fatalf("GetNodes() error = %v", err)
+	}
+	if len(nodes) != 1 {
+		t.Fatalf("GetNodes() returned %d nodes, want 1", len(nodes))
+	}
+
+	want := NodeSpec{
+		Addresses: []NodeAddress{{Type: NodeInternalIP, IP: "10.0.0.11"}, {Type: "CiliumInternalIP", IP: "10.244.0.1"}},
+		IPAM:      NodeIPAMSpec{PodCIDRs: []string{"10.244.0.0/24"}},
+	}
+	if nodes[0].Name != "node1" || !reflect.DeepEqual(nodes[0].Spec, want) {
+		t.Errorf("GetNodes() = %s %+v, want node1 %+v", nodes[0].Name, nodes[0].Spec, want)
+	}
+}
+
+func TestBGPPeeringPolicies(t *testing.T) {
+	c := New(fake.NewSimpleDynamicClient(runtime.NewScheme()), Options{})
+
+	policy := &BGPPeeringPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "netris-node1", Labels: map[string]string{"managed": "true"}},
+		Spec: BGPPeeringPolicySpec{
+			NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/hostname": "node1"}},
+			VirtualRouters: []BGPVirtualRouter{{
+				LocalASN:      4230000000,
+				ExportPodCIDR: true,
+				Neighbors:     []BGPNeighbor{{PeerAddress: "10.0.0.1/32", PeerASN: 65001}},
+			}},
+		},
+	}
+	if err := c.CreateBGPPeeringPolicy(policy); err != nil {
+		t.Fatalf("CreateBGPPeeringPolicy() error = %v", err)
+	}
+	other := &BGPPeeringPolicy{ObjectMeta: metav1.ObjectMeta{Name: "other"}}
+	if err := c.CreateBGPPeeringPolicy(other); err != nil {
+		t.Fatalf("CreateBGPPeeringPolicy() error = %v", err)
+	}
+
+	policies, err := c.GetBGPPeeringPolicies("managed=true")
+	if err != nil {
+		t.Fatalf("GetBGPPeeringPolicies() error = %v", err)
+	}
+	if len(policies) != 1 || !reflect.DeepEqual(policies[0].Spec, policy.Spec) {
+		t.Fatalf("GetBGPPeeringPolicies() = %+v, want %+v", policies, policy.Spec)
+	}
+
+	policy.Spec.VirtualRouters[0].Neighbors[0].PeerASN = 65002
+	if err := c.UpdateBGPPeeringPolicy(policy); err != nil {
+		t.Fatalf("UpdateBGPPeeringPolicy() error = %v", err)
+	}
+	policies, _ = c.GetBGPPeeringPolicies("managed=true")
+	if got := policies[0].Spec.VirtualRouters[0].Neighbors[0].PeerASN; got != 65002 {
+		t.Errorf("peerASN after update = %d, want 65002", got)
+	}
+
+	if err := c.DeleteBGPPeeringPolicy(policy.Name); err != nil {
+		t.Fatalf("DeleteBGPPeeringPolicy() error = %v", err)
+	}
+	if policies, _ = c.GetBGPPeeringPolicies("managed=true"); len(policies) != 0 {
+		t.Errorf("GetBGPPeeringPolicies() after delete = %+v, want none", policies)
+	}
+}
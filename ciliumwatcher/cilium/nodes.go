@@ -0,0 +1,96 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cilium
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Node is the CiliumNode resource, the Cilium view of a Kubernetes node.
+type Node struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              NodeSpec `json:"spec,omitempty"`
+}
+
+// NodeSpec contains the addresses and the IPAM state of the node.
+type NodeSpec struct {
+	Addresses []NodeAddress `json:"addresses,omitempty"`
+	IPAM      NodeIPAMSpec  `json:"ipam,omitempty"`
+}
+
+// NodeAddress is an address of the node.
+type NodeAddress struct {
+	Type string `json:"type,omitempty"`
+	IP   string `json:"ip,omitempty"`
+}
+
+// NodeIPAMSpec is the IPAM specification of the node.
+type NodeIPAMSpec struct {
+	// PodCIDRs are the pod CIDRs allocated to the node by the cluster-pool IPAM.
+	PodCIDRs []string `json:"podCIDRs,omitempty"`
+}
+
+// NodeInternalIP is the address type of the node addresses peered with the fabric.
+const NodeInternalIP = "InternalIP"
+
+// NodeResource is the CiliumNode resource.
+var NodeResource = schema.GroupVersionResource{
+	Group:    "cilium.io",
+	Version:  "v2",
+	Resource: "ciliumnodes",
+}
+
+// GetNodes .
+func (c *Cilium) GetNodes() ([]*Node, error) {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+
+	list, err := c.client.Resource(NodeResource).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []*Node
+	for i := range list.Items {
+		node, err := ParseNode(&list.Items[i])
+		if err != nil {
+			return nil, fmt.Errorf("{GetNodes} %s", err)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// ParseNode converts the unstructured CiliumNode, as returned by the dynamic client and informers.
+func ParseNode(obj *unstructured.Unstructured) (*Node, error) {
+	js, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var node *Node
+	if err := json.Unmarshal(js, &node); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
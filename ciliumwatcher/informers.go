@@ -0,0 +1,142 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ciliumwatcher
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/netrisai/netris-operator/ciliumwatcher/cilium"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// setupInformers registers event handlers for Nodes and waits until their cache is synced. Nothing is resynced,
+// the processing is driven by the events of the Nodes and CiliumNodes. The CiliumNode informer is set up once
+// Cilium is detected, see setupCiliumInformers.
+func (w *Watcher) setupInformers(stop <-chan struct{}) error {
+	factory := informers.NewSharedInformerFactory(w.clientset, 0)
+
+	nodeInformer := factory.Core().V1().Nodes()
+	nodeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(_ interface{}) { w.queue.Add(clusterKey) },
+		UpdateFunc: func(old, obj interface{}) {
+			if nodeChanged(old, obj) {
+				w.queue.Add(clusterKey)
+			}
+		},
+		DeleteFunc: func(_ interface{}) { w.queue.Add(clusterKey) },
+	})
+
+	factory.Start(stop)
+	for informer, synced := range factory.WaitForCacheSync(stop) {
+		if !synced {
+			return fmt.Errorf("{setupInformers} failed to sync %s informer", informer)
+		}
+	}
+	return nil
+}
+
+// setupCiliumInformers registers event handlers for CiliumNodes and waits until their cache is synced.
+func (w *Watcher) setupCiliumInformers(stop <-chan struct{}) error {
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(w.dynamicClient, 0)
+
+	ciliumNodeInformer := factory.ForResource(cilium.NodeResource)
+	ciliumNodeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(_ interface{}) { w.queue.Add(clusterKey) },
+		UpdateFunc: func(old, obj interface{}) {
+			if resourceVersionChanged(old, obj) {
+				w.queue.Add(clusterKey)
+			}
+		},
+		DeleteFunc: func(_ interface{}) { w.queue.Add(clusterKey) },
+	})
+
+	factory.Start(stop)
+	for resource, synced := range factory.WaitForCacheSync(stop) {
+		if !synced {
+			return fmt.Errorf("{setupCiliumInformers} failed to sync %s informer", resource.Resource)
+		}
+	}
+	w.ciliumNodeLister = ciliumNodeInformer.Lister()
+	return nil
+}
+
+// ciliumNodes returns the CiliumNodes from the informer cache.
+func (w *Watcher) ciliumNodes() ([]*cilium.Node, error) {
+	objs, err := w.ciliumNodeLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("{ciliumNodes} %s", err)
+	}
+	nodes := []*cilium.Node{}
+	for _, obj := range objs {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return nil, fmt.Errorf("{ciliumNodes} unexpected object %T", obj)
+		}
+		node, err := cilium.ParseNode(u)
+		if err != nil {
+			return nil, fmt.Errorf("{ciliumNodes} %s", err)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// nodeChanged reports whether the change of the node affects its BGP sessions and peering policy.
+func nodeChanged(old, obj interface{}) bool {
+	oldNode, ok1 := old.(*v1.Node)
+	node, ok2 := obj.(*v1.Node)
+	if !ok1 || !ok2 {
+		return true
+	}
+	return !reflect.DeepEqual(oldNode.Labels, node.Labels) ||
+		oldNode.Annotations[asnAnnotation] != node.Annotations[asnAnnotation] ||
+		oldNode.Spec.PodCIDR != node.Spec.PodCIDR ||
+		!reflect.DeepEqual(oldNode.Spec.PodCIDRs, node.Spec.PodCIDRs)
+}
+
+// resourceVersionChanged filters out the updates which don't change the object.
+func resourceVersionChanged(old, obj interface{}) bool {
+	oldMeta, err1 := meta.Accessor(old)
+	objMeta, err2 := meta.Accessor(obj)
+	if err1 != nil || err2 != nil {
+		return true
+	}
+	return oldMeta.GetResourceVersion() != objMeta.GetResourceVersion()
+}
+
+func (w *Watcher) processNextItem() bool {
+	key, quit := w.queue.Get()
+	if quit {
+		return false
+	}
+	defer w.queue.Done(key)
+
+	if err := w.mainProcessing(); err != nil {
+		logger.Error(err, "", "key", key)
+		w.queue.AddRateLimited(key)
+		return true
+	}
+	w.queue.Forget(key)
+	return true
+}
@@ -0,0 +1,336 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ciliumwatcher
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/ciliumwatcher/cilium"
+	"github.com/netrisai/netris-operator/netrisstorage"
+	"github.com/netrisai/netris-operator/watchercore"
+	"go.uber.org/zap/zapcore"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+var (
+	requeueInterval = time.Duration(10 * time.Second)
+	logger          = ctrl.Log.WithName("CiliumWatcher")
+	debugLogger     = logger.V(int(zapcore.WarnLevel))
+	cntxt           = context.Background()
+	contextTimeout  = requeueInterval
+)
+
+// clusterKey is the queue key of the processing, the watcher processes all nodes at once.
+const clusterKey = "/cluster"
+
+// Watcher is the main structure in order to manage ciliumwatcher
+type Watcher struct {
+	Options  Options
+	NStorage *netrisstorage.Storage
+	MGR      manager.Manager
+	Cilium   *cilium.Cilium
+
+	client           client.Client
+	clientset        kubernetes.Interface
+	dynamicClient    dynamic.Interface
+	queue            workqueue.RateLimitingInterface
+	ciliumNodeLister cache.GenericLister
+	ledger           *watchercore.ASNLedger
+	bgps             *watchercore.BGPs
+	data             data
+	stop             chan struct{}
+
+	ciliumNotFound bool
+}
+
+type data struct {
+	deleteMode    bool
+	generatedBGPs []*v1alpha1.BGP
+
+	ciliumNodes []*cilium.Node
+	nodes       map[string]*v1.Node
+	nodesMap    map[string]*nodeIP
+	asnStart    int
+	asnEnd      int
+}
+
+// Options is the main options struct.
+type Options struct {
+	RequeueInterval int
+	LogLevel        string
+	// Enabled turns the integration on, when it's off everything created by the watcher is removed.
+	Enabled bool
+	// ASNRange is the "start-end" range of the node AS numbers.
+	ASNRange string
+	// Namespace is the namespace of the operator, where the AS number ledger is kept.
+	Namespace string
+}
+
+// NewWatcher is the main initialization function.
+func NewWatcher(nStorage *netrisstorage.Storage, mgr manager.Manager, options Options) (*Watcher, error) {
+	if nStorage == nil {
+		return nil, fmt.Errorf("please provide NStorage")
+	}
+
+	watcher := &Watcher{
+		NStorage: nStorage,
+		MGR:      mgr,
+		Options:  options,
+		stop:     make(chan struct{}),
+	}
+	return watcher, nil
+}
+
+// initClients creates the clients that weren't provided yet.
+func (w *Watcher) initClients() error {
+	if w.client == nil {
+		w.client = w.MGR.GetClient()
+	}
+	if w.clientset == nil || w.dynamicClient == nil {
+		restConfig := ctrl.GetConfigOrDie()
+		if w.clientset == nil {
+			clientset, err := kubernetes.NewForConfig(restConfig)
+			if err != nil {
+				return err
+			}
+			w.clientset = clientset
+		}
+		if w.dynamicClient == nil {
+			dynamicClient, err := dynamic.NewForConfig(restConfig)
+			if err != nil {
+				return err
+			}
+			w.dynamicClient = dynamicClient
+		}
+	}
+	if w.Cilium == nil {
+		w.Cilium = cilium.New(w.dynamicClient, cilium.Options{ContextTimeout: w.Options.RequeueInterval})
+	}
+	if w.ledger == nil {
+		w.ledger = &watchercore.ASNLedger{
+			Clientset:      w.clientset,
+			Logger:         logger,
+			Name:           asnLedgerName,
+			Namespace:      w.asnLedgerNamespace(),
+			Annotation:     asnAnnotation,
+			ContextTimeout: contextTimeout,
+		}
+	}
+	if w.bgps == nil {
+		w.bgps = &watchercore.BGPs{
+			Client:         w.client,
+			Logger:         logger,
+			Annotation:     watcherAnnotation,
+			ContextTimeout: contextTimeout,
+		}
+	}
+	return nil
+}
+
+// Start .
+func (w *Watcher) Start() {
+	if w.Options.LogLevel == "debug" {
+		logger = zap.New(zap.Level(zapcore.DebugLevel), zap.UseDevMode(false))
+	} else {
+		logger = zap.New(zap.UseDevMode(false), zap.StacktraceLevel(zapcore.DPanicLevel))
+	}
+
+	logger = ctrl.Log.WithName("CiliumWatcher")
+	debugLogger = logger.V(int(zapcore.WarnLevel))
+
+	if w.Options.RequeueInterval > 0 {
+		requeueInterval = time.Duration(time.Duration(w.Options.RequeueInterval) * time.Second)
+		contextTimeout = requeueInterval
+	}
+
+	if err := w.initClients(); err != nil {
+		logger.Error(err, "")
+		return
+	}
+	w.queue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "ciliumwatcher")
+	defer w.queue.ShutDown()
+	defer close(w.stop)
+
+	if err := w.setupInformers(w.stop); err != nil {
+		logger.Error(err, "")
+		return
+	}
+
+	// The events drive the processing from here on, see setupInformers.
+	w.queue.Add(clusterKey)
+
+	for w.processNextItem() {
+	}
+}
+
+func (w *Watcher) mainProcessing() error {
+	w.data = data{}
+	asnRange := w.Options.ASNRange
+	if len(asnRange) == 0 {
+		asnRange = watchercore.DefaultASNRange
+	}
+	a, b, err := watchercore.ParseASNRange(asnRange)
+	if err != nil {
+		return err
+	}
+	w.data.asnStart = a
+	w.data.asnEnd = b
+
+	if w.ciliumNodeLister == nil {
+		if _, err := w.Cilium.GetNodes(); err != nil {
+			if cilium.IsMissingResource(err) {
+				if !w.ciliumNotFound {
+					logger.Info("Cilium CNI not detected, waiting for it to be installed")
+					w.ciliumNotFound = true
+				}
+				// There are no CiliumNodes to watch yet, so the detection is polled.
+				w.queue.AddAfter(clusterKey, requeueInterval)
+				return nil
+			}
+			return err
+		}
+		logger.Info("Cilium CNI detected")
+		w.ciliumNotFound = false
+		if err := w.setupCiliumInformers(w.stop); err != nil {
+			return err
+		}
+	}
+	if w.data.ciliumNodes, err = w.ciliumNodes(); err != nil {
+		return err
+	}
+
+	if !w.Options.Enabled {
+		w.data.deleteMode = true
+	}
+
+	if w.data.deleteMode {
+		debugLogger.Info("Cilium integration is disabled", "deleteMode", w.data.deleteMode)
+		debugLogger.Info("Clearing Netris staff", "deleteMode", w.data.deleteMode)
+		return w.deleteProcess()
+	}
+	debugLogger.Info("Cilium integration is enabled", "deleteMode", w.data.deleteMode)
+	debugLogger.Info("Creating Netris staff", "deleteMode", w.data.deleteMode)
+	return w.process()
+}
+
+func (w *Watcher) process() error {
+	debugLogger.Info("Getting Nodes", "deleteMode", w.data.deleteMode)
+	if err := w.getNodes(); err != nil {
+		return err
+	}
+
+	debugLogger.Info("Filling Nodes AS numbers", "deleteMode", w.data.deleteMode)
+	if err := w.fillNodesASNs(); err != nil {
+		return err
+	}
+
+	debugLogger.Info("Nodes Processing", "deleteMode", w.data.deleteMode)
+	if err := w.nodesProcessing(); err != nil {
+		return err
+	}
+
+	debugLogger.Info("Generating BGPs", "deleteMode", w.data.deleteMode)
+	w.generateBGPs()
+
+	if err := w.bgpsProcessing(); err != nil {
+		return err
+	}
+
+	debugLogger.Info("Peering policies processing", "deleteMode", w.data.deleteMode)
+	return w.peeringPoliciesProcessing()
+}
+
+func (w *Watcher) deleteProcess() error {
+	w.data.generatedBGPs = []*v1alpha1.BGP{}
+	w.data.nodesMap = map[string]*nodeIP{}
+
+	if err := w.bgpsProcessing(); err != nil {
+		return err
+	}
+
+	debugLogger.Info("Peering policies processing", "deleteMode", w.data.deleteMode)
+	if err := w.peeringPoliciesProcessing(); err != nil {
+		return err
+	}
+
+	debugLogger.Info("Getting Nodes", "deleteMode", w.data.deleteMode)
+	if err := w.getNodes(); err != nil {
+		return err
+	}
+
+	debugLogger.Info("Deleting Nodes ASN annotation", "deleteMode", w.data.deleteMode)
+	return w.deleteNodesASNs()
+}
+
+// bgpsProcessing brings the BGPs created by the watcher in line with the generated ones.
+func (w *Watcher) bgpsProcessing() error {
+	debugLogger.Info("BGPs processing", "deleteMode", w.data.deleteMode)
+	return w.bgps.Sync(w.data.generatedBGPs)
+}
+
+// watcherAnnotation marks the BGPs generated by the watcher.
+const watcherAnnotation = "k8s.netris.ai/ciliumwatcher"
+
+func (w *Watcher) generateBGPs() {
+	generatedBGPs := []*v1alpha1.BGP{}
+	for _, name := range w.sortedNodeNames() {
+		node := w.data.nodesMap[name]
+		for _, gw := range node.Gateways {
+			generatedBGPs = append(generatedBGPs, generateBGP(name, node, gw))
+		}
+	}
+	w.data.generatedBGPs = generatedBGPs
+}
+
+// generateBGP generates the BGP session of the node address with its VNet gateway.
+// The node advertises its pod CIDRs of the same IP family and gets the default route back.
+func generateBGP(name string, node *nodeIP, gw *watchercore.Gateway) *v1alpha1.BGP {
+	defaultRoute := "0.0.0.0/0"
+	if gw.IPv6 {
+		defaultRoute = "::/0"
+	}
+
+	PrefixListInboundList := []string{}
+	for _, cidr := range node.PodCIDRs {
+		if watchercore.IsIPv6CIDR(cidr) != gw.IPv6 {
+			continue
+		}
+		PrefixListInboundList = append(PrefixListInboundList, fmt.Sprintf("permit %s", cidr))
+	}
+	PrefixListOutboundList := []string{fmt.Sprintf("permit %s", defaultRoute)}
+
+	return watchercore.GenerateBGP(fmt.Sprintf("%s-%s", name, gw.NodeIP), node.ASN, gw, PrefixListInboundList, PrefixListOutboundList, watcherAnnotation)
+}
+
+// gatewayAddress returns the gateway address as a host route, the way Cilium expects the peer address.
+func gatewayAddress(gw *watchercore.Gateway) string {
+	if gw.IPv6 {
+		return fmt.Sprintf("%s/128", gw.IP())
+	}
+	return fmt.Sprintf("%s/32", gw.IP())
+}
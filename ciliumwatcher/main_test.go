@@ -0,0 +1,271 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ciliumwatcher
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/ciliumwatcher/cilium"
+	"github.com/netrisai/netris-operator/watchercore"
+	"github.com/netrisai/netris-operator/watchercore/watchercoretest"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/util/workqueue"
+)
+
+var nodeGVR = schema.GroupVersionResource{Group: "cilium.io", Version: "v2", Resource: "ciliumnodes"}
+
+func testCiliumNode(name string, ips []string, podCIDRs []string) *unstructured.Unstructured {
+	addresses := []interface{}{}
+	for _, ip := range ips {
+		addresses = append(addresses, map[string]interface{}{"type": "InternalIP", "ip": ip})
+	}
+	cidrs := []interface{}{}
+	for _, cidr := range podCIDRs {
+		cidrs = append(cidrs, cidr)
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cilium.io/v2",
+		"kind":       "CiliumNode",
+		"metadata":   map[string]interface{}{"name": name},
+		"spec": map[string]interface{}{
+			"addresses": addresses,
+			"ipam":      map[string]interface{}{"podCIDRs": cidrs},
+		},
+	}}
+}
+
+type testCluster struct {
+	watcher   *Watcher
+	dynClient *dynamicfake.FakeDynamicClient
+	clientset *kubefake.Clientset
+}
+
+func newTestCluster(t *testing.T, nodes []runtime.Object, ciliumNodes ...runtime.Object) *testCluster {
+	dynClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), ciliumNodes...)
+	clientset := kubefake.NewSimpleClientset(nodes...)
+
+	w, err := NewWatcher(watchercoretest.Storage(t, "10.0.0.0/24", "fd00::/64"), nil, Options{Enabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.client = watchercoretest.NewClient(t)
+	w.clientset = clientset
+	w.dynamicClient = dynClient
+	if err := w.initClients(); err != nil {
+		t.Fatal(err)
+	}
+	w.queue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "ciliumwatcher")
+	t.Cleanup(func() {
+		w.queue.ShutDown()
+		close(w.stop)
+	})
+	if err := w.setupInformers(w.stop); err != nil {
+		t.Fatal(err)
+	}
+	return &testCluster{watcher: w, dynClient: dynClient, clientset: clientset}
+}
+
+// run processes the queue until it settles.
+func (c *testCluster) run(t *testing.T) {
+	t.Helper()
+	c.watcher.queue.Add(clusterKey)
+	watchercoretest.Settle(t, c.watcher.queue, c.watcher.processNextItem)
+	if c.watcher.data.deleteMode != !c.watcher.Options.Enabled {
+		t.Fatalf("deleteMode = %v with Enabled = %v", c.watcher.data.deleteMode, c.watcher.Options.Enabled)
+	}
+}
+
+func (c *testCluster) bgps(t *testing.T) map[string]*v1alpha1.BGP {
+	t.Helper()
+	return watchercoretest.BGPs(t, c.watcher.client)
+}
+
+func (c *testCluster) policies(t *testing.T) map[string]cilium.BGPPeeringPolicySpec {
+	t.Helper()
+	policies, err := c.watcher.Cilium.GetBGPPeeringPolicies(policyLabel + "=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	specs := make(map[string]cilium.BGPPeeringPolicySpec)
+	for _, policy := range policies {
+		specs[policy.Name] = policy.Spec
+	}
+	return specs
+}
+
+func (c *testCluster) nodeASN(t *testing.T, name string) string {
+	t.Helper()
+	node, err := c.clientset.CoreV1().Nodes().Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return node.GetAnnotations()[asnAnnotation]
+}
+
+func TestGenerateBGPPrefixLists(t *testing.T) {
+	node := &nodeIP{ASN: 4230000000, PodCIDRs: []string{"10.244.1.0/24", "fd00:244:1::/80"}}
+	gw := &watchercore.Gateway{NodeIP: "fd00::11", IPv6: true, Site: "site1", VNet: "vnet1", Prefix: "fd00::1/64"}
+
+	bgp := generateBGP("node1", node, gw)
+	if bgp.Name != "node1-fd00-11" {
+		t.Errorf("name = %s, want node1-fd00-11", bgp.Name)
+	}
+	if want := []string{"permit fd00:244:1::/80"}; !reflect.DeepEqual(bgp.Spec.PrefixListInbound, want) {
+		t.Errorf("PrefixListInbound = %v, want %v", bgp.Spec.PrefixListInbound, want)
+	}
+	if want := []string{"permit ::/0"}; !reflect.DeepEqual(bgp.Spec.PrefixListOutbound, want) {
+		t.Errorf("PrefixListOutbound = %v, want %v", bgp.Spec.PrefixListOutbound, want)
+	}
+	if bgp.Spec.LocalIP != "fd00::1/64" || bgp.Spec.RemoteIP != "fd00::11/64" {
+		t.Errorf("LocalIP, RemoteIP = %s, %s, want fd00::1/64, fd00::11/64", bgp.Spec.LocalIP, bgp.Spec.RemoteIP)
+	}
+}
+
+func TestProcess(t *testing.T) {
+	c := newTestCluster(t,
+		[]runtime.Object{
+			watchercoretest.Node("node1", nil, nil),
+			watchercoretest.Node("node2", nil, map[string]string{asnAnnotation: "4230000000"}),
+			watchercoretest.Node("node3", nil, nil),
+		},
+		testCiliumNode("node1", []string{"10.0.0.11", "fd00::11"}, []string{"10.244.1.0/24", "fd00:244:1::/80"}),
+		testCiliumNode("node2", []string{"10.0.0.12"}, []string{"10.244.2.0/24"}),
+		// node3 is outside of the Netris subnets.
+		testCiliumNode("node3", []string{"192.168.0.13"}, []string{"10.244.3.0/24"}),
+	)
+	c.run(t)
+
+	if got := c.nodeASN(t, "node1"); got != "4230000001" {
+		t.Errorf("node1 ASN = %s, want 4230000001", got)
+	}
+	if got := c.nodeASN(t, "node2"); got != "4230000000" {
+		t.Errorf("node2 ASN = %s, want 4230000000", got)
+	}
+	ledger, err := c.clientset.CoreV1().ConfigMaps("default").Get(context.Background(), asnLedgerName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ledger.Data) != 3 {
+		t.Errorf("ASN ledger = %v, want node1, node2 and node3", ledger.Data)
+	}
+
+	bgps := c.bgps(t)
+	if len(bgps) != 3 {
+		t.Fatalf("BGPs = %v, want node1 IPv4, node1 IPv6 and node2 IPv4", bgps)
+	}
+	want := v1alpha1.BGPSpec{
+		Site:               "site1",
+		NeighborAS:         4230000001,
+		Transport:          v1alpha1.BGPTransport{Type: "vnet", Name: "vnet1"},
+		LocalIP:            "10.0.0.1/24",
+		RemoteIP:           "10.0.0.11/24",
+		PrefixListInbound:  []string{"permit 10.244.1.0/24"},
+		PrefixListOutbound: []string{"permit 0.0.0.0/0"},
+	}
+	if bgp, ok := bgps["node1-10.0.0.11"]; !ok || !reflect.DeepEqual(bgp.Spec, want) {
+		t.Errorf("BGP node1-10.0.0.11 = %+v, want %+v", bgp, want)
+	}
+	if _, ok := bgps["node1-fd00-11"]; !ok {
+		t.Errorf("BGP node1-fd00-11 is missing")
+	}
+
+	policies := c.policies(t)
+	if len(policies) != 2 {
+		t.Fatalf("peering policies = %v, want node1 and node2", policies)
+	}
+	wantPolicy := cilium.BGPPeeringPolicySpec{
+		NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/hostname": "node1"}},
+		VirtualRouters: []cilium.BGPVirtualRouter{{
+			LocalASN:      4230000001,
+			ExportPodCIDR: true,
+			Neighbors: []cilium.BGPNeighbor{
+				{PeerAddress: "10.0.0.1/32", PeerASN: 65001},
+				{PeerAddress: "fd00::1/128", PeerASN: 65001},
+			},
+		}},
+	}
+	if !reflect.DeepEqual(policies["netris-node1"], wantPolicy) {
+		t.Errorf("policy netris-node1 = %+v, want %+v", policies["netris-node1"], wantPolicy)
+	}
+
+	// node2 leaves the cluster, its BGP and peering policy are removed.
+	if err := c.dynClient.Resource(nodeGVR).Delete(context.Background(), "node2", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	c.run(t)
+	if bgps := c.bgps(t); len(bgps) != 2 {
+		t.Errorf("BGPs after node2 removal = %v, want node1 ones", bgps)
+	}
+	if policies := c.policies(t); len(policies) != 1 {
+		t.Errorf("peering policies after node2 removal = %v, want netris-node1", policies)
+	}
+
+	// Disabling the integration removes everything.
+	c.watcher.Options.Enabled = false
+	c.run(t)
+	if bgps := c.bgps(t); len(bgps) != 0 {
+		t.Errorf("BGPs after disabling = %v, want none", bgps)
+	}
+	if policies := c.policies(t); len(policies) != 0 {
+		t.Errorf("peering policies after disabling = %v, want none", policies)
+	}
+	if got := c.nodeASN(t, "node1"); got != "" {
+		t.Errorf("node1 ASN after disabling = %s, want none", got)
+	}
+	if _, err := c.clientset.CoreV1().ConfigMaps("default").Get(context.Background(), asnLedgerName, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Errorf("ASN ledger after disabling: %v, want not found", err)
+	}
+}
+
+func TestCiliumInstalledLater(t *testing.T) {
+	c := newTestCluster(t, []runtime.Object{watchercoretest.Node("node1", nil, nil)})
+	installed := false
+	c.dynClient.PrependReactor("list", "ciliumnodes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if !installed {
+			return true, nil, errors.NewNotFound(nodeGVR.GroupResource(), "")
+		}
+		return false, nil, nil
+	})
+
+	c.run(t)
+	if c.watcher.ciliumNodeLister != nil {
+		t.Fatal("CiliumNode informer started before Cilium is installed")
+	}
+	if bgps := c.bgps(t); len(bgps) != 0 {
+		t.Fatalf("BGPs without Cilium = %v", bgps)
+	}
+
+	installed = true
+	node := testCiliumNode("node1", []string{"10.0.0.11"}, []string{"10.244.1.0/24"})
+	if _, err := c.dynClient.Resource(nodeGVR).Create(context.Background(), node, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	c.run(t)
+	if bgps := c.bgps(t); len(bgps) != 1 {
+		t.Fatalf("BGPs once Cilium is installed = %v, want node1", bgps)
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ciliumwatcher
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strconv"
+
+	"github.com/netrisai/netris-operator/ciliumwatcher/cilium"
+	"github.com/netrisai/netris-operator/watchercore"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// nodeIP is a Cilium node with its AS number, pod CIDRs and the VNet gateways of its addresses.
+type nodeIP struct {
+	ASN      int
+	Hostname string
+	PodCIDRs []string
+	Gateways []*watchercore.Gateway
+}
+
+func (w *Watcher) getNodes() error {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	nodes, err := w.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	w.data.nodes = make(map[string]*v1.Node)
+	for i := range nodes.Items {
+		w.data.nodes[nodes.Items[i].Name] = &nodes.Items[i]
+	}
+	return nil
+}
+
+func (w *Watcher) nodesProcessing() error {
+	nodesMap := make(map[string]*nodeIP)
+	skipped := false
+
+	for _, cNode := range w.data.ciliumNodes {
+		node, ok := w.data.nodes[cNode.Name]
+		if !ok {
+			continue
+		}
+
+		asn, err := strconv.Atoi(node.GetAnnotations()[asnAnnotation])
+		if err != nil {
+			logger.Info("Skipping node", "node", node.Name, "error", "couldn't get as number")
+			continue
+		}
+
+		hostname := node.GetLabels()["kubernetes.io/hostname"]
+		if hostname == "" {
+			hostname = node.Name
+		}
+
+		tmpNode := &nodeIP{
+			ASN:      asn,
+			Hostname: hostname,
+			PodCIDRs: nodePodCIDRs(cNode, node),
+		}
+
+		for _, addr := range nodeAddresses(cNode) {
+			gw, err := watchercore.FindGateway(w.NStorage, addr)
+			if err != nil {
+				logger.Info("Skipping node address", "node", node.Name, "address", addr.String(), "error", err.Error())
+				skipped = true
+				continue
+			}
+			tmpNode.Gateways = append(tmpNode.Gateways, gw)
+		}
+		if len(tmpNode.Gateways) == 0 {
+			logger.Info("Skipping node", "node", node.Name, "error", "couldn't find vnet gateway")
+			skipped = true
+			continue
+		}
+
+		nodesMap[node.Name] = tmpNode
+	}
+	if skipped {
+		// Netris changes don't come as events, the processing is retried until the gateways show up.
+		w.queue.AddAfter(clusterKey, requeueInterval)
+	}
+
+	w.data.nodesMap = nodesMap
+	return nil
+}
+
+// nodeAddresses returns the first internal address of each IP family of the node.
+func nodeAddresses(node *cilium.Node) []net.IP {
+	var ipv4, ipv6 net.IP
+	for _, addr := range node.Spec.Addresses {
+		if addr.Type != cilium.NodeInternalIP {
+			continue
+		}
+		ip := net.ParseIP(addr.IP)
+		if ip == nil {
+			continue
+		}
+		if ip.To4() != nil && ipv4 == nil {
+			ipv4 = ip
+		} else if ip.To4() == nil && ipv6 == nil {
+			ipv6 = ip
+		}
+	}
+
+	addrs := []net.IP{}
+	for _, ip := range []net.IP{ipv4, ipv6} {
+		if ip != nil {
+			addrs = append(addrs, ip)
+		}
+	}
+	return addrs
+}
+
+// nodePodCIDRs returns the pod CIDRs of the node from the Cilium IPAM,
+// falling back to the Kubernetes ones when Cilium runs in the kubernetes IPAM mode.
+func nodePodCIDRs(cNode *cilium.Node, node *v1.Node) []string {
+	if len(cNode.Spec.IPAM.PodCIDRs) > 0 {
+		return cNode.Spec.IPAM.PodCIDRs
+	}
+	if len(node.Spec.PodCIDRs) > 0 {
+		return node.Spec.PodCIDRs
+	}
+	if node.Spec.PodCIDR != "" {
+		return []string{node.Spec.PodCIDR}
+	}
+	return nil
+}
+
+func (w *Watcher) sortedNodeNames() []string {
+	names := []string{}
+	for name := range w.data.nodesMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ciliumwatcher
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/netrisai/netris-operator/ciliumwatcher/cilium"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	policyPrefix = "netris"
+	// policyLabel marks the CiliumBGPPeeringPolicies managed by the watcher.
+	policyLabel = "k8s.netris.ai/ciliumwatcher"
+)
+
+// generatePeeringPolicies generates a CiliumBGPPeeringPolicy per node. The node speaks in its own AS,
+// peers with the VNet gateways of its addresses and exports its pod CIDRs.
+func (w *Watcher) generatePeeringPolicies() []*cilium.BGPPeeringPolicy {
+	nameReg, _ := regexp.Compile("[^a-z0-9.]+")
+
+	policies := []*cilium.BGPPeeringPolicy{}
+	for _, name := range w.sortedNodeNames() {
+		node := w.data.nodesMap[name]
+
+		neighbors := []cilium.BGPNeighbor{}
+		for _, gw := range node.Gateways {
+			neighbors = append(neighbors, cilium.BGPNeighbor{
+				PeerAddress: gatewayAddress(gw),
+				PeerASN:     int64(gw.SiteASN),
+			})
+		}
+
+		policy := &cilium.BGPPeeringPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   strings.Trim(nameReg.ReplaceAllString(policyPrefix+"-"+name, "-"), "-"),
+				Labels: map[string]string{policyLabel: "true"},
+			},
+			Spec: cilium.BGPPeeringPolicySpec{
+				NodeSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"kubernetes.io/hostname": node.Hostname},
+				},
+				VirtualRouters: []cilium.BGPVirtualRouter{{
+					LocalASN:      int64(node.ASN),
+					ExportPodCIDR: true,
+					Neighbors:     neighbors,
+				}},
+			},
+		}
+		policies = append(policies, policy)
+	}
+	return policies
+}
+
+// peeringPoliciesProcessing creates and updates the generated peering policies and deletes the stale ones.
+func (w *Watcher) peeringPoliciesProcessing() error {
+	policies, err := w.Cilium.GetBGPPeeringPolicies(policyLabel + "=true")
+	if err != nil {
+		if cilium.IsMissingResource(err) && w.data.deleteMode {
+			return nil
+		}
+		return err
+	}
+
+	policiesMap := make(map[string]*cilium.BGPPeeringPolicy)
+	for _, policy := range policies {
+		policiesMap[policy.Name] = policy
+	}
+
+	generated := []*cilium.BGPPeeringPolicy{}
+	if !w.data.deleteMode {
+		generated = w.generatePeeringPolicies()
+	}
+
+	generatedMap := make(map[string]bool)
+	for _, policy := range generated {
+		generatedMap[policy.Name] = true
+		existing, ok := policiesMap[policy.Name]
+		if !ok {
+			debugLogger.Info("Creating peering policy", "policy", policy.Name, "deleteMode", w.data.deleteMode)
+			if err := w.Cilium.CreateBGPPeeringPolicy(policy); err != nil {
+				return err
+			}
+			logger.Info("Peering policy is created", "policy", policy.Name)
+			continue
+		}
+		if reflect.DeepEqual(existing.Spec, policy.Spec) {
+			continue
+		}
+		debugLogger.Info("Updating peering policy", "policy", policy.Name, "deleteMode", w.data.deleteMode)
+		policy.ResourceVersion = existing.ResourceVersion
+		if err := w.Cilium.UpdateBGPPeeringPolicy(policy); err != nil {
+			return err
+		}
+		logger.Info("Peering policy is updated", "policy", policy.Name)
+	}
+
+	for _, policy := range policies {
+		if generatedMap[policy.Name] {
+			continue
+		}
+		debugLogger.Info("Deleting peering policy", "policy", policy.Name, "deleteMode", w.data.deleteMode)
+		if err := w.Cilium.DeleteBGPPeeringPolicy(policy.Name); err != nil {
+			return err
+		}
+		logger.Info("Peering policy is deleted", "policy", policy.Name)
+	}
+	return nil
+}
@@ -11,8 +11,21 @@ type config struct {
 	LogDevMode      bool       `yaml:"logdevmode" envconfig:"NOPERATOR_DEV_MODE"`
 	RequeueInterval int        `yaml:"requeueinterval" envconfig:"NOPERATOR_REQUEUE_INTERVAL"`
 	CalicoASNRange  string     `yaml:"calicoasnrange" envconfig:"NOPERATOR_CALICO_ASN_RANGE"`
+	CiliumEnabled   bool       `yaml:"ciliumenabled" envconfig:"NOPERATOR_CILIUM_ENABLED"`
+	CiliumASNRange  string     `yaml:"ciliumasnrange" envconfig:"NOPERATOR_CILIUM_ASN_RANGE"`
+	MetalLBEnabled  bool       `yaml:"metallbenabled" envconfig:"NOPERATOR_METALLB_ENABLED"`
+	MetalLBNS       string     `yaml:"metallbnamespace" envconfig:"NOPERATOR_METALLB_NAMESPACE"`
+	MetalLBASNRange string     `yaml:"metallbasnrange" envconfig:"NOPERATOR_METALLB_ASN_RANGE"`
 	L4lbTenant      string     `yaml:"l4lbtenant" envconfig:"NOPERATOR_L4LB_TENANT"`
 	VPCID           int        `yaml:"vpcid" envconfig:"NOPERATOR_VPC_ID"`
+	TagLabels       string     `yaml:"taglabels" envconfig:"NOPERATOR_TAG_LABELS"`
+	TagNamespace    bool       `yaml:"tagnamespace" envconfig:"NOPERATOR_TAG_NAMESPACE"`
+	LBClass         string     `yaml:"lbclass" envconfig:"NOPERATOR_LB_CLASS"`
+	LBClassOnly     bool       `yaml:"lbclassonly" envconfig:"NOPERATOR_LB_CLASS_ONLY"`
+	LBMultiSite     string     `yaml:"lbmultisite" envconfig:"NOPERATOR_LB_MULTISITE_POLICY"`
+	LBExcludeLabel  string     `yaml:"lbexcludelabel" envconfig:"NOPERATOR_LB_EXCLUDE_NODE_LABEL"`
+	LBNodeGrace     int        `yaml:"lbnodegrace" envconfig:"NOPERATOR_LB_NODE_GRACE_PERIOD"`
+	Namespace       string     `yaml:"namespace" envconfig:"NOPERATOR_NAMESPACE"`
 }
 
 type controller struct {
@@ -33,10 +33,14 @@ func init() {
 	}
 }
 
-func (r *VNetReconciler) getPortsMeta(portNames []k8sv1alpha1.VNetSwitchPort) ([]k8sv1alpha1.VNetMetaMember, error) {
+func (r *VNetReconciler) getPortsMeta(namespace string, portNames []k8sv1alpha1.VNetSwitchPort) ([]k8sv1alpha1.VNetMetaMember, error) {
 	members := []k8sv1alpha1.VNetMetaMember{}
 	hwPorts := make(map[string]*k8sv1alpha1.VNetMetaMember)
-	for _, port := range portNames {
+	for i, port := range portNames {
+		if err := validatePortOrLAG("name", port.Name, port.LAG); err != nil {
+			return members, fmt.Errorf("switchPorts[%d]: %s", i, err)
+		}
+
 		vlanID := "1"
 		if port.VlanID > 1 {
 			vlanID = strconv.Itoa(port.VlanID)
@@ -56,7 +60,20 @@ func (r *VNetReconciler) getPortsMeta(portNames []k8sv1alpha1.VNetSwitchPort) ([
 			}
 		}
 
-		hwPorts[port.Name] = &k8sv1alpha1.VNetMetaMember{
+		portName := port.Name
+		portID := 0
+		if port.LAG != "" {
+			apiLAG, err := getLAGAggregatedPort(r.Client, r.NStorage, namespace, port.LAG)
+			if err != nil {
+				return members, err
+			}
+			portName = apiLAG.Name
+			portID = apiLAG.ID
+		}
+
+		hwPorts[portName] = &k8sv1alpha1.VNetMetaMember{
+			ID:       portID,
+			Name:     portName,
 			Vlan:     vlanID,
 			Lacp:     "off",
 			State:    state,
@@ -65,6 +82,9 @@ func (r *VNetReconciler) getPortsMeta(portNames []k8sv1alpha1.VNetSwitchPort) ([
 
 	}
 	for portName := range hwPorts {
+		if hwPorts[portName].ID > 0 {
+			continue
+		}
 		if port, yes := r.NStorage.PortsStorage.FindByName(portName); yes {
 			hwPorts[portName].ID = port.ID
 			hwPorts[portName].Name = portName
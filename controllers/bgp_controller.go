@@ -49,6 +49,15 @@ type BGPReconciler struct {
 // +kubebuilder:rbac:groups=crd.projectcalico.org,resources=bgppeers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=crd.projectcalico.org,resources=bgpconfigurations,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=crd.projectcalico.org,resources=ippools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=crd.projectcalico.org,resources=nodes,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=cilium.io,resources=ciliumnodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cilium.io,resources=ciliumbgppeeringpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=metallb.io,resources=ipaddresspools;bgpadvertisements,verbs=get;list;watch
+// +kubebuilder:rbac:groups=metallb.io,resources=bgppeers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=k8s.netris.ai,resources=calicointegrations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=k8s.netris.ai,resources=calicointegrations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;create;update;delete
 
 // Reconcile is the main reconciler for the appropriate resource type
 func (r *BGPReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
@@ -67,6 +67,13 @@ func (r *BGPReconciler) BGPToBGPMeta(bgp *k8sv1alpha1.BGP) (*k8sv1alpha1.BGPMeta
 			return nil, fmt.Errorf("coundn't find port %s", bgp.Spec.Transport.Name)
 		}
 		vlanID = -1
+	} else if bgp.Spec.Transport.Type == "lag" {
+		apiLAG, err := getLAGAggregatedPort(r.Client, r.NStorage, bgp.GetNamespace(), bgp.Spec.Transport.Name)
+		if err != nil {
+			return nil, err
+		}
+		portID = apiLAG.ID
+		vlanID = -1
 	} else {
 		vnets, err := r.Cred.VNet().Get()
 		if err != nil {
@@ -108,6 +115,20 @@ func (r *BGPReconciler) BGPToBGPMeta(bgp *k8sv1alpha1.BGP) (*k8sv1alpha1.BGPMeta
 		ipVersion = "ipv4"
 	}
 
+	inboundRouteMap, err := getRouteMapID(r.Client, r.NStorage, bgp.GetNamespace(), bgp.Spec.InboundRouteMap)
+	if err != nil {
+		return nil, err
+	}
+	outboundRouteMap, err := getRouteMapID(r.Client, r.NStorage, bgp.GetNamespace(), bgp.Spec.OutboundRouteMap)
+	if err != nil {
+		return nil, err
+	}
+
+	bfd := "disabled"
+	if bgp.Spec.BFD == "enabled" {
+		bfd = "enabled"
+	}
+
 	var neighborAddress string
 
 	if bgp.Spec.Multihop.NeighborAddress != "" && bgp.Spec.Multihop.Hops > 0 {
@@ -141,13 +162,16 @@ func (r *BGPReconciler) BGPToBGPMeta(bgp *k8sv1alpha1.BGP) (*k8sv1alpha1.BGPMeta
 			Multihop:        bgp.Spec.Multihop.Hops,
 
 			BgpPassword:        bgp.Spec.BGPPassword,
+			Bfd:                bfd,
 			AllowasIn:          bgp.Spec.AllowAsIn,
 			Originate:          originate,
 			PrefixLimit:        strconv.Itoa(bgp.Spec.PrefixInboundMax), // ?
 			IPVersion:          ipVersion,
-			InboundRouteMap:    bgpMeta.Spec.InboundRouteMap,
+			InboundRouteMap:    inboundRouteMap,
+			OutboundRouteMap:   outboundRouteMap,
 			LocalPreference:    localPreference,
 			Weight:             bgp.Spec.Weight,
+			Tags:               objectTags(bgp, nil),
 			PrependInbound:     bgp.Spec.PrependInbound,
 			PrependOutbound:    bgp.Spec.PrependOutbound,
 			PrefixLength:       prefixLength, // ?
@@ -169,7 +193,7 @@ func bgpCompareFieldsForNewMeta(bgp *k8sv1alpha1.BGP, bgpMeta *k8sv1alpha1.BGPMe
 	if i, ok := bgp.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
 		reclaim = true
 	}
-	return bgp.GetGeneration() != bgpMeta.Spec.BGPCRGeneration || imported != bgpMeta.Spec.Imported || reclaim != bgpMeta.Spec.Reclaim
+	return bgp.GetGeneration() != bgpMeta.Spec.BGPCRGeneration || imported != bgpMeta.Spec.Imported || reclaim != bgpMeta.Spec.Reclaim || !compareTags(objectTags(bgp, nil), bgpMeta.Spec.Tags)
 }
 
 func bgpMustUpdateAnnotations(bgp *k8sv1alpha1.BGP) bool {
@@ -222,6 +246,7 @@ func BGPMetaToNetris(bgpMeta *k8sv1alpha1.BGPMeta) (*bgp.EBGPAdd, error) {
 	bgpAdd := &bgp.EBGPAdd{
 		AllowAsIn:          bgpMeta.Spec.AllowasIn,
 		BgpPassword:        bgpMeta.Spec.BgpPassword,
+		Bfd:                bgpMeta.Spec.Bfd,
 		BgpCommunity:       bgpMeta.Spec.Community,
 		Hardware:           bgp.IDNone{ID: hwID},
 		Vnet:               bgp.IDNone{ID: vnetID},
@@ -249,7 +274,7 @@ func BGPMetaToNetris(bgpMeta *k8sv1alpha1.BGPMeta) (*bgp.EBGPAdd, error) {
 		UpdateSource:       bgpMeta.Spec.UpdateSource,
 		Vlan:               bgpMeta.Spec.Vlan,
 		Weight:             bgpMeta.Spec.Weight,
-		Tags:               []string{},
+		Tags:               metaTags(bgpMeta.Spec.Tags),
 		Untagged:           untagged,
 	}
 
@@ -274,6 +299,7 @@ func BGPMetaToNetrisUpdate(bgpMeta *k8sv1alpha1.BGPMeta) (*bgp.EBGPUpdate, error
 	bgpAdd := &bgp.EBGPUpdate{
 		AllowAsIn:          bgpMeta.Spec.AllowasIn,
 		BgpPassword:        bgpMeta.Spec.BgpPassword,
+		Bfd:                bgpMeta.Spec.Bfd,
 		BgpCommunity:       bgpMeta.Spec.Community,
 		Description:        bgpMeta.Spec.Description,
 		InboundRouteMap:    optionalRouteMapID(bgpMeta.Spec.InboundRouteMap),
@@ -301,7 +327,7 @@ func BGPMetaToNetrisUpdate(bgpMeta *k8sv1alpha1.BGPMeta) (*bgp.EBGPUpdate, error
 		UpdateSource:       bgpMeta.Spec.UpdateSource,
 		Vlan:               bgpMeta.Spec.Vlan,
 		Weight:             bgpMeta.Spec.Weight,
-		Tags:               []string{},
+		Tags:               metaTags(bgpMeta.Spec.Tags),
 	}
 
 	return bgpAdd, nil
@@ -316,6 +342,10 @@ func compareBGPMetaAPIEBGP(bgpMeta *k8sv1alpha1.BGPMeta, apiBGP *bgp.EBGP, u uni
 		u.DebugLogger.Info("BgpPassword changed", "netrisValue", apiBGP.BgpPassword, "k8sValue", bgpMeta.Spec.BgpPassword)
 		return false
 	}
+	if (apiBGP.Bfd == "enabled") != (bgpMeta.Spec.Bfd == "enabled") {
+		u.DebugLogger.Info("Bfd changed", "netrisValue", apiBGP.Bfd, "k8sValue", bgpMeta.Spec.Bfd)
+		return false
+	}
 	if apiBGP.Community != bgpMeta.Spec.Community {
 		u.DebugLogger.Info("Community changed", "netrisValue", apiBGP.Community, "k8sValue", bgpMeta.Spec.Community)
 		return false
@@ -433,6 +463,10 @@ func compareBGPMetaAPIEBGP(bgpMeta *k8sv1alpha1.BGPMeta, apiBGP *bgp.EBGP, u uni
 		u.DebugLogger.Info("Weight changed", "netrisValue", apiBGP.Weight, "k8sValue", bgpMeta.Spec.Weight)
 		return false
 	}
+	if !compareTags(apiBGP.Tags, bgpMeta.Spec.Tags) {
+		u.DebugLogger.Info("Tags changed", "netrisValue", apiBGP.Tags, "k8sValue", bgpMeta.Spec.Tags)
+		return false
+	}
 
 	return true
 }
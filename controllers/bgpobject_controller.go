@@ -0,0 +1,233 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-logr/logr"
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/netrisstorage"
+	"github.com/netrisai/netriswebapi/http"
+	api "github.com/netrisai/netriswebapi/v2"
+)
+
+// BGPObjectReconciler reconciles a BGPObject object
+type BGPObjectReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Cred     *api.Clientset
+	NStorage *netrisstorage.Storage
+}
+
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=bgpobjects,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=bgpobjects/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=bgpobjects/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop
+func (r *BGPObjectReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("name", req.NamespacedName)
+	debugLogger := logger.V(int(zapcore.WarnLevel))
+	bgpObject := &k8sv1alpha1.BGPObject{}
+
+	u := uniReconciler{
+		Client:      r.Client,
+		Logger:      logger,
+		DebugLogger: debugLogger,
+		Cred:        r.Cred,
+		NStorage:    r.NStorage,
+	}
+
+	bgpObjectCtx, bgpObjectCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer bgpObjectCancel()
+	if err := r.Get(bgpObjectCtx, req.NamespacedName, bgpObject); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	bgpObjectMetaNamespaced := req.NamespacedName
+	bgpObjectMetaNamespaced.Name = string(bgpObject.GetUID())
+	bgpObjectMeta := &k8sv1alpha1.BGPObjectMeta{}
+	metaFound := true
+
+	bgpObjectMetaCtx, bgpObjectMetaCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer bgpObjectMetaCancel()
+	if err := r.Get(bgpObjectMetaCtx, bgpObjectMetaNamespaced, bgpObjectMeta); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			metaFound = false
+			bgpObjectMeta = nil
+		} else {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if bgpObject.DeletionTimestamp != nil {
+		logger.Info("Go to delete")
+		_, err := r.deleteBGPObject(bgpObject, bgpObjectMeta)
+		if err != nil {
+			logger.Error(fmt.Errorf("{deleteBGPObject} %s", err), "")
+			return u.patchBGPObjectStatus(bgpObject, "Failure", err.Error())
+		}
+		logger.Info("BGPObject deleted")
+		return ctrl.Result{}, nil
+	}
+
+	if bgpObjectMustUpdateAnnotations(bgpObject) {
+		debugLogger.Info("Setting default annotations")
+		bgpObjectUpdateDefaultAnnotations(bgpObject)
+		bgpObjectPatchCtx, bgpObjectPatchCancel := context.WithTimeout(cntxt, contextTimeout)
+		defer bgpObjectPatchCancel()
+		err := r.Patch(bgpObjectPatchCtx, bgpObject.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+		if err != nil {
+			logger.Error(fmt.Errorf("{Patch BGPObject default annotations} %s", err), "")
+			return ctrl.Result{RequeueAfter: requeueInterval}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if metaFound {
+		debugLogger.Info("Meta found")
+		if bgpObjectCompareFieldsForNewMeta(bgpObject, bgpObjectMeta) {
+			debugLogger.Info("Generating New Meta")
+			bgpObjectID := bgpObjectMeta.Spec.ID
+			newBGPObjectMeta, err := r.BGPObjectToMeta(bgpObject)
+			if err != nil {
+				logger.Error(fmt.Errorf("{BGPObjectToMeta} %s", err), "")
+				return u.patchBGPObjectStatus(bgpObject, "Failure", err.Error())
+			}
+			bgpObjectMeta.Spec = newBGPObjectMeta.DeepCopy().Spec
+			bgpObjectMeta.Spec.ID = bgpObjectID
+			bgpObjectMeta.Spec.BGPObjectCRGeneration = bgpObject.GetGeneration()
+
+			bgpObjectMetaUpdateCtx, bgpObjectMetaUpdateCancel := context.WithTimeout(cntxt, contextTimeout)
+			defer bgpObjectMetaUpdateCancel()
+			err = r.Update(bgpObjectMetaUpdateCtx, bgpObjectMeta.DeepCopyObject(), &client.UpdateOptions{})
+			if err != nil {
+				logger.Error(fmt.Errorf("{bgpObjectMeta Update} %s", err), "")
+				return ctrl.Result{RequeueAfter: requeueInterval}, nil
+			}
+		}
+	} else {
+		debugLogger.Info("Meta not found")
+		if bgpObject.GetFinalizers() == nil {
+			bgpObject.SetFinalizers([]string{"resource.k8s.netris.ai/delete"})
+
+			bgpObjectPatchCtx, bgpObjectPatchCancel := context.WithTimeout(cntxt, contextTimeout)
+			defer bgpObjectPatchCancel()
+			err := r.Patch(bgpObjectPatchCtx, bgpObject.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+			if err != nil {
+				logger.Error(fmt.Errorf("{Patch BGPObject Finalizer} %s", err), "")
+				return ctrl.Result{RequeueAfter: requeueInterval}, nil
+			}
+			return ctrl.Result{}, nil
+		}
+
+		bgpObjectMeta, err := r.BGPObjectToMeta(bgpObject)
+		if err != nil {
+			logger.Error(fmt.Errorf("{BGPObjectToMeta} %s", err), "")
+			return u.patchBGPObjectStatus(bgpObject, "Failure", err.Error())
+		}
+
+		bgpObjectMeta.Spec.BGPObjectCRGeneration = bgpObject.GetGeneration()
+
+		bgpObjectMetaCreateCtx, bgpObjectMetaCreateCancel := context.WithTimeout(cntxt, contextTimeout)
+		defer bgpObjectMetaCreateCancel()
+		if err := r.Create(bgpObjectMetaCreateCtx, bgpObjectMeta.DeepCopyObject(), &client.CreateOptions{}); err != nil {
+			logger.Error(fmt.Errorf("{bgpObjectMeta Create} %s", err), "")
+			return ctrl.Result{RequeueAfter: requeueInterval}, nil
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: requeueInterval}, nil
+}
+
+func (r *BGPObjectReconciler) deleteBGPObject(bgpObject *k8sv1alpha1.BGPObject, bgpObjectMeta *k8sv1alpha1.BGPObjectMeta) (ctrl.Result, error) {
+	refs, err := bgpObjectReferences(r.Client, bgpObject)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("{deleteBGPObject} %s", err)
+	}
+	if len(refs) > 0 {
+		return ctrl.Result{}, fmt.Errorf("BGPObject is referenced by RouteMap %s", strings.Join(refs, ", "))
+	}
+	if bgpObjectMeta != nil && bgpObjectMeta.Spec.ID > 0 && !bgpObjectMeta.Spec.Reclaim {
+		reply, err := r.Cred.BGPObject().Delete(bgpObjectMeta.Spec.ID)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("{deleteBGPObject} %s", err)
+		}
+		resp, err := http.ParseAPIResponse(reply.Data)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !resp.IsSuccess && resp.Meta.StatusCode != 404 {
+			return ctrl.Result{}, fmt.Errorf("{deleteBGPObject} %s", fmt.Errorf(resp.Message))
+		}
+	}
+	return r.deleteCRs(bgpObject, bgpObjectMeta)
+}
+
+func (r *BGPObjectReconciler) deleteCRs(bgpObject *k8sv1alpha1.BGPObject, bgpObjectMeta *k8sv1alpha1.BGPObjectMeta) (ctrl.Result, error) {
+	if bgpObjectMeta != nil {
+		_, err := r.deleteBGPObjectMetaCR(bgpObjectMeta)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("{deleteCRs} %s", err)
+		}
+	}
+
+	return r.deleteBGPObjectCR(bgpObject)
+}
+
+func (r *BGPObjectReconciler) deleteBGPObjectCR(bgpObject *k8sv1alpha1.BGPObject) (ctrl.Result, error) {
+	bgpObject.ObjectMeta.SetFinalizers(nil)
+	bgpObject.SetFinalizers(nil)
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	if err := r.Update(ctx, bgpObject.DeepCopyObject(), &client.UpdateOptions{}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("{deleteBGPObjectCR} %s", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *BGPObjectReconciler) deleteBGPObjectMetaCR(bgpObjectMeta *k8sv1alpha1.BGPObjectMeta) (ctrl.Result, error) {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	if err := r.Delete(ctx, bgpObjectMeta.DeepCopyObject(), &client.DeleteOptions{}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("{deleteBGPObjectMetaCR} %s", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BGPObjectReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&k8sv1alpha1.BGPObject{}).
+		Complete(r)
+}
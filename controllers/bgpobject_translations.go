@@ -0,0 +1,178 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/netrisstorage"
+	"github.com/netrisai/netriswebapi/v1/types/bgpobject"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BGPObjectToMeta converts the BGPObject resource to Meta type.
+func (r *BGPObjectReconciler) BGPObjectToMeta(bgpObject *k8sv1alpha1.BGPObject) (*k8sv1alpha1.BGPObjectMeta, error) {
+	var (
+		imported = false
+		reclaim  = false
+	)
+
+	if i, ok := bgpObject.GetAnnotations()["resource.k8s.netris.ai/import"]; ok && i == "true" {
+		imported = true
+	}
+	if i, ok := bgpObject.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
+		reclaim = true
+	}
+
+	bgpObjectMeta := &k8sv1alpha1.BGPObjectMeta{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      string(bgpObject.GetUID()),
+			Namespace: bgpObject.GetNamespace(),
+		},
+		TypeMeta: metav1.TypeMeta{},
+		Spec: k8sv1alpha1.BGPObjectMetaSpec{
+			Imported:      imported,
+			Reclaim:       reclaim,
+			BGPObjectName: bgpObject.Name,
+			Type:          bgpObject.Spec.Type,
+			TypeValue:     strings.Join(bgpObject.Spec.Entries, "\n"),
+		},
+	}
+
+	return bgpObjectMeta, nil
+}
+
+func bgpObjectCompareFieldsForNewMeta(bgpObject *k8sv1alpha1.BGPObject, bgpObjectMeta *k8sv1alpha1.BGPObjectMeta) bool {
+	imported := false
+	reclaim := false
+	if i, ok := bgpObject.GetAnnotations()["resource.k8s.netris.ai/import"]; ok && i == "true" {
+		imported = true
+	}
+	if i, ok := bgpObject.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
+		reclaim = true
+	}
+	return bgpObject.GetGeneration() != bgpObjectMeta.Spec.BGPObjectCRGeneration || imported != bgpObjectMeta.Spec.Imported || reclaim != bgpObjectMeta.Spec.Reclaim
+}
+
+func bgpObjectMustUpdateAnnotations(bgpObject *k8sv1alpha1.BGPObject) bool {
+	update := false
+	if i, ok := bgpObject.GetAnnotations()["resource.k8s.netris.ai/import"]; !(ok && (i == "true" || i == "false")) {
+		update = true
+	}
+	if i, ok := bgpObject.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; !(ok && (i == "retain" || i == "delete")) {
+		update = true
+	}
+	return update
+}
+
+func bgpObjectUpdateDefaultAnnotations(bgpObject *k8sv1alpha1.BGPObject) {
+	imported := "false"
+	reclaim := "delete"
+	if i, ok := bgpObject.GetAnnotations()["resource.k8s.netris.ai/import"]; ok && i == "true" {
+		imported = "true"
+	}
+	if i, ok := bgpObject.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
+		reclaim = "retain"
+	}
+	annotations := bgpObject.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations["resource.k8s.netris.ai/import"] = imported
+	annotations["resource.k8s.netris.ai/reclaimPolicy"] = reclaim
+	bgpObject.SetAnnotations(annotations)
+}
+
+// BGPObjectMetaToNetris converts Meta to Netris API type.
+func BGPObjectMetaToNetris(bgpObjectMeta *k8sv1alpha1.BGPObjectMeta) *bgpobject.BGPObjectW {
+	return &bgpobject.BGPObjectW{
+		ID:        bgpObjectMeta.Spec.ID,
+		Name:      bgpObjectMeta.Spec.BGPObjectName,
+		Type:      bgpObjectMeta.Spec.Type,
+		TypeValue: bgpObjectMeta.Spec.TypeValue,
+	}
+}
+
+func compareBGPObjectMetaAPI(bgpObjectMeta *k8sv1alpha1.BGPObjectMeta, apiBGPObject *bgpobject.BGPObject, u uniReconciler) bool {
+	if apiBGPObject.Name != bgpObjectMeta.Spec.BGPObjectName {
+		u.DebugLogger.Info("Name changed", "netrisValue", apiBGPObject.Name, "k8sValue", bgpObjectMeta.Spec.BGPObjectName)
+		return false
+	}
+	if apiBGPObject.Type != bgpObjectMeta.Spec.Type {
+		u.DebugLogger.Info("Type changed", "netrisValue", apiBGPObject.Type, "k8sValue", bgpObjectMeta.Spec.Type)
+		return false
+	}
+	if strings.TrimSpace(apiBGPObject.TypeValue) != strings.TrimSpace(bgpObjectMeta.Spec.TypeValue) {
+		u.DebugLogger.Info("TypeValue changed", "netrisValue", apiBGPObject.TypeValue, "k8sValue", bgpObjectMeta.Spec.TypeValue)
+		return false
+	}
+	return true
+}
+
+// bgpObjectReferences returns the names of RouteMap resources in the namespace that use the BGP object.
+func bgpObjectReferences(cl client.Client, bgpObject *k8sv1alpha1.BGPObject) ([]string, error) {
+	routeMaps := &k8sv1alpha1.RouteMapList{}
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	if err := cl.List(ctx, routeMaps, client.InNamespace(bgpObject.GetNamespace())); err != nil {
+		return nil, err
+	}
+
+	refs := []string{}
+	for _, routeMap := range routeMaps.Items {
+		if routeMap.DeletionTimestamp != nil {
+			continue
+		}
+	sequences:
+		for _, sequence := range routeMap.Spec.Sequences {
+			for _, match := range sequence.Matches {
+				if match.BGPObject == bgpObject.Name {
+					refs = append(refs, routeMap.Name)
+					break sequences
+				}
+			}
+		}
+	}
+	return refs, nil
+}
+
+// getBGPObject resolves a BGP object by name.
+// If a BGPObject resource with that name exists in the namespace, it must be provisioned first.
+func getBGPObject(cl client.Client, nStorage *netrisstorage.Storage, namespace, name string) (*bgpobject.BGPObject, error) {
+	bgpObject := &k8sv1alpha1.BGPObject{}
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	if err := cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, bgpObject); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+	} else if bgpObject.Status.Status != "OK" || bgpObject.DeletionTimestamp != nil {
+		return nil, fmt.Errorf("waiting for BGPObject '%s' to be provisioned", name)
+	}
+
+	apiBGPObject, ok := nStorage.BGPObjectStorage.FindByName(name)
+	if !ok {
+		return nil, fmt.Errorf("BGP object '%s' not found", name)
+	}
+	return apiBGPObject, nil
+}
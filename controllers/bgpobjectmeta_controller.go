@@ -0,0 +1,246 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-logr/logr"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/netrisstorage"
+	"github.com/netrisai/netriswebapi/http"
+	"github.com/netrisai/netriswebapi/v1/types/bgpobject"
+	api "github.com/netrisai/netriswebapi/v2"
+)
+
+// BGPObjectMetaReconciler reconciles a BGPObjectMeta object
+type BGPObjectMetaReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Cred     *api.Clientset
+	NStorage *netrisstorage.Storage
+}
+
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=bgpobjectmeta,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=bgpobjectmeta/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=bgpobjectmeta/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop
+func (r *BGPObjectMetaReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	debugLogger := r.Log.WithValues("name", req.NamespacedName).V(int(zapcore.WarnLevel))
+
+	bgpObjectMeta := &k8sv1alpha1.BGPObjectMeta{}
+	bgpObjectCR := &k8sv1alpha1.BGPObject{}
+	bgpObjectMetaCtx, bgpObjectMetaCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer bgpObjectMetaCancel()
+	if err := r.Get(bgpObjectMetaCtx, req.NamespacedName, bgpObjectMeta); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	logger := r.Log.WithValues("name", fmt.Sprintf("%s/%s", req.NamespacedName.Namespace, bgpObjectMeta.Spec.BGPObjectName))
+	debugLogger = logger.V(int(zapcore.WarnLevel))
+
+	u := uniReconciler{
+		Client:      r.Client,
+		Logger:      logger,
+		DebugLogger: debugLogger,
+		Cred:        r.Cred,
+		NStorage:    r.NStorage,
+	}
+
+	provisionState := "OK"
+
+	bgpObjectNN := req.NamespacedName
+	bgpObjectNN.Name = bgpObjectMeta.Spec.BGPObjectName
+	bgpObjectNNCtx, bgpObjectNNCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer bgpObjectNNCancel()
+	if err := r.Get(bgpObjectNNCtx, bgpObjectNN, bgpObjectCR); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if bgpObjectMeta.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if bgpObjectMeta.Spec.ID == 0 {
+		debugLogger.Info("ID Not found in meta")
+		if bgpObjectMeta.Spec.Imported {
+			logger.Info("Importing BGPObject")
+			debugLogger.Info("Imported yaml mode. Finding BGPObject by name")
+			if apiBGPObject, ok := r.NStorage.BGPObjectStorage.FindByName(bgpObjectMeta.Spec.BGPObjectName); ok {
+				debugLogger.Info("Imported yaml mode. BGPObject found")
+				bgpObjectMeta.Spec.ID = apiBGPObject.ID
+
+				bgpObjectMetaPatchCtx, bgpObjectMetaPatchCancel := context.WithTimeout(cntxt, contextTimeout)
+				defer bgpObjectMetaPatchCancel()
+				err := r.Patch(bgpObjectMetaPatchCtx, bgpObjectMeta.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+				if err != nil {
+					logger.Error(fmt.Errorf("{patch bgpObjectMeta.Spec.ID} %s", err), "")
+					return u.patchBGPObjectStatus(bgpObjectCR, "Failure", err.Error())
+				}
+				debugLogger.Info("Imported yaml mode. ID patched")
+				logger.Info("BGPObject imported")
+				return ctrl.Result{RequeueAfter: requeueInterval}, nil
+			}
+			logger.Info("BGPObject not found for import")
+			debugLogger.Info("Imported yaml mode. BGPObject not found")
+		}
+
+		logger.Info("Creating BGPObject")
+		if _, err, errMsg := r.createBGPObject(bgpObjectMeta); err != nil {
+			logger.Error(fmt.Errorf("{createBGPObject} %s", err), "")
+			return u.patchBGPObjectStatus(bgpObjectCR, "Failure", errMsg.Error())
+		}
+		logger.Info("BGPObject Created")
+	} else {
+		if apiBGPObject, ok := r.NStorage.BGPObjectStorage.FindByID(bgpObjectMeta.Spec.ID); ok {
+			debugLogger.Info("Comparing BGPObjectMeta with Netris BGPObject")
+
+			if ok := compareBGPObjectMetaAPI(bgpObjectMeta, apiBGPObject, u); ok {
+				debugLogger.Info("Nothing Changed")
+			} else {
+				debugLogger.Info("Go to update BGPObject in Netris")
+				logger.Info("Updating BGPObject")
+				bgpObjectUpdate := BGPObjectMetaToNetris(bgpObjectMeta)
+
+				js, _ := json.Marshal(bgpObjectUpdate)
+				debugLogger.Info("bgpObjectUpdate", "payload", string(js))
+
+				_, err, errMsg := updateBGPObject(bgpObjectUpdate, r.Cred)
+				if err != nil {
+					logger.Error(fmt.Errorf("{updateBGPObject} %s", err), "")
+					return u.patchBGPObjectStatus(bgpObjectCR, "Failure", errMsg.Error())
+				}
+				logger.Info("BGPObject Updated")
+			}
+		} else {
+			debugLogger.Info("BGPObject not found in Netris")
+			debugLogger.Info("Going to create BGPObject")
+			logger.Info("Creating BGPObject")
+			if _, err, errMsg := r.createBGPObject(bgpObjectMeta); err != nil {
+				logger.Error(fmt.Errorf("{createBGPObject} %s", err), "")
+				return u.patchBGPObjectStatus(bgpObjectCR, "Failure", errMsg.Error())
+			}
+			logger.Info("BGPObject Created")
+		}
+	}
+
+	return u.patchBGPObjectStatus(bgpObjectCR, provisionState, "Success")
+}
+
+func (r *BGPObjectMetaReconciler) createBGPObject(bgpObjectMeta *k8sv1alpha1.BGPObjectMeta) (ctrl.Result, error, error) {
+	debugLogger := r.Log.WithValues(
+		"name", fmt.Sprintf("%s/%s", bgpObjectMeta.Namespace, bgpObjectMeta.Spec.BGPObjectName),
+		"bgpObjectName", bgpObjectMeta.Spec.BGPObjectCRGeneration,
+	).V(int(zapcore.WarnLevel))
+
+	bgpObjectAdd := BGPObjectMetaToNetris(bgpObjectMeta)
+
+	js, _ := json.Marshal(bgpObjectAdd)
+	debugLogger.Info("bgpObjectToAdd", "payload", string(js))
+
+	reply, err := r.Cred.BGPObject().Add(bgpObjectAdd)
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+
+	idStruct := struct {
+		ID int `json:"id"`
+	}{}
+
+	data, err := reply.Parse()
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+
+	if reply.StatusCode != 200 {
+		return ctrl.Result{}, fmt.Errorf(data.Message), fmt.Errorf(data.Message)
+	}
+
+	if d, ok := data.Data.(map[string]interface{}); ok {
+		if id, ok := d["id"].(float64); ok {
+			idStruct.ID = int(id)
+		}
+	}
+
+	if idStruct.ID == 0 {
+		if err := r.NStorage.BGPObjectStorage.Download(); err != nil {
+			return ctrl.Result{}, err, err
+		}
+		apiBGPObject, ok := r.NStorage.BGPObjectStorage.FindByName(bgpObjectMeta.Spec.BGPObjectName)
+		if !ok {
+			err := fmt.Errorf("BGPObject not found after creation")
+			return ctrl.Result{}, err, err
+		}
+		idStruct.ID = apiBGPObject.ID
+	}
+
+	debugLogger.Info("BGPObject Created", "id", idStruct.ID)
+
+	bgpObjectMeta.Spec.ID = idStruct.ID
+
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	err = r.Patch(ctx, bgpObjectMeta.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+
+	debugLogger.Info("ID patched to meta", "id", idStruct.ID)
+	return ctrl.Result{}, nil, nil
+}
+
+func updateBGPObject(bgpObjectW *bgpobject.BGPObjectW, cred *api.Clientset) (ctrl.Result, error, error) {
+	reply, err := cred.BGPObject().Update(bgpObjectW)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("{updateBGPObject} %s", err), err
+	}
+	resp, err := http.ParseAPIResponse(reply.Data)
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+	if !resp.IsSuccess {
+		return ctrl.Result{}, fmt.Errorf("{updateBGPObject} %s", fmt.Errorf(resp.Message)), fmt.Errorf(resp.Message)
+	}
+
+	return ctrl.Result{}, nil, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BGPObjectMetaReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&k8sv1alpha1.BGPObjectMeta{}).
+		Complete(r)
+}
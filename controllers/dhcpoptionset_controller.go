@@ -0,0 +1,225 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-logr/logr"
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/netrisstorage"
+	"github.com/netrisai/netriswebapi/http"
+	api "github.com/netrisai/netriswebapi/v2"
+)
+
+// DHCPOptionSetReconciler reconciles a DHCPOptionSet object
+type DHCPOptionSetReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Cred     *api.Clientset
+	NStorage *netrisstorage.Storage
+}
+
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=dhcpoptionsets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=dhcpoptionsets/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=dhcpoptionsets/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop
+func (r *DHCPOptionSetReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("name", req.NamespacedName)
+	debugLogger := logger.V(int(zapcore.WarnLevel))
+	dhcpOptionSet := &k8sv1alpha1.DHCPOptionSet{}
+
+	u := uniReconciler{
+		Client:      r.Client,
+		Logger:      logger,
+		DebugLogger: debugLogger,
+		Cred:        r.Cred,
+		NStorage:    r.NStorage,
+	}
+
+	dhcpOptionSetCtx, dhcpOptionSetCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer dhcpOptionSetCancel()
+	if err := r.Get(dhcpOptionSetCtx, req.NamespacedName, dhcpOptionSet); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	dhcpOptionSetMetaNamespaced := req.NamespacedName
+	dhcpOptionSetMetaNamespaced.Name = string(dhcpOptionSet.GetUID())
+	dhcpOptionSetMeta := &k8sv1alpha1.DHCPOptionSetMeta{}
+	metaFound := true
+
+	dhcpOptionSetMetaCtx, dhcpOptionSetMetaCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer dhcpOptionSetMetaCancel()
+	if err := r.Get(dhcpOptionSetMetaCtx, dhcpOptionSetMetaNamespaced, dhcpOptionSetMeta); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			metaFound = false
+			dhcpOptionSetMeta = nil
+		} else {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if dhcpOptionSet.DeletionTimestamp != nil {
+		logger.Info("Go to delete")
+		_, err := r.deleteDHCPOptionSet(dhcpOptionSet, dhcpOptionSetMeta)
+		if err != nil {
+			logger.Error(fmt.Errorf("{deleteDHCPOptionSet} %s", err), "")
+			return u.patchDHCPOptionSetStatus(dhcpOptionSet, "Failure", err.Error())
+		}
+		logger.Info("DHCPOptionSet deleted")
+		return ctrl.Result{}, nil
+	}
+
+	if dhcpOptionSetMustUpdateAnnotations(dhcpOptionSet) {
+		debugLogger.Info("Setting default annotations")
+		dhcpOptionSetUpdateDefaultAnnotations(dhcpOptionSet)
+		dhcpOptionSetPatchCtx, dhcpOptionSetPatchCancel := context.WithTimeout(cntxt, contextTimeout)
+		defer dhcpOptionSetPatchCancel()
+		err := r.Patch(dhcpOptionSetPatchCtx, dhcpOptionSet.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+		if err != nil {
+			logger.Error(fmt.Errorf("{Patch DHCPOptionSet default annotations} %s", err), "")
+			return ctrl.Result{RequeueAfter: requeueInterval}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if metaFound {
+		debugLogger.Info("Meta found")
+		if dhcpOptionSetCompareFieldsForNewMeta(dhcpOptionSet, dhcpOptionSetMeta) {
+			debugLogger.Info("Generating New Meta")
+			dhcpOptionSetID := dhcpOptionSetMeta.Spec.ID
+			newDHCPOptionSetMeta, err := r.DHCPOptionSetToMeta(dhcpOptionSet)
+			if err != nil {
+				logger.Error(fmt.Errorf("{DHCPOptionSetToMeta} %s", err), "")
+				return u.patchDHCPOptionSetStatus(dhcpOptionSet, "Failure", err.Error())
+			}
+			dhcpOptionSetMeta.Spec = newDHCPOptionSetMeta.DeepCopy().Spec
+			dhcpOptionSetMeta.Spec.ID = dhcpOptionSetID
+			dhcpOptionSetMeta.Spec.DHCPOptionSetCRGeneration = dhcpOptionSet.GetGeneration()
+
+			dhcpOptionSetMetaUpdateCtx, dhcpOptionSetMetaUpdateCancel := context.WithTimeout(cntxt, contextTimeout)
+			defer dhcpOptionSetMetaUpdateCancel()
+			err = r.Update(dhcpOptionSetMetaUpdateCtx, dhcpOptionSetMeta.DeepCopyObject(), &client.UpdateOptions{})
+			if err != nil {
+				logger.Error(fmt.Errorf("{dhcpOptionSetMeta Update} %s", err), "")
+				return ctrl.Result{RequeueAfter: requeueInterval}, nil
+			}
+		}
+	} else {
+		debugLogger.Info("Meta not found")
+		if dhcpOptionSet.GetFinalizers() == nil {
+			dhcpOptionSet.SetFinalizers([]string{"resource.k8s.netris.ai/delete"})
+
+			dhcpOptionSetPatchCtx, dhcpOptionSetPatchCancel := context.WithTimeout(cntxt, contextTimeout)
+			defer dhcpOptionSetPatchCancel()
+			err := r.Patch(dhcpOptionSetPatchCtx, dhcpOptionSet.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+			if err != nil {
+				logger.Error(fmt.Errorf("{Patch DHCPOptionSet Finalizer} %s", err), "")
+				return ctrl.Result{RequeueAfter: requeueInterval}, nil
+			}
+			return ctrl.Result{}, nil
+		}
+
+		dhcpOptionSetMeta, err := r.DHCPOptionSetToMeta(dhcpOptionSet)
+		if err != nil {
+			logger.Error(fmt.Errorf("{DHCPOptionSetToMeta} %s", err), "")
+			return u.patchDHCPOptionSetStatus(dhcpOptionSet, "Failure", err.Error())
+		}
+
+		dhcpOptionSetMeta.Spec.DHCPOptionSetCRGeneration = dhcpOptionSet.GetGeneration()
+
+		dhcpOptionSetMetaCreateCtx, dhcpOptionSetMetaCreateCancel := context.WithTimeout(cntxt, contextTimeout)
+		defer dhcpOptionSetMetaCreateCancel()
+		if err := r.Create(dhcpOptionSetMetaCreateCtx, dhcpOptionSetMeta.DeepCopyObject(), &client.CreateOptions{}); err != nil {
+			logger.Error(fmt.Errorf("{dhcpOptionSetMeta Create} %s", err), "")
+			return ctrl.Result{RequeueAfter: requeueInterval}, nil
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: requeueInterval}, nil
+}
+
+func (r *DHCPOptionSetReconciler) deleteDHCPOptionSet(dhcpOptionSet *k8sv1alpha1.DHCPOptionSet, dhcpOptionSetMeta *k8sv1alpha1.DHCPOptionSetMeta) (ctrl.Result, error) {
+	if dhcpOptionSetMeta != nil && dhcpOptionSetMeta.Spec.ID > 0 && !dhcpOptionSetMeta.Spec.Reclaim {
+		reply, err := r.Cred.DHCP().Delete(dhcpOptionSetMeta.Spec.ID)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("{deleteDHCPOptionSet} %s", err)
+		}
+		resp, err := http.ParseAPIResponse(reply.Data)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !resp.IsSuccess && resp.Meta.StatusCode != 404 {
+			return ctrl.Result{}, fmt.Errorf("{deleteDHCPOptionSet} %s", fmt.Errorf(resp.Message))
+		}
+	}
+	return r.deleteCRs(dhcpOptionSet, dhcpOptionSetMeta)
+}
+
+func (r *DHCPOptionSetReconciler) deleteCRs(dhcpOptionSet *k8sv1alpha1.DHCPOptionSet, dhcpOptionSetMeta *k8sv1alpha1.DHCPOptionSetMeta) (ctrl.Result, error) {
+	if dhcpOptionSetMeta != nil {
+		_, err := r.deleteDHCPOptionSetMetaCR(dhcpOptionSetMeta)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("{deleteCRs} %s", err)
+		}
+	}
+
+	return r.deleteDHCPOptionSetCR(dhcpOptionSet)
+}
+
+func (r *DHCPOptionSetReconciler) deleteDHCPOptionSetCR(dhcpOptionSet *k8sv1alpha1.DHCPOptionSet) (ctrl.Result, error) {
+	dhcpOptionSet.ObjectMeta.SetFinalizers(nil)
+	dhcpOptionSet.SetFinalizers(nil)
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	if err := r.Update(ctx, dhcpOptionSet.DeepCopyObject(), &client.UpdateOptions{}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("{deleteDHCPOptionSetCR} %s", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *DHCPOptionSetReconciler) deleteDHCPOptionSetMetaCR(dhcpOptionSetMeta *k8sv1alpha1.DHCPOptionSetMeta) (ctrl.Result, error) {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	if err := r.Delete(ctx, dhcpOptionSetMeta.DeepCopyObject(), &client.DeleteOptions{}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("{deleteDHCPOptionSetMetaCR} %s", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DHCPOptionSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&k8sv1alpha1.DHCPOptionSet{}).
+		Complete(r)
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netriswebapi/v2/types/dhcp"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DHCPOptionSetToMeta converts the DHCPOptionSet resource to Meta type.
+func (r *DHCPOptionSetReconciler) DHCPOptionSetToMeta(dhcpOptionSet *k8sv1alpha1.DHCPOptionSet) (*k8sv1alpha1.DHCPOptionSetMeta, error) {
+	var (
+		imported  = false
+		reclaim   = false
+		leaseTime = 86400
+	)
+
+	if i, ok := dhcpOptionSet.GetAnnotations()["resource.k8s.netris.ai/import"]; ok && i == "true" {
+		imported = true
+	}
+	if i, ok := dhcpOptionSet.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
+		reclaim = true
+	}
+
+	if dhcpOptionSet.Spec.LeaseTime > 0 {
+		leaseTime = dhcpOptionSet.Spec.LeaseTime
+	}
+
+	dnsServers := []string{}
+	dnsServers = append(dnsServers, dhcpOptionSet.Spec.DNSServers...)
+	ntpServers := []string{}
+	ntpServers = append(ntpServers, dhcpOptionSet.Spec.NTPServers...)
+	options := []k8sv1alpha1.DHCPOption{}
+	options = append(options, dhcpOptionSet.Spec.AdditionalOptions...)
+
+	dhcpOptionSetMeta := &k8sv1alpha1.DHCPOptionSetMeta{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      string(dhcpOptionSet.GetUID()),
+			Namespace: dhcpOptionSet.GetNamespace(),
+		},
+		TypeMeta: metav1.TypeMeta{},
+		Spec: k8sv1alpha1.DHCPOptionSetMetaSpec{
+			Imported:          imported,
+			Reclaim:           reclaim,
+			DHCPOptionSetName: dhcpOptionSet.Name,
+			Description:       dhcpOptionSet.Spec.Description,
+			DNSServers:        dnsServers,
+			DomainSearch:      dhcpOptionSet.Spec.DomainSearch,
+			NTPServers:        ntpServers,
+			LeaseTime:         leaseTime,
+			AdditionalOptions: options,
+		},
+	}
+
+	return dhcpOptionSetMeta, nil
+}
+
+func dhcpOptionSetCompareFieldsForNewMeta(dhcpOptionSet *k8sv1alpha1.DHCPOptionSet, dhcpOptionSetMeta *k8sv1alpha1.DHCPOptionSetMeta) bool {
+	imported := false
+	reclaim := false
+	if i, ok := dhcpOptionSet.GetAnnotations()["resource.k8s.netris.ai/import"]; ok && i == "true" {
+		imported = true
+	}
+	if i, ok := dhcpOptionSet.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
+		reclaim = true
+	}
+	return dhcpOptionSet.GetGeneration() != dhcpOptionSetMeta.Spec.DHCPOptionSetCRGeneration || imported != dhcpOptionSetMeta.Spec.Imported || reclaim != dhcpOptionSetMeta.Spec.Reclaim
+}
+
+func dhcpOptionSetMustUpdateAnnotations(dhcpOptionSet *k8sv1alpha1.DHCPOptionSet) bool {
+	update := false
+	if i, ok := dhcpOptionSet.GetAnnotations()["resource.k8s.netris.ai/import"]; !(ok && (i == "true" || i == "false")) {
+		update = true
+	}
+	if i, ok := dhcpOptionSet.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; !(ok && (i == "retain" || i == "delete")) {
+		update = true
+	}
+	return update
+}
+
+func dhcpOptionSetUpdateDefaultAnnotations(dhcpOptionSet *k8sv1alpha1.DHCPOptionSet) {
+	imported := "false"
+	reclaim := "delete"
+	if i, ok := dhcpOptionSet.GetAnnotations()["resource.k8s.netris.ai/import"]; ok && i == "true" {
+		imported = "true"
+	}
+	if i, ok := dhcpOptionSet.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
+		reclaim = "retain"
+	}
+	annotations := dhcpOptionSet.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations["resource.k8s.netris.ai/import"] = imported
+	annotations["resource.k8s.netris.ai/reclaimPolicy"] = reclaim
+	dhcpOptionSet.SetAnnotations(annotations)
+}
+
+// DHCPOptionSetMetaToNetris converts Meta to Netris API type.
+func DHCPOptionSetMetaToNetris(dhcpOptionSetMeta *k8sv1alpha1.DHCPOptionSetMeta) *dhcp.DHCPw {
+	options := []dhcp.AdditionalOption{}
+	for _, option := range dhcpOptionSetMeta.Spec.AdditionalOptions {
+		options = append(options, dhcp.AdditionalOption{
+			Code:     option.Code,
+			Type:     option.Type,
+			Value:    option.Value,
+			IsCustom: option.Custom,
+		})
+	}
+
+	return &dhcp.DHCPw{
+		Name:              dhcpOptionSetMeta.Spec.DHCPOptionSetName,
+		Description:       dhcpOptionSetMeta.Spec.Description,
+		DNSServers:        dhcpOptionSetMeta.Spec.DNSServers,
+		DomainSearch:      dhcpOptionSetMeta.Spec.DomainSearch,
+		NTPServers:        dhcpOptionSetMeta.Spec.NTPServers,
+		LeaseTime:         dhcpOptionSetMeta.Spec.LeaseTime,
+		AdditionalOptions: options,
+	}
+}
+
+func compareDHCPOptionSetMetaAPI(dhcpOptionSetMeta *k8sv1alpha1.DHCPOptionSetMeta, apiDHCPOptionSet *dhcp.DHCPOptionSet, u uniReconciler) bool {
+	if apiDHCPOptionSet.Name != dhcpOptionSetMeta.Spec.DHCPOptionSetName {
+		u.DebugLogger.Info("Name changed", "netrisValue", apiDHCPOptionSet.Name, "k8sValue", dhcpOptionSetMeta.Spec.DHCPOptionSetName)
+		return false
+	}
+	if apiDHCPOptionSet.Description != dhcpOptionSetMeta.Spec.Description {
+		u.DebugLogger.Info("Description changed", "netrisValue", apiDHCPOptionSet.Description, "k8sValue", dhcpOptionSetMeta.Spec.Description)
+		return false
+	}
+	if apiDHCPOptionSet.DomainSearch != dhcpOptionSetMeta.Spec.DomainSearch {
+		u.DebugLogger.Info("DomainSearch changed", "netrisValue", apiDHCPOptionSet.DomainSearch, "k8sValue", dhcpOptionSetMeta.Spec.DomainSearch)
+		return false
+	}
+	if apiDHCPOptionSet.LeaseTime != dhcpOptionSetMeta.Spec.LeaseTime {
+		u.DebugLogger.Info("LeaseTime changed", "netrisValue", apiDHCPOptionSet.LeaseTime, "k8sValue", dhcpOptionSetMeta.Spec.LeaseTime)
+		return false
+	}
+	if len(apiDHCPOptionSet.DNSServers)+len(dhcpOptionSetMeta.Spec.DNSServers) > 0 && !reflect.DeepEqual(apiDHCPOptionSet.DNSServers, dhcpOptionSetMeta.Spec.DNSServers) {
+		u.DebugLogger.Info("DNSServers changed", "netrisValue", apiDHCPOptionSet.DNSServers, "k8sValue", dhcpOptionSetMeta.Spec.DNSServers)
+		return false
+	}
+	if len(apiDHCPOptionSet.NTPServers)+len(dhcpOptionSetMeta.Spec.NTPServers) > 0 && !reflect.DeepEqual(apiDHCPOptionSet.NTPServers, dhcpOptionSetMeta.Spec.NTPServers) {
+		u.DebugLogger.Info("NTPServers changed", "netrisValue", apiDHCPOptionSet.NTPServers, "k8sValue", dhcpOptionSetMeta.Spec.NTPServers)
+		return false
+	}
+	if len(apiDHCPOptionSet.AdditionalOptions) != len(dhcpOptionSetMeta.Spec.AdditionalOptions) {
+		u.DebugLogger.Info("AdditionalOptions count changed", "netrisValue", len(apiDHCPOptionSet.AdditionalOptions), "k8sValue", len(dhcpOptionSetMeta.Spec.AdditionalOptions))
+		return false
+	}
+	for i, option := range dhcpOptionSetMeta.Spec.AdditionalOptions {
+		apiOption := apiDHCPOptionSet.AdditionalOptions[i]
+		if fmt.Sprint(apiOption.Code) != fmt.Sprint(option.Code) || apiOption.Type != option.Type || apiOption.Value != option.Value || apiOption.IsCustom != option.Custom {
+			u.DebugLogger.Info("AdditionalOption changed", "netrisValue", apiOption, "k8sValue", option)
+			return false
+		}
+	}
+	return true
+}
+
+// checkDHCPOptionSetDependency makes sure that the DHCP option set referenced by name is ready to be used.
+// If a DHCPOptionSet resource with that name exists in the namespace, it must be provisioned first.
+func checkDHCPOptionSetDependency(cl client.Client, namespace, name string, dhcpOptionSetsByNames map[string]*dhcp.DHCPOptionSet) error {
+	dhcpOptionSet := &k8sv1alpha1.DHCPOptionSet{}
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	if err := cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, dhcpOptionSet); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	} else if dhcpOptionSet.Status.Status != "OK" || dhcpOptionSet.DeletionTimestamp != nil {
+		return fmt.Errorf("waiting for DHCPOptionSet '%s' to be provisioned", name)
+	}
+
+	if _, ok := dhcpOptionSetsByNames[name]; !ok {
+		return fmt.Errorf("DHCP option set '%s' not found", name)
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-logr/logr"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/netrisstorage"
+	"github.com/netrisai/netriswebapi/http"
+	api "github.com/netrisai/netriswebapi/v2"
+	"github.com/netrisai/netriswebapi/v2/types/dhcp"
+)
+
+// DHCPOptionSetMetaReconciler reconciles a DHCPOptionSetMeta object
+type DHCPOptionSetMetaReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Cred     *api.Clientset
+	NStorage *netrisstorage.Storage
+}
+
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=dhcpoptionsetmeta,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=dhcpoptionsetmeta/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=dhcpoptionsetmeta/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop
+func (r *DHCPOptionSetMetaReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	debugLogger := r.Log.WithValues("name", req.NamespacedName).V(int(zapcore.WarnLevel))
+
+	dhcpOptionSetMeta := &k8sv1alpha1.DHCPOptionSetMeta{}
+	dhcpOptionSetCR := &k8sv1alpha1.DHCPOptionSet{}
+	dhcpOptionSetMetaCtx, dhcpOptionSetMetaCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer dhcpOptionSetMetaCancel()
+	if err := r.Get(dhcpOptionSetMetaCtx, req.NamespacedName, dhcpOptionSetMeta); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	logger := r.Log.WithValues("name", fmt.Sprintf("%s/%s", req.NamespacedName.Namespace, dhcpOptionSetMeta.Spec.DHCPOptionSetName))
+	debugLogger = logger.V(int(zapcore.WarnLevel))
+
+	u := uniReconciler{
+		Client:      r.Client,
+		Logger:      logger,
+		DebugLogger: debugLogger,
+		Cred:        r.Cred,
+		NStorage:    r.NStorage,
+	}
+
+	provisionState := "OK"
+
+	dhcpOptionSetNN := req.NamespacedName
+	dhcpOptionSetNN.Name = dhcpOptionSetMeta.Spec.DHCPOptionSetName
+	dhcpOptionSetNNCtx, dhcpOptionSetNNCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer dhcpOptionSetNNCancel()
+	if err := r.Get(dhcpOptionSetNNCtx, dhcpOptionSetNN, dhcpOptionSetCR); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if dhcpOptionSetMeta.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if dhcpOptionSetMeta.Spec.ID == 0 {
+		debugLogger.Info("ID Not found in meta")
+		if dhcpOptionSetMeta.Spec.Imported {
+			logger.Info("Importing DHCPOptionSet")
+			debugLogger.Info("Imported yaml mode. Finding DHCPOptionSet by name")
+			if apiDHCPOptionSet, ok := r.NStorage.DHCPOptionSetStorage.FindByName(dhcpOptionSetMeta.Spec.DHCPOptionSetName); ok {
+				debugLogger.Info("Imported yaml mode. DHCPOptionSet found")
+				dhcpOptionSetMeta.Spec.ID = apiDHCPOptionSet.ID
+
+				dhcpOptionSetMetaPatchCtx, dhcpOptionSetMetaPatchCancel := context.WithTimeout(cntxt, contextTimeout)
+				defer dhcpOptionSetMetaPatchCancel()
+				err := r.Patch(dhcpOptionSetMetaPatchCtx, dhcpOptionSetMeta.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+				if err != nil {
+					logger.Error(fmt.Errorf("{patch dhcpOptionSetMeta.Spec.ID} %s", err), "")
+					return u.patchDHCPOptionSetStatus(dhcpOptionSetCR, "Failure", err.Error())
+				}
+				debugLogger.Info("Imported yaml mode. ID patched")
+				logger.Info("DHCPOptionSet imported")
+				return ctrl.Result{RequeueAfter: requeueInterval}, nil
+			}
+			logger.Info("DHCPOptionSet not found for import")
+			debugLogger.Info("Imported yaml mode. DHCPOptionSet not found")
+		}
+
+		logger.Info("Creating DHCPOptionSet")
+		if _, err, errMsg := r.createDHCPOptionSet(dhcpOptionSetMeta); err != nil {
+			logger.Error(fmt.Errorf("{createDHCPOptionSet} %s", err), "")
+			return u.patchDHCPOptionSetStatus(dhcpOptionSetCR, "Failure", errMsg.Error())
+		}
+		logger.Info("DHCPOptionSet Created")
+	} else {
+		if apiDHCPOptionSet, ok := r.NStorage.DHCPOptionSetStorage.FindByID(dhcpOptionSetMeta.Spec.ID); ok {
+			debugLogger.Info("Comparing DHCPOptionSetMeta with Netris DHCPOptionSet")
+
+			if ok := compareDHCPOptionSetMetaAPI(dhcpOptionSetMeta, apiDHCPOptionSet, u); ok {
+				debugLogger.Info("Nothing Changed")
+			} else {
+				debugLogger.Info("Go to update DHCPOptionSet in Netris")
+				logger.Info("Updating DHCPOptionSet")
+				dhcpOptionSetUpdate := DHCPOptionSetMetaToNetris(dhcpOptionSetMeta)
+
+				js, _ := json.Marshal(dhcpOptionSetUpdate)
+				debugLogger.Info("dhcpOptionSetUpdate", "payload", string(js))
+
+				_, err, errMsg := updateDHCPOptionSet(dhcpOptionSetMeta.Spec.ID, dhcpOptionSetUpdate, r.Cred)
+				if err != nil {
+					logger.Error(fmt.Errorf("{updateDHCPOptionSet} %s", err), "")
+					return u.patchDHCPOptionSetStatus(dhcpOptionSetCR, "Failure", errMsg.Error())
+				}
+				logger.Info("DHCPOptionSet Updated")
+			}
+		} else {
+			debugLogger.Info("DHCPOptionSet not found in Netris")
+			debugLogger.Info("Going to create DHCPOptionSet")
+			logger.Info("Creating DHCPOptionSet")
+			if _, err, errMsg := r.createDHCPOptionSet(dhcpOptionSetMeta); err != nil {
+				logger.Error(fmt.Errorf("{createDHCPOptionSet} %s", err), "")
+				return u.patchDHCPOptionSetStatus(dhcpOptionSetCR, "Failure", errMsg.Error())
+			}
+			logger.Info("DHCPOptionSet Created")
+		}
+	}
+
+	return u.patchDHCPOptionSetStatus(dhcpOptionSetCR, provisionState, "Success")
+}
+
+func (r *DHCPOptionSetMetaReconciler) createDHCPOptionSet(dhcpOptionSetMeta *k8sv1alpha1.DHCPOptionSetMeta) (ctrl.Result, error, error) {
+	debugLogger := r.Log.WithValues(
+		"name", fmt.Sprintf("%s/%s", dhcpOptionSetMeta.Namespace, dhcpOptionSetMeta.Spec.DHCPOptionSetName),
+		"dhcpOptionSetName", dhcpOptionSetMeta.Spec.DHCPOptionSetCRGeneration,
+	).V(int(zapcore.WarnLevel))
+
+	dhcpOptionSetAdd := DHCPOptionSetMetaToNetris(dhcpOptionSetMeta)
+
+	js, _ := json.Marshal(dhcpOptionSetAdd)
+	debugLogger.Info("dhcpOptionSetToAdd", "payload", string(js))
+
+	reply, err := r.Cred.DHCP().Add(dhcpOptionSetAdd)
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+
+	idStruct := struct {
+		ID int `json:"id"`
+	}{}
+
+	data, err := reply.Parse()
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+
+	if reply.StatusCode != 200 {
+		return ctrl.Result{}, fmt.Errorf(data.Message), fmt.Errorf(data.Message)
+	}
+
+	idStruct.ID = int(data.Data.(map[string]interface{})["id"].(float64))
+
+	debugLogger.Info("DHCPOptionSet Created", "id", idStruct.ID)
+
+	dhcpOptionSetMeta.Spec.ID = idStruct.ID
+
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	err = r.Patch(ctx, dhcpOptionSetMeta.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+
+	debugLogger.Info("ID patched to meta", "id", idStruct.ID)
+	return ctrl.Result{}, nil, nil
+}
+
+func updateDHCPOptionSet(id int, dhcpW *dhcp.DHCPw, cred *api.Clientset) (ctrl.Result, error, error) {
+	reply, err := cred.DHCP().Update(id, dhcpW)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("{updateDHCPOptionSet} %s", err), err
+	}
+	resp, err := http.ParseAPIResponse(reply.Data)
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+	if !resp.IsSuccess {
+		return ctrl.Result{}, fmt.Errorf("{updateDHCPOptionSet} %s", fmt.Errorf(resp.Message)), fmt.Errorf(resp.Message)
+	}
+
+	return ctrl.Result{}, nil, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DHCPOptionSetMetaReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&k8sv1alpha1.DHCPOptionSetMeta{}).
+		Complete(r)
+}
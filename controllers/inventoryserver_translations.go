@@ -74,7 +74,22 @@ func (r *InventoryServerReconciler) InventoryServerToInventoryServerMeta(invento
 
 	// Convert links from CRD format to API format
 	links := make([]inventory.HWLink, 0, len(inventoryServer.Spec.Links))
-	for _, link := range inventoryServer.Spec.Links {
+	for i, link := range inventoryServer.Spec.Links {
+		if err := validatePortOrLAG("remote", link.Remote, link.LAG); err != nil {
+			return nil, fmt.Errorf("links[%d]: %s", i, err)
+		}
+		if link.LAG != "" {
+			apiLAG, err := getLAGAggregatedPort(r.Client, r.NStorage, inventoryServer.GetNamespace(), link.LAG)
+			if err != nil {
+				return nil, err
+			}
+			links = append(links, inventory.HWLink{
+				Local:  inventory.IDName{Name: link.Local},
+				Remote: inventory.IDName{ID: apiLAG.ID, Name: apiLAG.Name},
+			})
+			continue
+		}
+
 		// Remote is expected in format "portName@switchName"
 		// FindByName already searches by "portName@switchName"
 		port, ok := r.NStorage.PortsStorage.FindByName(link.Remote)
@@ -109,7 +124,7 @@ func (r *InventoryServerReconciler) InventoryServerToInventoryServerMeta(invento
 			UUID:                inventoryServer.Spec.UUID,
 			Links:               links,
 			CustomData:          inventoryServer.Spec.CustomData,
-			Tags:                inventoryServer.Spec.Tags,
+			Tags:                objectTags(inventoryServer, inventoryServer.Spec.Tags),
 			SRVRole:             inventoryServer.Spec.SRVRole,
 		},
 	}
@@ -126,7 +141,7 @@ func inventoryServerCompareFieldsForNewMeta(inventoryServer *k8sv1alpha1.Invento
 	if i, ok := inventoryServer.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
 		reclaim = true
 	}
-	return inventoryServer.GetGeneration() != inventoryServerMeta.Spec.InventoryServerCRGeneration || imported != inventoryServerMeta.Spec.Imported || reclaim != inventoryServerMeta.Spec.Reclaim
+	return inventoryServer.GetGeneration() != inventoryServerMeta.Spec.InventoryServerCRGeneration || imported != inventoryServerMeta.Spec.Imported || reclaim != inventoryServerMeta.Spec.Reclaim || !compareTags(objectTags(inventoryServer, inventoryServer.Spec.Tags), inventoryServerMeta.Spec.Tags)
 }
 
 func inventoryServerMustUpdateAnnotations(inventoryServer *k8sv1alpha1.InventoryServer) bool {
@@ -188,7 +203,7 @@ func InventoryServerMetaToNetris(inventoryServerMeta *k8sv1alpha1.InventoryServe
 		UUID:        inventoryServerMeta.Spec.UUID,
 		Links:       inventoryServerMeta.Spec.Links,
 		CustomData:  inventoryServerMeta.Spec.CustomData,
-		Tags:        inventoryServerMeta.Spec.Tags,
+		Tags:        metaTags(inventoryServerMeta.Spec.Tags),
 		SRVRole:     inventoryServerMeta.Spec.SRVRole,
 	}
 
@@ -225,7 +240,7 @@ func InventoryServerMetaToNetrisUpdate(inventoryServerMeta *k8sv1alpha1.Inventor
 		UUID:        inventoryServerMeta.Spec.UUID,
 		Links:       inventoryServerMeta.Spec.Links,
 		CustomData:  inventoryServerMeta.Spec.CustomData,
-		Tags:        inventoryServerMeta.Spec.Tags,
+		Tags:        metaTags(inventoryServerMeta.Spec.Tags),
 		SRVRole:     inventoryServerMeta.Spec.SRVRole,
 	}
 
@@ -288,5 +303,10 @@ func compareInventoryServerMetaAPIServer(inventoryServerMeta *k8sv1alpha1.Invent
 		return false
 	}
 
+	if !compareTags(apiServer.Tags, inventoryServerMeta.Spec.Tags) {
+		u.DebugLogger.Info("Tags changed", "netrisValue", apiServer.Tags, "k8sValue", inventoryServerMeta.Spec.Tags)
+		return false
+	}
+
 	return true
 }
@@ -19,7 +19,6 @@ package controllers
 import (
 	"fmt"
 	"net"
-	"regexp"
 	"strconv"
 	"strings"
 
@@ -33,8 +32,6 @@ import (
 
 // L4LBToL4LBMeta converts the VNet resource to VNetMeta type and used for add the VNet for Netris API.
 func (r *L4LBReconciler) L4LBToL4LBMeta(l4lb *k8sv1alpha1.L4LB) (*k8sv1alpha1.L4LBMeta, error) {
-	bReg := regexp.MustCompile(`^(?P<ip>(([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\.){3}([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])):(?P<port>([1-9]|[1-9][0-9]{1,3}|[1-5][0-9]{4}|6[0-4][0-9]{3}|65[0-4][0-9]{2}|655[0-2][0-9]|6553[0-4]))$`)
-
 	tenantID := 0
 	siteID := 0
 	vpcID := 0
@@ -47,15 +44,13 @@ func (r *L4LBReconciler) L4LBToL4LBMeta(l4lb *k8sv1alpha1.L4LB) (*k8sv1alpha1.L4
 	ipForTenant := ""
 
 	for _, backend := range l4lb.Spec.Backend {
-		valueMatch := bReg.FindStringSubmatch(string(backend))
-		result := regParser(valueMatch, bReg.SubexpNames())
-		port, err := strconv.Atoi(result["port"])
+		ip, port, err := parseL4LBBackend(backend)
 		if err != nil {
 			return nil, err
 		}
-		ipForTenant = result["ip"]
+		ipForTenant = ip
 		l4lbMetaBackends = append(l4lbMetaBackends, k8sv1alpha1.L4LBMetaBackend{
-			IP:   result["ip"],
+			IP:   ip,
 			Port: port,
 		})
 	}
@@ -101,7 +96,14 @@ func (r *L4LBReconciler) L4LBToL4LBMeta(l4lb *k8sv1alpha1.L4LB) (*k8sv1alpha1.L4
 	if r.VPCID > 0 {
 		vpcIDInput = r.VPCID
 	}
-	if vpcIDInput > 0 {
+	if l4lb.Spec.VPC != "" {
+		if vpc, ok := r.NStorage.VPCStorage.FindByName(l4lb.Spec.VPC); ok {
+			vpcID = vpc.ID
+			vpcName = vpc.Name
+		} else {
+			return nil, fmt.Errorf("vpc '%s' not found", l4lb.Spec.VPC)
+		}
+	} else if vpcIDInput > 0 {
 		if vpc, ok := r.NStorage.VPCStorage.FindByID(vpcIDInput); ok {
 			vpcID = vpc.ID
 			vpcName = vpc.Name
@@ -214,14 +216,14 @@ func compareL4LBMetaAPIL4LBBackend(l4lbMetaBackends []k8sv1alpha1.L4LBMetaBacken
 	for _, m := range l4lbMetaBackends {
 		l4lbBackends = append(l4lbBackends, member{
 			Port: strconv.Itoa(m.Port),
-			IP:   m.IP,
+			IP:   normalizeIP(m.IP),
 		})
 	}
 
 	for _, m := range apiL4LBBackends {
 		apiBackends = append(apiBackends, member{
 			Port: m.Port,
-			IP:   m.IP,
+			IP:   normalizeIP(m.IP),
 		})
 	}
 
@@ -233,7 +235,7 @@ func compareL4LBMetaAPIL4LB(l4lbMeta *k8sv1alpha1.L4LBMeta, apiL4LB *l4lb.LoadBa
 	if l4lbMeta.Spec.L4LBName != apiL4LB.Name {
 		return false
 	}
-	if l4lbMeta.Spec.IP != apiL4LB.IP {
+	if normalizeIP(l4lbMeta.Spec.IP) != normalizeIP(apiL4LB.IP) {
 		return false
 	}
 	if l4lbMeta.Spec.Automatic != apiL4LB.Automatic {
@@ -454,3 +456,47 @@ func (r *L4LBReconciler) findSiteByIP(ip string) (int, error) {
 
 	return siteID, fmt.Errorf("there are no sites for specified IP address %s", ip)
 }
+
+// parseL4LBBackend splits an "ip:port" or "[ipv6]:port" backend into its address and port.
+func parseL4LBBackend(backend k8sv1alpha1.L4LBBackend) (string, int, error) {
+	host, portStr, err := net.SplitHostPort(string(backend))
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid backend '%s': %s", backend, err)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return "", 0, fmt.Errorf("invalid backend '%s': invalid ip address", backend)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 1 || port > 65534 {
+		return "", 0, fmt.Errorf("invalid backend '%s': invalid port", backend)
+	}
+	return ip.String(), port, nil
+}
+
+// apiL4LBBackendsStatus converts the backends health reported by Netris into the L4LB status.
+// Netris reports the health check result of a backend as "up" or "down", backends in maintenance,
+// not checked yet or with any other status are reported as unknown.
+func apiL4LBBackendsStatus(apiBackends []l4lb.LBBackend) ([]k8sv1alpha1.L4LBBackendStatus, string) {
+	backends := []k8sv1alpha1.L4LBBackendStatus{}
+	healthy := 0
+	for _, b := range apiBackends {
+		status := k8sv1alpha1.L4LBBackendStatus{
+			Backend:  k8sv1alpha1.L4LBBackend(net.JoinHostPort(b.IP, b.Port)),
+			Health:   k8sv1alpha1.L4LBBackendUnknown,
+			Status:   b.Status,
+			Response: b.Response,
+		}
+		switch {
+		case b.Maintenance:
+			status.Status = "maintenance"
+		case strings.EqualFold(b.Status, "up"):
+			status.Health = k8sv1alpha1.L4LBBackendHealthy
+			healthy++
+		case strings.EqualFold(b.Status, "down"):
+			status.Health = k8sv1alpha1.L4LBBackendUnhealthy
+		}
+		backends = append(backends, status)
+	}
+	return backends, fmt.Sprintf("%d/%d", healthy, len(backends))
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netriswebapi/v2/types/l4lb"
+)
+
+func TestAPIL4LBBackendsStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		apiBackends []l4lb.LBBackend
+		want        []k8sv1alpha1.L4LBBackendStatus
+		wantHealthy string
+	}{
+		{
+			name:        "no backends",
+			want:        []k8sv1alpha1.L4LBBackendStatus{},
+			wantHealthy: "0/0",
+		},
+		{
+			name: "health check results",
+			apiBackends: []l4lb.LBBackend{
+				{IP: "10.0.0.10", Port: "30080", Status: "up", Response: "200 OK"},
+				{IP: "10.0.0.11", Port: "30080", Status: "Down", Response: "connection refused"},
+				{IP: "10.0.0.12", Port: "30080", Status: "pending"},
+				{IP: "10.0.0.13", Port: "30080"},
+			},
+			want: []k8sv1alpha1.L4LBBackendStatus{
+				{Backend: "10.0.0.10:30080", Health: k8sv1alpha1.L4LBBackendHealthy, Status: "up", Response: "200 OK"},
+				{Backend: "10.0.0.11:30080", Health: k8sv1alpha1.L4LBBackendUnhealthy, Status: "Down", Response: "connection refused"},
+				{Backend: "10.0.0.12:30080", Health: k8sv1alpha1.L4LBBackendUnknown, Status: "pending"},
+				{Backend: "10.0.0.13:30080", Health: k8sv1alpha1.L4LBBackendUnknown},
+			},
+			wantHealthy: "1/4",
+		},
+		{
+			name: "maintenance",
+			apiBackends: []l4lb.LBBackend{
+				{IP: "10.0.0.10", Port: "30080", Status: "up"},
+				{IP: "10.0.0.11", Port: "30080", Status: "up", Maintenance: true},
+			},
+			want: []k8sv1alpha1.L4LBBackendStatus{
+				{Backend: "10.0.0.10:30080", Health: k8sv1alpha1.L4LBBackendHealthy, Status: "up"},
+				{Backend: "10.0.0.11:30080", Health: k8sv1alpha1.L4LBBackendUnknown, Status: "maintenance"},
+			},
+			wantHealthy: "1/2",
+		},
+		{
+			name:        "IPv6 backend",
+			apiBackends: []l4lb.LBBackend{{IP: "2001:db8::10", Port: "30080", Status: "up"}},
+			want: []k8sv1alpha1.L4LBBackendStatus{
+				{Backend: "[2001:db8::10]:30080", Health: k8sv1alpha1.L4LBBackendHealthy, Status: "up"},
+			},
+			wantHealthy: "1/1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, healthy := apiL4LBBackendsStatus(tt.apiBackends)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("backends = %+v, want %+v", got, tt.want)
+			}
+			if healthy != tt.wantHealthy {
+				t.Errorf("healthy = %q, want %q", healthy, tt.wantHealthy)
+			}
+		})
+	}
+}
+
+func TestAPIL4LBBackendsStatusClearsRemovedBackends(t *testing.T) {
+	l4lbCR := &k8sv1alpha1.L4LB{}
+	l4lbCR.Status.Backends, l4lbCR.Status.BackendsHealthy = apiL4LBBackendsStatus([]l4lb.LBBackend{
+		{IP: "10.0.0.10", Port: "30080", Status: "up"},
+		{IP: "10.0.0.11", Port: "30080", Status: "down"},
+	})
+
+	l4lbCR.Status.Backends, l4lbCR.Status.BackendsHealthy = apiL4LBBackendsStatus([]l4lb.LBBackend{
+		{IP: "10.0.0.10", Port: "30080", Status: "up"},
+	})
+	want := []k8sv1alpha1.L4LBBackendStatus{{Backend: "10.0.0.10:30080", Health: k8sv1alpha1.L4LBBackendHealthy, Status: "up"}}
+	if !reflect.DeepEqual(l4lbCR.Status.Backends, want) || l4lbCR.Status.BackendsHealthy != "1/1" {
+		t.Errorf("backends = %+v %q, want %+v %q", l4lbCR.Status.Backends, l4lbCR.Status.BackendsHealthy, want, "1/1")
+	}
+
+	l4lbCR.Status.Backends, l4lbCR.Status.BackendsHealthy = apiL4LBBackendsStatus(nil)
+	if len(l4lbCR.Status.Backends) != 0 || l4lbCR.Status.BackendsHealthy != "0/0" {
+		t.Errorf("backends = %+v %q, want none", l4lbCR.Status.Backends, l4lbCR.Status.BackendsHealthy)
+	}
+}
+
+func TestL4LBBackendIPv6RoundTrip(t *testing.T) {
+	tests := []struct {
+		name        string
+		backends    []k8sv1alpha1.L4LBBackend
+		apiBackends []l4lb.LBBackend
+		want        bool
+	}{
+		{
+			name:        "IPv4",
+			backends:    []k8sv1alpha1.L4LBBackend{"10.0.0.10:30080"},
+			apiBackends: []l4lb.LBBackend{{IP: "10.0.0.10", Port: "30080"}},
+			want:        true,
+		},
+		{
+			name:        "IPv6",
+			backends:    []k8sv1alpha1.L4LBBackend{"[2001:db8::10]:30080", "[2001:db8::11]:30080"},
+			apiBackends: []l4lb.LBBackend{{IP: "2001:db8::10", Port: "30080"}, {IP: "2001:db8::11", Port: "30080"}},
+			want:        true,
+		},
+		{
+			name:        "IPv6 in another notation",
+			backends:    []k8sv1alpha1.L4LBBackend{"[2001:DB8:0::10]:30080"},
+			apiBackends: []l4lb.LBBackend{{IP: "2001:db8:0:0:0:0:0:10", Port: "30080"}},
+			want:        true,
+		},
+		{
+			name:        "IPv6 port changed",
+			backends:    []k8sv1alpha1.L4LBBackend{"[2001:db8::10]:30081"},
+			apiBackends: []l4lb.LBBackend{{IP: "2001:db8::10", Port: "30080"}},
+		},
+		{
+			name:        "IPv6 address changed",
+			backends:    []k8sv1alpha1.L4LBBackend{"[2001:db8::11]:30080"},
+			apiBackends: []l4lb.LBBackend{{IP: "2001:db8::10", Port: "30080"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metaBackends := []k8sv1alpha1.L4LBMetaBackend{}
+			for _, backend := range tt.backends {
+				ip, port, err := parseL4LBBackend(backend)
+				if err != nil {
+					t.Fatalf("parseL4LBBackend(%s): %s", backend, err)
+				}
+				metaBackends = append(metaBackends, k8sv1alpha1.L4LBMetaBackend{IP: ip, Port: port})
+			}
+			if got := compareL4LBMetaAPIL4LBBackend(metaBackends, tt.apiBackends); got != tt.want {
+				t.Errorf("compareL4LBMetaAPIL4LBBackend = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseL4LBBackend(t *testing.T) {
+	tests := []struct {
+		backend  k8sv1alpha1.L4LBBackend
+		wantIP   string
+		wantPort int
+		wantErr  bool
+	}{
+		{backend: "10.0.0.10:30080", wantIP: "10.0.0.10", wantPort: 30080},
+		{backend: "[2001:db8::10]:30080", wantIP: "2001:db8::10", wantPort: 30080},
+		{backend: "2001:db8::10:30080", wantErr: true},
+		{backend: "[2001:db8::10]", wantErr: true},
+		{backend: "node1:30080", wantErr: true},
+		{backend: "10.0.0.10:0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.backend), func(t *testing.T) {
+			ip, port, err := parseL4LBBackend(tt.backend)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ip != tt.wantIP || port != tt.wantPort {
+				t.Errorf("parseL4LBBackend = %s, %d, want %s, %d", ip, port, tt.wantIP, tt.wantPort)
+			}
+		})
+	}
+}
@@ -205,6 +205,7 @@ func (r *L4LBMetaReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 				logger.Info("L4LB Updated")
 			}
 			provisionState = apiL4LB.Label.Text
+			l4lbCR.Status.Backends, l4lbCR.Status.BackendsHealthy = apiL4LBBackendsStatus(apiL4LB.BackendIPs)
 		}
 	}
 
@@ -322,7 +323,13 @@ func (u *uniReconciler) updateL4LBIfNeccesarry(l4lbCR *k8sv1alpha1.L4LB, l4lbMet
 	return ctrl.Result{}, nil
 }
 
+// populateMetaVPC sets the global VPC of the operator on the meta of an L4LB without its own VPC.
+// The VPC of the L4LB is already resolved by L4LBToL4LBMeta and is kept as is.
 func (r *L4LBMetaReconciler) populateMetaVPC(l4lbMeta *k8sv1alpha1.L4LBMeta, l4lbCR *k8sv1alpha1.L4LB) error {
+	if l4lbCR.Spec.VPC != "" {
+		return nil
+	}
+
 	vpcIDInput := r.VPCID
 
 	if vpcIDInput == 0 {
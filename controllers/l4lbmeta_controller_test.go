@@ -0,0 +1,80 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/netrisstorage"
+	"github.com/netrisai/netriswebapi/v2/types/vpc"
+)
+
+func TestPopulateMetaVPC(t *testing.T) {
+	nStorage := netrisstorage.NewStorage(nil)
+	nStorage.VPCStorage.VPCs = []*vpc.VPC{{ID: 3, Name: "global"}, {ID: 7, Name: "tenant-vpc"}}
+
+	tests := []struct {
+		name     string
+		globalID int
+		crVPC    string
+		meta     k8sv1alpha1.L4LBMetaSpec
+		wantID   int
+		wantName string
+	}{
+		{
+			name:     "own VPC is kept over the global one",
+			globalID: 3,
+			crVPC:    "tenant-vpc",
+			meta:     k8sv1alpha1.L4LBMetaSpec{VPCID: 7, VPCName: "tenant-vpc"},
+			wantID:   7,
+			wantName: "tenant-vpc",
+		},
+		{
+			name:     "own VPC is kept without a global one",
+			crVPC:    "tenant-vpc",
+			meta:     k8sv1alpha1.L4LBMetaSpec{VPCID: 7, VPCName: "tenant-vpc"},
+			wantID:   7,
+			wantName: "tenant-vpc",
+		},
+		{
+			name:     "global VPC without own VPC",
+			globalID: 3,
+			wantID:   3,
+			wantName: "global",
+		},
+		{
+			name:   "no VPC at all",
+			meta:   k8sv1alpha1.L4LBMetaSpec{VPCID: 3, VPCName: "global"},
+			wantID: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &L4LBMetaReconciler{NStorage: nStorage, VPCID: tt.globalID}
+			l4lbCR := &k8sv1alpha1.L4LB{Spec: k8sv1alpha1.L4LBSpec{VPC: tt.crVPC}}
+			l4lbMeta := &k8sv1alpha1.L4LBMeta{Spec: tt.meta}
+			if err := r.populateMetaVPC(l4lbMeta, l4lbCR); err != nil {
+				t.Fatal(err)
+			}
+			if l4lbMeta.Spec.VPCID != tt.wantID || l4lbMeta.Spec.VPCName != tt.wantName {
+				t.Errorf("VPC = %d %q, want %d %q", l4lbMeta.Spec.VPCID, l4lbMeta.Spec.VPCName, tt.wantID, tt.wantName)
+			}
+		})
+	}
+}
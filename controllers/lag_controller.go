@@ -0,0 +1,231 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-logr/logr"
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/netrisstorage"
+	"github.com/netrisai/netriswebapi/http"
+	api "github.com/netrisai/netriswebapi/v2"
+	"github.com/netrisai/netriswebapi/v2/types/port"
+)
+
+// LAGReconciler reconciles a LAG object
+type LAGReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Cred     *api.Clientset
+	NStorage *netrisstorage.Storage
+}
+
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=lags,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=lags/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=lags/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop
+func (r *LAGReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("name", req.NamespacedName)
+	debugLogger := logger.V(int(zapcore.WarnLevel))
+	lag := &k8sv1alpha1.LAG{}
+
+	u := uniReconciler{
+		Client:      r.Client,
+		Logger:      logger,
+		DebugLogger: debugLogger,
+		Cred:        r.Cred,
+		NStorage:    r.NStorage,
+	}
+
+	lagCtx, lagCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer lagCancel()
+	if err := r.Get(lagCtx, req.NamespacedName, lag); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	lagMetaNamespaced := req.NamespacedName
+	lagMetaNamespaced.Name = string(lag.GetUID())
+	lagMeta := &k8sv1alpha1.LAGMeta{}
+	metaFound := true
+
+	lagMetaCtx, lagMetaCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer lagMetaCancel()
+	if err := r.Get(lagMetaCtx, lagMetaNamespaced, lagMeta); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			metaFound = false
+			lagMeta = nil
+		} else {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if lag.DeletionTimestamp != nil {
+		logger.Info("Go to delete")
+		_, err := r.deleteLAG(lag, lagMeta)
+		if err != nil {
+			logger.Error(fmt.Errorf("{deleteLAG} %s", err), "")
+			return u.patchLAGStatus(lag, "Failure", err.Error())
+		}
+		logger.Info("LAG deleted")
+		return ctrl.Result{}, nil
+	}
+
+	if lagMustUpdateAnnotations(lag) {
+		debugLogger.Info("Setting default annotations")
+		lagUpdateDefaultAnnotations(lag)
+		lagPatchCtx, lagPatchCancel := context.WithTimeout(cntxt, contextTimeout)
+		defer lagPatchCancel()
+		err := r.Patch(lagPatchCtx, lag.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+		if err != nil {
+			logger.Error(fmt.Errorf("{Patch LAG default annotations} %s", err), "")
+			return ctrl.Result{RequeueAfter: requeueInterval}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if metaFound {
+		debugLogger.Info("Meta found")
+		if lagCompareFieldsForNewMeta(lag, lagMeta) {
+			debugLogger.Info("Generating New Meta")
+			lagID := lagMeta.Spec.ID
+			newLAGMeta, err := r.LAGToMeta(lag)
+			if err != nil {
+				logger.Error(fmt.Errorf("{LAGToMeta} %s", err), "")
+				return u.patchLAGStatus(lag, "Failure", err.Error())
+			}
+			lagMeta.Spec = newLAGMeta.DeepCopy().Spec
+			lagMeta.Spec.ID = lagID
+			lagMeta.Spec.LAGCRGeneration = lag.GetGeneration()
+
+			lagMetaUpdateCtx, lagMetaUpdateCancel := context.WithTimeout(cntxt, contextTimeout)
+			defer lagMetaUpdateCancel()
+			err = r.Update(lagMetaUpdateCtx, lagMeta.DeepCopyObject(), &client.UpdateOptions{})
+			if err != nil {
+				logger.Error(fmt.Errorf("{lagMeta Update} %s", err), "")
+				return ctrl.Result{RequeueAfter: requeueInterval}, nil
+			}
+		}
+	} else {
+		debugLogger.Info("Meta not found")
+		if lag.GetFinalizers() == nil {
+			lag.SetFinalizers([]string{"resource.k8s.netris.ai/delete"})
+
+			lagPatchCtx, lagPatchCancel := context.WithTimeout(cntxt, contextTimeout)
+			defer lagPatchCancel()
+			err := r.Patch(lagPatchCtx, lag.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+			if err != nil {
+				logger.Error(fmt.Errorf("{Patch LAG Finalizer} %s", err), "")
+				return ctrl.Result{RequeueAfter: requeueInterval}, nil
+			}
+			return ctrl.Result{}, nil
+		}
+
+		lagMeta, err := r.LAGToMeta(lag)
+		if err != nil {
+			logger.Error(fmt.Errorf("{LAGToMeta} %s", err), "")
+			return u.patchLAGStatus(lag, "Failure", err.Error())
+		}
+
+		lagMeta.Spec.LAGCRGeneration = lag.GetGeneration()
+
+		lagMetaCreateCtx, lagMetaCreateCancel := context.WithTimeout(cntxt, contextTimeout)
+		defer lagMetaCreateCancel()
+		if err := r.Create(lagMetaCreateCtx, lagMeta.DeepCopyObject(), &client.CreateOptions{}); err != nil {
+			logger.Error(fmt.Errorf("{lagMeta Create} %s", err), "")
+			return ctrl.Result{RequeueAfter: requeueInterval}, nil
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: requeueInterval}, nil
+}
+
+func (r *LAGReconciler) deleteLAG(lag *k8sv1alpha1.LAG, lagMeta *k8sv1alpha1.LAGMeta) (ctrl.Result, error) {
+	if lagMeta != nil && lagMeta.Spec.ID > 0 && !lagMeta.Spec.Reclaim {
+		// Freeing up the member ports removes the aggregated port in Netris.
+		members := []*port.IDName{}
+		for _, p := range lagMeta.Spec.Ports {
+			members = append(members, &port.IDName{ID: p.ID, Name: p.Name})
+		}
+		reply, err := r.Cred.Port().FreeUP(members)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("{deleteLAG} %s", err)
+		}
+		resp, err := http.ParseAPIResponse(reply.Data)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !resp.IsSuccess && resp.Meta.StatusCode != 404 {
+			return ctrl.Result{}, fmt.Errorf("{deleteLAG} %s", fmt.Errorf(resp.Message))
+		}
+	}
+	return r.deleteCRs(lag, lagMeta)
+}
+
+func (r *LAGReconciler) deleteCRs(lag *k8sv1alpha1.LAG, lagMeta *k8sv1alpha1.LAGMeta) (ctrl.Result, error) {
+	if lagMeta != nil {
+		_, err := r.deleteLAGMetaCR(lagMeta)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("{deleteCRs} %s", err)
+		}
+	}
+
+	return r.deleteLAGCR(lag)
+}
+
+func (r *LAGReconciler) deleteLAGCR(lag *k8sv1alpha1.LAG) (ctrl.Result, error) {
+	lag.ObjectMeta.SetFinalizers(nil)
+	lag.SetFinalizers(nil)
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	if err := r.Update(ctx, lag.DeepCopyObject(), &client.UpdateOptions{}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("{deleteLAGCR} %s", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *LAGReconciler) deleteLAGMetaCR(lagMeta *k8sv1alpha1.LAGMeta) (ctrl.Result, error) {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	if err := r.Delete(ctx, lagMeta.DeepCopyObject(), &client.DeleteOptions{}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("{deleteLAGMetaCR} %s", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LAGReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&k8sv1alpha1.LAG{}).
+		Complete(r)
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/netrisstorage"
+	"github.com/netrisai/netriswebapi/v2/types/port"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// LAGToMeta converts the LAG resource to Meta type.
+func (r *LAGReconciler) LAGToMeta(lag *k8sv1alpha1.LAG) (*k8sv1alpha1.LAGMeta, error) {
+	var (
+		imported = false
+		reclaim  = false
+		lacp     = "on"
+		mtu      = 9000
+	)
+
+	if i, ok := lag.GetAnnotations()["resource.k8s.netris.ai/import"]; ok && i == "true" {
+		imported = true
+	}
+	if i, ok := lag.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
+		reclaim = true
+	}
+
+	tenantID := 0
+	tenantName := lag.Spec.Tenant
+	if tenant, ok := r.NStorage.TenantsStorage.FindByName(lag.Spec.Tenant); ok {
+		tenantID = tenant.ID
+		tenantName = tenant.Name
+	} else {
+		return nil, fmt.Errorf("couldn't find tenant '%s'", lag.Spec.Tenant)
+	}
+
+	if lag.Spec.LACP != "" {
+		lacp = lag.Spec.LACP
+	}
+	if lag.Spec.MTU > 0 {
+		mtu = lag.Spec.MTU
+	}
+
+	ports := []k8sv1alpha1.LAGMetaPort{}
+	switches := make(map[string]struct{})
+	for _, portName := range lag.Spec.Ports {
+		p, ok := r.NStorage.PortsStorage.FindByName(string(portName))
+		if !ok {
+			return nil, fmt.Errorf("port '%s' not found", portName)
+		}
+		switches[p.SwitchName] = struct{}{}
+		ports = append(ports, k8sv1alpha1.LAGMetaPort{
+			ID:   p.ID,
+			Name: string(portName),
+		})
+	}
+
+	if len(switches) > 2 {
+		return nil, fmt.Errorf("LAG ports can span at most two switches")
+	}
+	if len(switches) == 2 && lag.Spec.MCLAGID == nil {
+		return nil, fmt.Errorf("mclagId is required when ports belong to different switches")
+	}
+
+	lagMeta := &k8sv1alpha1.LAGMeta{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      string(lag.GetUID()),
+			Namespace: lag.GetNamespace(),
+		},
+		TypeMeta: metav1.TypeMeta{},
+		Spec: k8sv1alpha1.LAGMetaSpec{
+			Imported:    imported,
+			Reclaim:     reclaim,
+			LAGName:     lag.Name,
+			TenantID:    tenantID,
+			TenantName:  tenantName,
+			Description: lag.Spec.Description,
+			Ports:       ports,
+			LACP:        lacp,
+			MTU:         mtu,
+			MCLAGID:     lag.Spec.MCLAGID,
+		},
+	}
+
+	return lagMeta, nil
+}
+
+func lagCompareFieldsForNewMeta(lag *k8sv1alpha1.LAG, lagMeta *k8sv1alpha1.LAGMeta) bool {
+	imported := false
+	reclaim := false
+	if i, ok := lag.GetAnnotations()["resource.k8s.netris.ai/import"]; ok && i == "true" {
+		imported = true
+	}
+	if i, ok := lag.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
+		reclaim = true
+	}
+	return lag.GetGeneration() != lagMeta.Spec.LAGCRGeneration || imported != lagMeta.Spec.Imported || reclaim != lagMeta.Spec.Reclaim
+}
+
+func lagMustUpdateAnnotations(lag *k8sv1alpha1.LAG) bool {
+	update := false
+	if i, ok := lag.GetAnnotations()["resource.k8s.netris.ai/import"]; !(ok && (i == "true" || i == "false")) {
+		update = true
+	}
+	if i, ok := lag.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; !(ok && (i == "retain" || i == "delete")) {
+		update = true
+	}
+	return update
+}
+
+func lagUpdateDefaultAnnotations(lag *k8sv1alpha1.LAG) {
+	imported := "false"
+	reclaim := "delete"
+	if i, ok := lag.GetAnnotations()["resource.k8s.netris.ai/import"]; ok && i == "true" {
+		imported = "true"
+	}
+	if i, ok := lag.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
+		reclaim = "retain"
+	}
+	annotations := lag.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations["resource.k8s.netris.ai/import"] = imported
+	annotations["resource.k8s.netris.ai/reclaimPolicy"] = reclaim
+	lag.SetAnnotations(annotations)
+}
+
+// LAGMetaToNetris converts Meta to Netris API type.
+func LAGMetaToNetris(lagMeta *k8sv1alpha1.LAGMeta) *port.PortLAG {
+	ports := []port.IDName{}
+	for _, p := range lagMeta.Spec.Ports {
+		ports = append(ports, port.IDName{ID: p.ID, Name: p.Name})
+	}
+
+	return &port.PortLAG{
+		AggregatedPort: port.IDName{ID: lagMeta.Spec.ID},
+		Description:    lagMeta.Spec.Description,
+		Mtu:            lagMeta.Spec.MTU,
+		Ports:          ports,
+		Tenant: port.IDName{
+			ID:   lagMeta.Spec.TenantID,
+			Name: lagMeta.Spec.TenantName,
+		},
+		LACP:    lagMeta.Spec.LACP,
+		MCLagId: lagMeta.Spec.MCLAGID,
+	}
+}
+
+func lagMetaPortIDs(lagMeta *k8sv1alpha1.LAGMeta) []int {
+	ids := []int{}
+	for _, p := range lagMeta.Spec.Ports {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
+// lagRemovedMembers returns the member ports of the aggregated port that are no longer listed in the meta.
+func lagRemovedMembers(lagMeta *k8sv1alpha1.LAGMeta, apiLAG *port.AggregatedPort) []*port.IDName {
+	wanted := make(map[int]struct{})
+	for _, p := range lagMeta.Spec.Ports {
+		wanted[p.ID] = struct{}{}
+	}
+	removed := []*port.IDName{}
+	for _, member := range apiLAG.LagMembers {
+		if _, ok := wanted[member.ID]; !ok {
+			removed = append(removed, &port.IDName{ID: member.ID, Name: member.Port})
+		}
+	}
+	return removed
+}
+
+func compareLAGMetaAPI(lagMeta *k8sv1alpha1.LAGMeta, apiLAG *port.AggregatedPort, u uniReconciler) bool {
+	if apiLAG.Tenant.ID != lagMeta.Spec.TenantID {
+		u.DebugLogger.Info("TenantID changed", "netrisValue", apiLAG.Tenant.ID, "k8sValue", lagMeta.Spec.TenantID)
+		return false
+	}
+	if apiLAG.Lacp != lagMeta.Spec.LACP {
+		u.DebugLogger.Info("LACP changed", "netrisValue", apiLAG.Lacp, "k8sValue", lagMeta.Spec.LACP)
+		return false
+	}
+
+	apiMembers := []int{}
+	for _, member := range apiLAG.LagMembers {
+		apiMembers = append(apiMembers, member.ID)
+	}
+	metaMembers := lagMetaPortIDs(lagMeta)
+	sort.Ints(apiMembers)
+	sort.Ints(metaMembers)
+	if fmt.Sprint(apiMembers) != fmt.Sprint(metaMembers) {
+		u.DebugLogger.Info("Members changed", "netrisValue", apiMembers, "k8sValue", metaMembers)
+		return false
+	}
+
+	if port, ok := u.NStorage.PortsStorage.FindByID(apiLAG.ID); ok {
+		if port.Mtu != lagMeta.Spec.MTU {
+			u.DebugLogger.Info("MTU changed", "netrisValue", port.Mtu, "k8sValue", lagMeta.Spec.MTU)
+			return false
+		}
+		if port.Description != lagMeta.Spec.Description {
+			u.DebugLogger.Info("Description changed", "netrisValue", port.Description, "k8sValue", lagMeta.Spec.Description)
+			return false
+		}
+	}
+	return true
+}
+
+// validatePortOrLAG checks that a port reference sets exactly one of the switch port and the LAG.
+func validatePortOrLAG(field, portName, lag string) error {
+	if portName == "" && lag == "" {
+		return fmt.Errorf("either %s or lag must be set", field)
+	}
+	if portName != "" && lag != "" {
+		return fmt.Errorf("%s and lag are mutually exclusive, got %s=%s and lag=%s", field, field, portName, lag)
+	}
+	return nil
+}
+
+// getLAGAggregatedPort resolves a LAG resource by name to its aggregated port in Netris.
+func getLAGAggregatedPort(cl client.Client, nStorage *netrisstorage.Storage, namespace, name string) (*port.AggregatedPort, error) {
+	lag := &k8sv1alpha1.LAG{}
+	lagCtx, lagCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer lagCancel()
+	if err := cl.Get(lagCtx, types.NamespacedName{Namespace: namespace, Name: name}, lag); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("LAG '%s' not found", name)
+		}
+		return nil, err
+	}
+
+	lagMeta := &k8sv1alpha1.LAGMeta{}
+	lagMetaCtx, lagMetaCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer lagMetaCancel()
+	if err := cl.Get(lagMetaCtx, types.NamespacedName{Namespace: namespace, Name: string(lag.GetUID())}, lagMeta); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("LAG '%s' is not provisioned yet", name)
+		}
+		return nil, err
+	}
+
+	if lagMeta.Spec.ID == 0 {
+		return nil, fmt.Errorf("LAG '%s' is not provisioned yet", name)
+	}
+
+	apiLAG, ok := nStorage.LAGStorage.FindByID(lagMeta.Spec.ID)
+	if !ok {
+		return nil, fmt.Errorf("aggregated port for LAG '%s' not found", name)
+	}
+	return apiLAG, nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+)
+
+func TestValidatePortOrLAG(t *testing.T) {
+	tests := []struct {
+		name    string
+		port    string
+		lag     string
+		wantErr string
+	}{
+		{name: "port only", port: "swp1@leaf01"},
+		{name: "lag only", lag: "bond0"},
+		{name: "neither", wantErr: "either name or lag must be set"},
+		{name: "both", port: "swp1@leaf01", lag: "bond0", wantErr: "mutually exclusive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePortOrLAG("name", tt.port, tt.lag)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validatePortOrLAG() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validatePortOrLAG() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetPortsMetaRejectsInvalidPorts(t *testing.T) {
+	r := &VNetReconciler{}
+	tests := []struct {
+		name    string
+		ports   []k8sv1alpha1.VNetSwitchPort
+		wantErr string
+	}{
+		{
+			name:    "port without name and lag",
+			ports:   []k8sv1alpha1.VNetSwitchPort{{VlanID: 10}},
+			wantErr: "switchPorts[0]: either name or lag must be set",
+		},
+		{
+			name: "port with name and lag",
+			ports: []k8sv1alpha1.VNetSwitchPort{
+				{Name: "swp1@leaf01"},
+				{Name: "swp2@leaf01", LAG: "bond0"},
+			},
+			wantErr: "switchPorts[1]: name and lag are mutually exclusive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.getPortsMeta("default", tt.ports)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("getPortsMeta() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
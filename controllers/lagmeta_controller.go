@@ -0,0 +1,250 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-logr/logr"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/netrisstorage"
+	"github.com/netrisai/netriswebapi/http"
+	api "github.com/netrisai/netriswebapi/v2"
+	"github.com/netrisai/netriswebapi/v2/types/port"
+)
+
+// LAGMetaReconciler reconciles a LAGMeta object
+type LAGMetaReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Cred     *api.Clientset
+	NStorage *netrisstorage.Storage
+}
+
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=lagmeta,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=lagmeta/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=lagmeta/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop
+func (r *LAGMetaReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	debugLogger := r.Log.WithValues("name", req.NamespacedName).V(int(zapcore.WarnLevel))
+
+	lagMeta := &k8sv1alpha1.LAGMeta{}
+	lagCR := &k8sv1alpha1.LAG{}
+	lagMetaCtx, lagMetaCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer lagMetaCancel()
+	if err := r.Get(lagMetaCtx, req.NamespacedName, lagMeta); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	logger := r.Log.WithValues("name", fmt.Sprintf("%s/%s", req.NamespacedName.Namespace, lagMeta.Spec.LAGName))
+	debugLogger = logger.V(int(zapcore.WarnLevel))
+
+	u := uniReconciler{
+		Client:      r.Client,
+		Logger:      logger,
+		DebugLogger: debugLogger,
+		Cred:        r.Cred,
+		NStorage:    r.NStorage,
+	}
+
+	provisionState := "OK"
+
+	lagNN := req.NamespacedName
+	lagNN.Name = lagMeta.Spec.LAGName
+	lagNNCtx, lagNNCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer lagNNCancel()
+	if err := r.Get(lagNNCtx, lagNN, lagCR); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if lagMeta.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if lagMeta.Spec.ID == 0 {
+		debugLogger.Info("ID Not found in meta")
+		if lagMeta.Spec.Imported {
+			logger.Info("Importing LAG")
+			debugLogger.Info("Imported yaml mode. Finding aggregated port by members")
+			if apiLAG, ok := r.NStorage.LAGStorage.FindByMembers(lagMetaPortIDs(lagMeta)); ok {
+				debugLogger.Info("Imported yaml mode. Aggregated port found")
+				lagMeta.Spec.ID = apiLAG.ID
+
+				lagMetaPatchCtx, lagMetaPatchCancel := context.WithTimeout(cntxt, contextTimeout)
+				defer lagMetaPatchCancel()
+				err := r.Patch(lagMetaPatchCtx, lagMeta.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+				if err != nil {
+					logger.Error(fmt.Errorf("{patch lagMeta.Spec.ID} %s", err), "")
+					return u.patchLAGStatus(lagCR, "Failure", err.Error())
+				}
+				debugLogger.Info("Imported yaml mode. ID patched")
+				logger.Info("LAG imported")
+				return ctrl.Result{RequeueAfter: requeueInterval}, nil
+			}
+			logger.Info("LAG not found for import")
+			debugLogger.Info("Imported yaml mode. Aggregated port not found")
+		}
+
+		logger.Info("Creating LAG")
+		if _, err, errMsg := r.createLAG(lagMeta); err != nil {
+			logger.Error(fmt.Errorf("{createLAG} %s", err), "")
+			return u.patchLAGStatus(lagCR, "Failure", errMsg.Error())
+		}
+		logger.Info("LAG Created")
+	} else {
+		if apiLAG, ok := r.NStorage.LAGStorage.FindByID(lagMeta.Spec.ID); ok {
+			debugLogger.Info("Comparing LAGMeta with Netris aggregated port")
+			lagCR.Status.AggregatedPort = apiLAG.Name
+
+			if ok := compareLAGMetaAPI(lagMeta, apiLAG, u); ok {
+				debugLogger.Info("Nothing Changed")
+			} else {
+				debugLogger.Info("Go to update LAG in Netris")
+				logger.Info("Updating LAG")
+				lagUpdate := LAGMetaToNetris(lagMeta)
+
+				js, _ := json.Marshal(lagUpdate)
+				debugLogger.Info("lagUpdate", "payload", string(js))
+
+				_, err, errMsg := updateLAG(lagUpdate, lagRemovedMembers(lagMeta, apiLAG), r.Cred)
+				if err != nil {
+					logger.Error(fmt.Errorf("{updateLAG} %s", err), "")
+					return u.patchLAGStatus(lagCR, "Failure", errMsg.Error())
+				}
+				logger.Info("LAG Updated")
+			}
+		} else {
+			debugLogger.Info("LAG not found in Netris")
+			debugLogger.Info("Going to create LAG")
+			logger.Info("Creating LAG")
+			lagMeta.Spec.ID = 0
+			if _, err, errMsg := r.createLAG(lagMeta); err != nil {
+				logger.Error(fmt.Errorf("{createLAG} %s", err), "")
+				return u.patchLAGStatus(lagCR, "Failure", errMsg.Error())
+			}
+			logger.Info("LAG Created")
+		}
+	}
+
+	return u.patchLAGStatus(lagCR, provisionState, "Success")
+}
+
+func (r *LAGMetaReconciler) createLAG(lagMeta *k8sv1alpha1.LAGMeta) (ctrl.Result, error, error) {
+	debugLogger := r.Log.WithValues(
+		"name", fmt.Sprintf("%s/%s", lagMeta.Namespace, lagMeta.Spec.LAGName),
+		"lagName", lagMeta.Spec.LAGCRGeneration,
+	).V(int(zapcore.WarnLevel))
+
+	lagAdd := LAGMetaToNetris(lagMeta)
+
+	js, _ := json.Marshal(lagAdd)
+	debugLogger.Info("lagToAdd", "payload", string(js))
+
+	reply, err := r.Cred.Port().AddToLAG(lagAdd)
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+
+	data, err := reply.Parse()
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+
+	if reply.StatusCode != 200 {
+		return ctrl.Result{}, fmt.Errorf(data.Message), fmt.Errorf(data.Message)
+	}
+
+	// The API does not return the ID of the aggregated port, so look it up by its members.
+	if err := r.NStorage.LAGStorage.Download(); err != nil {
+		return ctrl.Result{}, err, err
+	}
+	apiLAG, ok := r.NStorage.LAGStorage.FindByMembers(lagMetaPortIDs(lagMeta))
+	if !ok {
+		err := fmt.Errorf("aggregated port not found after creation")
+		return ctrl.Result{}, err, err
+	}
+
+	debugLogger.Info("LAG Created", "id", apiLAG.ID)
+
+	lagMeta.Spec.ID = apiLAG.ID
+
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	err = r.Patch(ctx, lagMeta.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+
+	debugLogger.Info("ID patched to meta", "id", apiLAG.ID)
+	return ctrl.Result{}, nil, nil
+}
+
+func updateLAG(lagW *port.PortLAG, removed []*port.IDName, cred *api.Clientset) (ctrl.Result, error, error) {
+	if len(removed) > 0 {
+		reply, err := cred.Port().FreeUP(removed)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("{updateLAG} %s", err), err
+		}
+		resp, err := http.ParseAPIResponse(reply.Data)
+		if err != nil {
+			return ctrl.Result{}, err, err
+		}
+		if !resp.IsSuccess {
+			return ctrl.Result{}, fmt.Errorf("{updateLAG} %s", fmt.Errorf(resp.Message)), fmt.Errorf(resp.Message)
+		}
+	}
+
+	reply, err := cred.Port().AddToLAG(lagW)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("{updateLAG} %s", err), err
+	}
+	resp, err := http.ParseAPIResponse(reply.Data)
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+	if !resp.IsSuccess {
+		return ctrl.Result{}, fmt.Errorf("{updateLAG} %s", fmt.Errorf(resp.Message)), fmt.Errorf(resp.Message)
+	}
+
+	return ctrl.Result{}, nil, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LAGMetaReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&k8sv1alpha1.LAGMeta{}).
+		Complete(r)
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-logr/logr"
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/netrisstorage"
+	"github.com/netrisai/netriswebapi/http"
+	api "github.com/netrisai/netriswebapi/v2"
+)
+
+// NetrisObjectReconciler reconciles a NetrisObject object
+type NetrisObjectReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Cred     *api.Clientset
+	NStorage *netrisstorage.Storage
+}
+
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=netrisobjects,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=netrisobjects/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=netrisobjects/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop
+func (r *NetrisObjectReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("name", req.NamespacedName)
+	debugLogger := logger.V(int(zapcore.WarnLevel))
+	netrisObject := &k8sv1alpha1.NetrisObject{}
+
+	u := uniReconciler{
+		Client:      r.Client,
+		Logger:      logger,
+		DebugLogger: debugLogger,
+		Cred:        r.Cred,
+		NStorage:    r.NStorage,
+	}
+
+	netrisObjectCtx, netrisObjectCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer netrisObjectCancel()
+	if err := r.Get(netrisObjectCtx, req.NamespacedName, netrisObject); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	netrisObjectMetaNamespaced := req.NamespacedName
+	netrisObjectMetaNamespaced.Name = string(netrisObject.GetUID())
+	netrisObjectMeta := &k8sv1alpha1.NetrisObjectMeta{}
+	metaFound := true
+
+	netrisObjectMetaCtx, netrisObjectMetaCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer netrisObjectMetaCancel()
+	if err := r.Get(netrisObjectMetaCtx, netrisObjectMetaNamespaced, netrisObjectMeta); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			metaFound = false
+			netrisObjectMeta = nil
+		} else {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if netrisObject.DeletionTimestamp != nil {
+		logger.Info("Go to delete")
+		_, err := r.deleteNetrisObject(netrisObject, netrisObjectMeta)
+		if err != nil {
+			logger.Error(fmt.Errorf("{deleteNetrisObject} %s", err), "")
+			return u.patchNetrisObjectStatus(netrisObject, "Failure", err.Error())
+		}
+		logger.Info("NetrisObject deleted")
+		return ctrl.Result{}, nil
+	}
+
+	if netrisObjectMustUpdateAnnotations(netrisObject) {
+		debugLogger.Info("Setting default annotations")
+		netrisObjectUpdateDefaultAnnotations(netrisObject)
+		netrisObjectPatchCtx, netrisObjectPatchCancel := context.WithTimeout(cntxt, contextTimeout)
+		defer netrisObjectPatchCancel()
+		err := r.Patch(netrisObjectPatchCtx, netrisObject.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+		if err != nil {
+			logger.Error(fmt.Errorf("{Patch NetrisObject default annotations} %s", err), "")
+			return ctrl.Result{RequeueAfter: requeueInterval}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if metaFound {
+		debugLogger.Info("Meta found")
+		if netrisObjectCompareFieldsForNewMeta(netrisObject, netrisObjectMeta) {
+			debugLogger.Info("Generating New Meta")
+			netrisObjectID := netrisObjectMeta.Spec.ID
+			newNetrisObjectMeta, err := r.NetrisObjectToMeta(netrisObject)
+			if err != nil {
+				logger.Error(fmt.Errorf("{NetrisObjectToMeta} %s", err), "")
+				return u.patchNetrisObjectStatus(netrisObject, "Failure", err.Error())
+			}
+			netrisObjectMeta.Spec = newNetrisObjectMeta.DeepCopy().Spec
+			netrisObjectMeta.Spec.ID = netrisObjectID
+			netrisObjectMeta.Spec.NetrisObjectCRGeneration = netrisObject.GetGeneration()
+
+			netrisObjectMetaUpdateCtx, netrisObjectMetaUpdateCancel := context.WithTimeout(cntxt, contextTimeout)
+			defer netrisObjectMetaUpdateCancel()
+			err = r.Update(netrisObjectMetaUpdateCtx, netrisObjectMeta.DeepCopyObject(), &client.UpdateOptions{})
+			if err != nil {
+				logger.Error(fmt.Errorf("{netrisObjectMeta Update} %s", err), "")
+				return ctrl.Result{RequeueAfter: requeueInterval}, nil
+			}
+		}
+	} else {
+		debugLogger.Info("Meta not found")
+		if netrisObject.GetFinalizers() == nil {
+			netrisObject.SetFinalizers([]string{"resource.k8s.netris.ai/delete"})
+
+			netrisObjectPatchCtx, netrisObjectPatchCancel := context.WithTimeout(cntxt, contextTimeout)
+			defer netrisObjectPatchCancel()
+			err := r.Patch(netrisObjectPatchCtx, netrisObject.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+			if err != nil {
+				logger.Error(fmt.Errorf("{Patch NetrisObject Finalizer} %s", err), "")
+				return ctrl.Result{RequeueAfter: requeueInterval}, nil
+			}
+			return ctrl.Result{}, nil
+		}
+
+		netrisObjectMeta, err := r.NetrisObjectToMeta(netrisObject)
+		if err != nil {
+			logger.Error(fmt.Errorf("{NetrisObjectToMeta} %s", err), "")
+			return u.patchNetrisObjectStatus(netrisObject, "Failure", err.Error())
+		}
+
+		netrisObjectMeta.Spec.NetrisObjectCRGeneration = netrisObject.GetGeneration()
+
+		netrisObjectMetaCreateCtx, netrisObjectMetaCreateCancel := context.WithTimeout(cntxt, contextTimeout)
+		defer netrisObjectMetaCreateCancel()
+		if err := r.Create(netrisObjectMetaCreateCtx, netrisObjectMeta.DeepCopyObject(), &client.CreateOptions{}); err != nil {
+			logger.Error(fmt.Errorf("{netrisObjectMeta Create} %s", err), "")
+			return ctrl.Result{RequeueAfter: requeueInterval}, nil
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: requeueInterval}, nil
+}
+
+func (r *NetrisObjectReconciler) deleteNetrisObject(netrisObject *k8sv1alpha1.NetrisObject, netrisObjectMeta *k8sv1alpha1.NetrisObjectMeta) (ctrl.Result, error) {
+	if netrisObjectMeta != nil && netrisObjectMeta.Spec.ID > 0 && !netrisObjectMeta.Spec.Reclaim {
+		reply, err := r.Cred.Client.Delete(netrisObjectAddress(r.Cred, netrisObjectMeta.Spec.Path, netrisObjectMeta.Spec.ID), nil)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("{deleteNetrisObject} %s", err)
+		}
+		resp, err := http.ParseAPIResponse(reply.Data)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !resp.IsSuccess && resp.Meta.StatusCode != 404 {
+			return ctrl.Result{}, fmt.Errorf("{deleteNetrisObject} %s", fmt.Errorf(resp.Message))
+		}
+	}
+	return r.deleteCRs(netrisObject, netrisObjectMeta)
+}
+
+func (r *NetrisObjectReconciler) deleteCRs(netrisObject *k8sv1alpha1.NetrisObject, netrisObjectMeta *k8sv1alpha1.NetrisObjectMeta) (ctrl.Result, error) {
+	if netrisObjectMeta != nil {
+		_, err := r.deleteNetrisObjectMetaCR(netrisObjectMeta)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("{deleteCRs} %s", err)
+		}
+	}
+
+	return r.deleteNetrisObjectCR(netrisObject)
+}
+
+func (r *NetrisObjectReconciler) deleteNetrisObjectCR(netrisObject *k8sv1alpha1.NetrisObject) (ctrl.Result, error) {
+	netrisObject.ObjectMeta.SetFinalizers(nil)
+	netrisObject.SetFinalizers(nil)
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	if err := r.Update(ctx, netrisObject.DeepCopyObject(), &client.UpdateOptions{}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("{deleteNetrisObjectCR} %s", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *NetrisObjectReconciler) deleteNetrisObjectMetaCR(netrisObjectMeta *k8sv1alpha1.NetrisObjectMeta) (ctrl.Result, error) {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	if err := r.Delete(ctx, netrisObjectMeta.DeepCopyObject(), &client.DeleteOptions{}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("{deleteNetrisObjectMetaCR} %s", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NetrisObjectReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&k8sv1alpha1.NetrisObject{}).
+		Complete(r)
+}
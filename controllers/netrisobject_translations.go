@@ -0,0 +1,223 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	api "github.com/netrisai/netriswebapi/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NetrisObjectToMeta converts the NetrisObject resource to Meta type.
+func (r *NetrisObjectReconciler) NetrisObjectToMeta(netrisObject *k8sv1alpha1.NetrisObject) (*k8sv1alpha1.NetrisObjectMeta, error) {
+	var (
+		imported    = false
+		reclaim     = false
+		identityKey = "name"
+	)
+
+	if i, ok := netrisObject.GetAnnotations()["resource.k8s.netris.ai/import"]; ok && i == "true" {
+		imported = true
+	}
+	if i, ok := netrisObject.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
+		reclaim = true
+	}
+
+	if netrisObject.Spec.IdentityKey != "" {
+		identityKey = netrisObject.Spec.IdentityKey
+	}
+
+	body, err := netrisObjectBody(netrisObject.Spec.Body)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := body[identityKey]; !ok {
+		return nil, fmt.Errorf("body doesn't contain identity key '%s'", identityKey)
+	}
+
+	netrisObjectMeta := &k8sv1alpha1.NetrisObjectMeta{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      string(netrisObject.GetUID()),
+			Namespace: netrisObject.GetNamespace(),
+		},
+		TypeMeta: metav1.TypeMeta{},
+		Spec: k8sv1alpha1.NetrisObjectMetaSpec{
+			Imported:         imported,
+			Reclaim:          reclaim,
+			NetrisObjectName: netrisObject.Name,
+			Path:             strings.TrimSuffix(netrisObject.Spec.Path, "/"),
+			IdentityKey:      identityKey,
+			Body:             *netrisObject.Spec.Body.DeepCopy(),
+		},
+	}
+
+	return netrisObjectMeta, nil
+}
+
+func netrisObjectCompareFieldsForNewMeta(netrisObject *k8sv1alpha1.NetrisObject, netrisObjectMeta *k8sv1alpha1.NetrisObjectMeta) bool {
+	imported := false
+	reclaim := false
+	if i, ok := netrisObject.GetAnnotations()["resource.k8s.netris.ai/import"]; ok && i == "true" {
+		imported = true
+	}
+	if i, ok := netrisObject.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
+		reclaim = true
+	}
+	return netrisObject.GetGeneration() != netrisObjectMeta.Spec.NetrisObjectCRGeneration || imported != netrisObjectMeta.Spec.Imported || reclaim != netrisObjectMeta.Spec.Reclaim
+}
+
+func netrisObjectMustUpdateAnnotations(netrisObject *k8sv1alpha1.NetrisObject) bool {
+	update := false
+	if i, ok := netrisObject.GetAnnotations()["resource.k8s.netris.ai/import"]; !(ok && (i == "true" || i == "false")) {
+		update = true
+	}
+	if i, ok := netrisObject.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; !(ok && (i == "retain" || i == "delete")) {
+		update = true
+	}
+	return update
+}
+
+func netrisObjectUpdateDefaultAnnotations(netrisObject *k8sv1alpha1.NetrisObject) {
+	imported := "false"
+	reclaim := "delete"
+	if i, ok := netrisObject.GetAnnotations()["resource.k8s.netris.ai/import"]; ok && i == "true" {
+		imported = "true"
+	}
+	if i, ok := netrisObject.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
+		reclaim = "retain"
+	}
+	annotations := netrisObject.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations["resource.k8s.netris.ai/import"] = imported
+	annotations["resource.k8s.netris.ai/reclaimPolicy"] = reclaim
+	netrisObject.SetAnnotations(annotations)
+}
+
+func netrisObjectBody(raw runtime.RawExtension) (map[string]interface{}, error) {
+	body := map[string]interface{}{}
+	if len(raw.Raw) == 0 {
+		return body, nil
+	}
+	if err := json.Unmarshal(raw.Raw, &body); err != nil {
+		return nil, fmt.Errorf("invalid body: %s", err)
+	}
+	return body, nil
+}
+
+func netrisObjectAddress(cred *api.Clientset, path string, id int) string {
+	address := cred.Client.URL.String() + path
+	if id > 0 {
+		address += "/" + strconv.Itoa(id)
+	}
+	return address
+}
+
+// getNetrisObjects returns the items of the API collection.
+func getNetrisObjects(cred *api.Clientset, path string) ([]map[string]interface{}, error) {
+	APIResult, err := cred.Client.Get(netrisObjectAddress(cred, path, 0))
+	if err != nil {
+		return nil, fmt.Errorf("{getNetrisObjects} %s", err)
+	}
+	items := []map[string]interface{}{}
+	js, err := json.Marshal(APIResult.Data)
+	if err != nil {
+		return nil, fmt.Errorf("{getNetrisObjects} %s", err)
+	}
+	if err := json.Unmarshal(js, &items); err != nil {
+		return nil, fmt.Errorf("{getNetrisObjects} path %s doesn't return a list: %s", path, err)
+	}
+	return items, nil
+}
+
+func netrisObjectID(item map[string]interface{}) int {
+	if id, ok := item["id"].(float64); ok {
+		return int(id)
+	}
+	return 0
+}
+
+// findNetrisObject finds the object by ID, or by identity key when ID is zero.
+func findNetrisObject(netrisObjectMeta *k8sv1alpha1.NetrisObjectMeta, items []map[string]interface{}) (map[string]interface{}, bool) {
+	body, err := netrisObjectBody(netrisObjectMeta.Spec.Body)
+	if err != nil {
+		return nil, false
+	}
+	for _, item := range items {
+		if netrisObjectMeta.Spec.ID > 0 {
+			if netrisObjectID(item) == netrisObjectMeta.Spec.ID {
+				return item, true
+			}
+			continue
+		}
+		if fmt.Sprint(item[netrisObjectMeta.Spec.IdentityKey]) == fmt.Sprint(body[netrisObjectMeta.Spec.IdentityKey]) {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+// compareNetrisObjectMetaAPI detects drift of the fields set in the body. Fields that are only present in the API are ignored.
+func compareNetrisObjectMetaAPI(netrisObjectMeta *k8sv1alpha1.NetrisObjectMeta, apiObject map[string]interface{}, u uniReconciler) bool {
+	body, err := netrisObjectBody(netrisObjectMeta.Spec.Body)
+	if err != nil {
+		return true
+	}
+	for key, value := range body {
+		if !netrisObjectValueEqual(value, apiObject[key]) {
+			u.DebugLogger.Info("Field changed", "field", key, "netrisValue", apiObject[key], "k8sValue", value)
+			return false
+		}
+	}
+	return true
+}
+
+func netrisObjectValueEqual(k8sValue, apiValue interface{}) bool {
+	switch v := k8sValue.(type) {
+	case map[string]interface{}:
+		apiMap, ok := apiValue.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range v {
+			if !netrisObjectValueEqual(value, apiMap[key]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		apiList, ok := apiValue.([]interface{})
+		if !ok || len(apiList) != len(v) {
+			return false
+		}
+		for i := range v {
+			if !netrisObjectValueEqual(v[i], apiList[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(k8sValue, apiValue)
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-logr/logr"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/netrisstorage"
+	"github.com/netrisai/netriswebapi/http"
+	api "github.com/netrisai/netriswebapi/v2"
+)
+
+// NetrisObjectMetaReconciler reconciles a NetrisObjectMeta object
+type NetrisObjectMetaReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Cred     *api.Clientset
+	NStorage *netrisstorage.Storage
+}
+
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=netrisobjectmeta,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=netrisobjectmeta/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=netrisobjectmeta/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop
+func (r *NetrisObjectMetaReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	debugLogger := r.Log.WithValues("name", req.NamespacedName).V(int(zapcore.WarnLevel))
+
+	netrisObjectMeta := &k8sv1alpha1.NetrisObjectMeta{}
+	netrisObjectCR := &k8sv1alpha1.NetrisObject{}
+	netrisObjectMetaCtx, netrisObjectMetaCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer netrisObjectMetaCancel()
+	if err := r.Get(netrisObjectMetaCtx, req.NamespacedName, netrisObjectMeta); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	logger := r.Log.WithValues("name", fmt.Sprintf("%s/%s", req.NamespacedName.Namespace, netrisObjectMeta.Spec.NetrisObjectName))
+	debugLogger = logger.V(int(zapcore.WarnLevel))
+
+	u := uniReconciler{
+		Client:      r.Client,
+		Logger:      logger,
+		DebugLogger: debugLogger,
+		Cred:        r.Cred,
+		NStorage:    r.NStorage,
+	}
+
+	provisionState := "OK"
+
+	netrisObjectNN := req.NamespacedName
+	netrisObjectNN.Name = netrisObjectMeta.Spec.NetrisObjectName
+	netrisObjectNNCtx, netrisObjectNNCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer netrisObjectNNCancel()
+	if err := r.Get(netrisObjectNNCtx, netrisObjectNN, netrisObjectCR); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if netrisObjectMeta.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	items, err := getNetrisObjects(r.Cred, netrisObjectMeta.Spec.Path)
+	if err != nil {
+		logger.Error(fmt.Errorf("{getNetrisObjects} %s", err), "")
+		return u.patchNetrisObjectStatus(netrisObjectCR, "Failure", err.Error())
+	}
+
+	if netrisObjectMeta.Spec.ID == 0 {
+		debugLogger.Info("ID Not found in meta")
+		if netrisObjectMeta.Spec.Imported {
+			logger.Info("Importing NetrisObject")
+			debugLogger.Info("Imported yaml mode. Finding NetrisObject by identity key", "identityKey", netrisObjectMeta.Spec.IdentityKey)
+			if apiObject, ok := findNetrisObject(netrisObjectMeta, items); ok {
+				debugLogger.Info("Imported yaml mode. NetrisObject found")
+				netrisObjectMeta.Spec.ID = netrisObjectID(apiObject)
+
+				netrisObjectMetaPatchCtx, netrisObjectMetaPatchCancel := context.WithTimeout(cntxt, contextTimeout)
+				defer netrisObjectMetaPatchCancel()
+				err := r.Patch(netrisObjectMetaPatchCtx, netrisObjectMeta.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+				if err != nil {
+					logger.Error(fmt.Errorf("{patch netrisObjectMeta.Spec.ID} %s", err), "")
+					return u.patchNetrisObjectStatus(netrisObjectCR, "Failure", err.Error())
+				}
+				debugLogger.Info("Imported yaml mode. ID patched")
+				logger.Info("NetrisObject imported")
+				return ctrl.Result{RequeueAfter: requeueInterval}, nil
+			}
+			logger.Info("NetrisObject not found for import")
+			debugLogger.Info("Imported yaml mode. NetrisObject not found")
+		}
+
+		logger.Info("Creating NetrisObject")
+		if _, err, errMsg := r.createNetrisObject(netrisObjectMeta); err != nil {
+			logger.Error(fmt.Errorf("{createNetrisObject} %s", err), "")
+			return u.patchNetrisObjectStatus(netrisObjectCR, "Failure", errMsg.Error())
+		}
+		logger.Info("NetrisObject Created")
+	} else {
+		if apiObject, ok := findNetrisObject(netrisObjectMeta, items); ok {
+			debugLogger.Info("Comparing NetrisObjectMeta with Netris object")
+
+			if ok := compareNetrisObjectMetaAPI(netrisObjectMeta, apiObject, u); ok {
+				debugLogger.Info("Nothing Changed")
+			} else {
+				debugLogger.Info("Go to update NetrisObject in Netris")
+				logger.Info("Updating NetrisObject")
+				debugLogger.Info("netrisObjectUpdate", "payload", string(netrisObjectMeta.Spec.Body.Raw))
+
+				_, err, errMsg := updateNetrisObject(netrisObjectMeta, r.Cred)
+				if err != nil {
+					logger.Error(fmt.Errorf("{updateNetrisObject} %s", err), "")
+					return u.patchNetrisObjectStatus(netrisObjectCR, "Failure", errMsg.Error())
+				}
+				logger.Info("NetrisObject Updated")
+			}
+		} else {
+			debugLogger.Info("NetrisObject not found in Netris")
+			debugLogger.Info("Going to create NetrisObject")
+			logger.Info("Creating NetrisObject")
+			if _, err, errMsg := r.createNetrisObject(netrisObjectMeta); err != nil {
+				logger.Error(fmt.Errorf("{createNetrisObject} %s", err), "")
+				return u.patchNetrisObjectStatus(netrisObjectCR, "Failure", errMsg.Error())
+			}
+			logger.Info("NetrisObject Created")
+		}
+	}
+
+	return u.patchNetrisObjectStatus(netrisObjectCR, provisionState, "Success")
+}
+
+func (r *NetrisObjectMetaReconciler) createNetrisObject(netrisObjectMeta *k8sv1alpha1.NetrisObjectMeta) (ctrl.Result, error, error) {
+	debugLogger := r.Log.WithValues(
+		"name", fmt.Sprintf("%s/%s", netrisObjectMeta.Namespace, netrisObjectMeta.Spec.NetrisObjectName),
+		"netrisObjectName", netrisObjectMeta.Spec.NetrisObjectCRGeneration,
+	).V(int(zapcore.WarnLevel))
+
+	debugLogger.Info("netrisObjectToAdd", "path", netrisObjectMeta.Spec.Path, "payload", string(netrisObjectMeta.Spec.Body.Raw))
+
+	reply, err := r.Cred.Client.Post(netrisObjectAddress(r.Cred, netrisObjectMeta.Spec.Path, 0), netrisObjectMeta.Spec.Body.Raw)
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+
+	data, err := reply.Parse()
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+
+	if reply.StatusCode != 200 {
+		return ctrl.Result{}, fmt.Errorf(data.Message), fmt.Errorf(data.Message)
+	}
+
+	id := 0
+	if d, ok := data.Data.(map[string]interface{}); ok {
+		id = netrisObjectID(d)
+	}
+
+	if id == 0 {
+		netrisObjectMeta.Spec.ID = 0
+		items, err := getNetrisObjects(r.Cred, netrisObjectMeta.Spec.Path)
+		if err != nil {
+			return ctrl.Result{}, err, err
+		}
+		apiObject, ok := findNetrisObject(netrisObjectMeta, items)
+		if !ok {
+			err := fmt.Errorf("object not found after creation")
+			return ctrl.Result{}, err, err
+		}
+		id = netrisObjectID(apiObject)
+	}
+
+	debugLogger.Info("NetrisObject Created", "id", id)
+
+	netrisObjectMeta.Spec.ID = id
+
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	err = r.Patch(ctx, netrisObjectMeta.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+
+	debugLogger.Info("ID patched to meta", "id", id)
+	return ctrl.Result{}, nil, nil
+}
+
+func updateNetrisObject(netrisObjectMeta *k8sv1alpha1.NetrisObjectMeta, cred *api.Clientset) (ctrl.Result, error, error) {
+	reply, err := cred.Client.Put(netrisObjectAddress(cred, netrisObjectMeta.Spec.Path, netrisObjectMeta.Spec.ID), netrisObjectMeta.Spec.Body.Raw)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("{updateNetrisObject} %s", err), err
+	}
+	resp, err := http.ParseAPIResponse(reply.Data)
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+	if !resp.IsSuccess {
+		return ctrl.Result{}, fmt.Errorf("{updateNetrisObject} %s", fmt.Errorf(resp.Message)), fmt.Errorf(resp.Message)
+	}
+
+	return ctrl.Result{}, nil, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NetrisObjectMetaReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&k8sv1alpha1.NetrisObjectMeta{}).
+		Complete(r)
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-logr/logr"
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/netrisstorage"
+	"github.com/netrisai/netriswebapi/http"
+	api "github.com/netrisai/netriswebapi/v2"
+)
+
+// RouteMapReconciler reconciles a RouteMap object
+type RouteMapReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Cred     *api.Clientset
+	NStorage *netrisstorage.Storage
+}
+
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=routemaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=routemaps/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=routemaps/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop
+func (r *RouteMapReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("name", req.NamespacedName)
+	debugLogger := logger.V(int(zapcore.WarnLevel))
+	routeMap := &k8sv1alpha1.RouteMap{}
+
+	u := uniReconciler{
+		Client:      r.Client,
+		Logger:      logger,
+		DebugLogger: debugLogger,
+		Cred:        r.Cred,
+		NStorage:    r.NStorage,
+	}
+
+	routeMapCtx, routeMapCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer routeMapCancel()
+	if err := r.Get(routeMapCtx, req.NamespacedName, routeMap); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	routeMapMetaNamespaced := req.NamespacedName
+	routeMapMetaNamespaced.Name = string(routeMap.GetUID())
+	routeMapMeta := &k8sv1alpha1.RouteMapMeta{}
+	metaFound := true
+
+	routeMapMetaCtx, routeMapMetaCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer routeMapMetaCancel()
+	if err := r.Get(routeMapMetaCtx, routeMapMetaNamespaced, routeMapMeta); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			metaFound = false
+			routeMapMeta = nil
+		} else {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if routeMap.DeletionTimestamp != nil {
+		logger.Info("Go to delete")
+		_, err := r.deleteRouteMap(routeMap, routeMapMeta)
+		if err != nil {
+			logger.Error(fmt.Errorf("{deleteRouteMap} %s", err), "")
+			return u.patchRouteMapStatus(routeMap, "Failure", err.Error())
+		}
+		logger.Info("RouteMap deleted")
+		return ctrl.Result{}, nil
+	}
+
+	if routeMapMustUpdateAnnotations(routeMap) {
+		debugLogger.Info("Setting default annotations")
+		routeMapUpdateDefaultAnnotations(routeMap)
+		routeMapPatchCtx, routeMapPatchCancel := context.WithTimeout(cntxt, contextTimeout)
+		defer routeMapPatchCancel()
+		err := r.Patch(routeMapPatchCtx, routeMap.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+		if err != nil {
+			logger.Error(fmt.Errorf("{Patch RouteMap default annotations} %s", err), "")
+			return ctrl.Result{RequeueAfter: requeueInterval}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if metaFound {
+		debugLogger.Info("Meta found")
+		if routeMapCompareFieldsForNewMeta(routeMap, routeMapMeta) {
+			debugLogger.Info("Generating New Meta")
+			routeMapID := routeMapMeta.Spec.ID
+			newRouteMapMeta, err := r.RouteMapToMeta(routeMap)
+			if err != nil {
+				logger.Error(fmt.Errorf("{RouteMapToMeta} %s", err), "")
+				return u.patchRouteMapStatus(routeMap, "Failure", err.Error())
+			}
+			routeMapMeta.Spec = newRouteMapMeta.DeepCopy().Spec
+			routeMapMeta.Spec.ID = routeMapID
+			routeMapMeta.Spec.RouteMapCRGeneration = routeMap.GetGeneration()
+
+			routeMapMetaUpdateCtx, routeMapMetaUpdateCancel := context.WithTimeout(cntxt, contextTimeout)
+			defer routeMapMetaUpdateCancel()
+			err = r.Update(routeMapMetaUpdateCtx, routeMapMeta.DeepCopyObject(), &client.UpdateOptions{})
+			if err != nil {
+				logger.Error(fmt.Errorf("{routeMapMeta Update} %s", err), "")
+				return ctrl.Result{RequeueAfter: requeueInterval}, nil
+			}
+		}
+	} else {
+		debugLogger.Info("Meta not found")
+		if routeMap.GetFinalizers() == nil {
+			routeMap.SetFinalizers([]string{"resource.k8s.netris.ai/delete"})
+
+			routeMapPatchCtx, routeMapPatchCancel := context.WithTimeout(cntxt, contextTimeout)
+			defer routeMapPatchCancel()
+			err := r.Patch(routeMapPatchCtx, routeMap.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+			if err != nil {
+				logger.Error(fmt.Errorf("{Patch RouteMap Finalizer} %s", err), "")
+				return ctrl.Result{RequeueAfter: requeueInterval}, nil
+			}
+			return ctrl.Result{}, nil
+		}
+
+		routeMapMeta, err := r.RouteMapToMeta(routeMap)
+		if err != nil {
+			logger.Error(fmt.Errorf("{RouteMapToMeta} %s", err), "")
+			return u.patchRouteMapStatus(routeMap, "Failure", err.Error())
+		}
+
+		routeMapMeta.Spec.RouteMapCRGeneration = routeMap.GetGeneration()
+
+		routeMapMetaCreateCtx, routeMapMetaCreateCancel := context.WithTimeout(cntxt, contextTimeout)
+		defer routeMapMetaCreateCancel()
+		if err := r.Create(routeMapMetaCreateCtx, routeMapMeta.DeepCopyObject(), &client.CreateOptions{}); err != nil {
+			logger.Error(fmt.Errorf("{routeMapMeta Create} %s", err), "")
+			return ctrl.Result{RequeueAfter: requeueInterval}, nil
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: requeueInterval}, nil
+}
+
+func (r *RouteMapReconciler) deleteRouteMap(routeMap *k8sv1alpha1.RouteMap, routeMapMeta *k8sv1alpha1.RouteMapMeta) (ctrl.Result, error) {
+	refs, err := routeMapReferences(r.Client, routeMap)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("{deleteRouteMap} %s", err)
+	}
+	if len(refs) > 0 {
+		return ctrl.Result{}, fmt.Errorf("RouteMap is referenced by BGP %s", strings.Join(refs, ", "))
+	}
+	if routeMapMeta != nil && routeMapMeta.Spec.ID > 0 && !routeMapMeta.Spec.Reclaim {
+		reply, err := r.Cred.RouteMap().Delete(routeMapMeta.Spec.ID)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("{deleteRouteMap} %s", err)
+		}
+		resp, err := http.ParseAPIResponse(reply.Data)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !resp.IsSuccess && resp.Meta.StatusCode != 404 {
+			return ctrl.Result{}, fmt.Errorf("{deleteRouteMap} %s", fmt.Errorf(resp.Message))
+		}
+	}
+	return r.deleteCRs(routeMap, routeMapMeta)
+}
+
+func (r *RouteMapReconciler) deleteCRs(routeMap *k8sv1alpha1.RouteMap, routeMapMeta *k8sv1alpha1.RouteMapMeta) (ctrl.Result, error) {
+	if routeMapMeta != nil {
+		_, err := r.deleteRouteMapMetaCR(routeMapMeta)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("{deleteCRs} %s", err)
+		}
+	}
+
+	return r.deleteRouteMapCR(routeMap)
+}
+
+func (r *RouteMapReconciler) deleteRouteMapCR(routeMap *k8sv1alpha1.RouteMap) (ctrl.Result, error) {
+	routeMap.ObjectMeta.SetFinalizers(nil)
+	routeMap.SetFinalizers(nil)
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	if err := r.Update(ctx, routeMap.DeepCopyObject(), &client.UpdateOptions{}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("{deleteRouteMapCR} %s", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *RouteMapReconciler) deleteRouteMapMetaCR(routeMapMeta *k8sv1alpha1.RouteMapMeta) (ctrl.Result, error) {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	if err := r.Delete(ctx, routeMapMeta.DeepCopyObject(), &client.DeleteOptions{}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("{deleteRouteMapMetaCR} %s", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RouteMapReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&k8sv1alpha1.RouteMap{}).
+		Complete(r)
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/netrisstorage"
+	"github.com/netrisai/netriswebapi/v1/types/routemap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RouteMapToMeta converts the RouteMap resource to Meta type.
+func (r *RouteMapReconciler) RouteMapToMeta(routeMap *k8sv1alpha1.RouteMap) (*k8sv1alpha1.RouteMapMeta, error) {
+	var (
+		imported = false
+		reclaim  = false
+	)
+
+	if i, ok := routeMap.GetAnnotations()["resource.k8s.netris.ai/import"]; ok && i == "true" {
+		imported = true
+	}
+	if i, ok := routeMap.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
+		reclaim = true
+	}
+
+	sequences := []k8sv1alpha1.RouteMapMetaSequence{}
+	for _, sequence := range routeMap.Spec.Sequences {
+		matches := []k8sv1alpha1.RouteMapMetaMatch{}
+		for _, match := range sequence.Matches {
+			metaMatch := k8sv1alpha1.RouteMapMetaMatch{
+				Type:  match.Type,
+				Value: match.Value,
+			}
+			if match.BGPObject != "" {
+				bgpObject, err := getBGPObject(r.Client, r.NStorage, routeMap.GetNamespace(), match.BGPObject)
+				if err != nil {
+					return nil, err
+				}
+				metaMatch.BGPObjectID = bgpObject.ID
+				metaMatch.BGPObjectType = bgpObject.Type
+			}
+			matches = append(matches, metaMatch)
+		}
+
+		actions := []k8sv1alpha1.RouteMapAction{}
+		actions = append(actions, sequence.Actions...)
+
+		sequences = append(sequences, k8sv1alpha1.RouteMapMetaSequence{
+			Number:      sequence.Number,
+			Policy:      sequence.Policy,
+			Description: sequence.Description,
+			Matches:     matches,
+			Actions:     actions,
+		})
+	}
+
+	routeMapMeta := &k8sv1alpha1.RouteMapMeta{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      string(routeMap.GetUID()),
+			Namespace: routeMap.GetNamespace(),
+		},
+		TypeMeta: metav1.TypeMeta{},
+		Spec: k8sv1alpha1.RouteMapMetaSpec{
+			Imported:     imported,
+			Reclaim:      reclaim,
+			RouteMapName: routeMap.Name,
+			Sequences:    sequences,
+		},
+	}
+
+	return routeMapMeta, nil
+}
+
+func routeMapCompareFieldsForNewMeta(routeMap *k8sv1alpha1.RouteMap, routeMapMeta *k8sv1alpha1.RouteMapMeta) bool {
+	imported := false
+	reclaim := false
+	if i, ok := routeMap.GetAnnotations()["resource.k8s.netris.ai/import"]; ok && i == "true" {
+		imported = true
+	}
+	if i, ok := routeMap.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
+		reclaim = true
+	}
+	return routeMap.GetGeneration() != routeMapMeta.Spec.RouteMapCRGeneration || imported != routeMapMeta.Spec.Imported || reclaim != routeMapMeta.Spec.Reclaim
+}
+
+func routeMapMustUpdateAnnotations(routeMap *k8sv1alpha1.RouteMap) bool {
+	update := false
+	if i, ok := routeMap.GetAnnotations()["resource.k8s.netris.ai/import"]; !(ok && (i == "true" || i == "false")) {
+		update = true
+	}
+	if i, ok := routeMap.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; !(ok && (i == "retain" || i == "delete")) {
+		update = true
+	}
+	return update
+}
+
+func routeMapUpdateDefaultAnnotations(routeMap *k8sv1alpha1.RouteMap) {
+	imported := "false"
+	reclaim := "delete"
+	if i, ok := routeMap.GetAnnotations()["resource.k8s.netris.ai/import"]; ok && i == "true" {
+		imported = "true"
+	}
+	if i, ok := routeMap.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
+		reclaim = "retain"
+	}
+	annotations := routeMap.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations["resource.k8s.netris.ai/import"] = imported
+	annotations["resource.k8s.netris.ai/reclaimPolicy"] = reclaim
+	routeMap.SetAnnotations(annotations)
+}
+
+// RouteMapMetaToNetris converts Meta to Netris API type.
+func RouteMapMetaToNetris(routeMapMeta *k8sv1alpha1.RouteMapMeta) *routemap.RouteMap {
+	sequences := []routemap.Sequence{}
+	for _, sequence := range routeMapMeta.Spec.Sequences {
+		matches := []routemap.SequenceMatch{}
+		for _, match := range sequence.Matches {
+			apiMatch := routemap.SequenceMatch{
+				Type:           match.Type,
+				EbgpObjectType: match.BGPObjectType,
+			}
+			if match.BGPObjectID > 0 {
+				apiMatch.EbgpObject = match.BGPObjectID
+			}
+			if match.Value != "" {
+				value := match.Value
+				apiMatch.Value = &value
+			}
+			matches = append(matches, apiMatch)
+		}
+
+		actions := []routemap.SequenceAction{}
+		for _, action := range sequence.Actions {
+			actions = append(actions, routemap.SequenceAction{
+				Type:      action.Type,
+				Parameter: action.Parameter,
+				Value:     action.Value,
+			})
+		}
+
+		sequences = append(sequences, routemap.Sequence{
+			Number:      sequence.Number,
+			Policy:      sequence.Policy,
+			Description: sequence.Description,
+			Matches:     matches,
+			Actions:     actions,
+		})
+	}
+
+	return &routemap.RouteMap{
+		ID:        routeMapMeta.Spec.ID,
+		Name:      routeMapMeta.Spec.RouteMapName,
+		Sequences: sequences,
+	}
+}
+
+func compareRouteMapMetaAPI(routeMapMeta *k8sv1alpha1.RouteMapMeta, apiRouteMap *routemap.RouteMap, u uniReconciler) bool {
+	if apiRouteMap.Name != routeMapMeta.Spec.RouteMapName {
+		u.DebugLogger.Info("Name changed", "netrisValue", apiRouteMap.Name, "k8sValue", routeMapMeta.Spec.RouteMapName)
+		return false
+	}
+	if len(apiRouteMap.Sequences) != len(routeMapMeta.Spec.Sequences) {
+		u.DebugLogger.Info("Sequences count changed", "netrisValue", len(apiRouteMap.Sequences), "k8sValue", len(routeMapMeta.Spec.Sequences))
+		return false
+	}
+
+	apiSequences := make(map[int]routemap.Sequence)
+	for _, sequence := range apiRouteMap.Sequences {
+		apiSequences[sequence.Number] = sequence
+	}
+
+	for _, sequence := range routeMapMeta.Spec.Sequences {
+		apiSequence, ok := apiSequences[sequence.Number]
+		if !ok {
+			u.DebugLogger.Info("Sequence not found", "k8sValue", sequence.Number)
+			return false
+		}
+		if apiSequence.Policy != sequence.Policy {
+			u.DebugLogger.Info("Policy changed", "sequence", sequence.Number, "netrisValue", apiSequence.Policy, "k8sValue", sequence.Policy)
+			return false
+		}
+		if apiSequence.Description != sequence.Description {
+			u.DebugLogger.Info("Description changed", "sequence", sequence.Number, "netrisValue", apiSequence.Description, "k8sValue", sequence.Description)
+			return false
+		}
+		if len(apiSequence.Matches) != len(sequence.Matches) {
+			u.DebugLogger.Info("Matches count changed", "sequence", sequence.Number, "netrisValue", len(apiSequence.Matches), "k8sValue", len(sequence.Matches))
+			return false
+		}
+		for i, match := range sequence.Matches {
+			apiMatch := apiSequence.Matches[i]
+			apiValue := ""
+			if apiMatch.Value != nil {
+				apiValue = *apiMatch.Value
+			}
+			if apiMatch.Type != match.Type || apiValue != match.Value || routeMapEbgpObjectID(apiMatch.EbgpObject) != match.BGPObjectID {
+				u.DebugLogger.Info("Match changed", "sequence", sequence.Number, "netrisValue", apiMatch, "k8sValue", match)
+				return false
+			}
+		}
+		if len(apiSequence.Actions) != len(sequence.Actions) {
+			u.DebugLogger.Info("Actions count changed", "sequence", sequence.Number, "netrisValue", len(apiSequence.Actions), "k8sValue", len(sequence.Actions))
+			return false
+		}
+		for i, action := range sequence.Actions {
+			apiAction := apiSequence.Actions[i]
+			if apiAction.Type != action.Type || apiAction.Parameter != action.Parameter || apiAction.Value != action.Value {
+				u.DebugLogger.Info("Action changed", "sequence", sequence.Number, "netrisValue", apiAction, "k8sValue", action)
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// routeMapEbgpObjectID extracts the BGP object ID from the match, which the API returns either as a number or as an object.
+func routeMapEbgpObjectID(ebgpObject interface{}) int {
+	switch v := ebgpObject.(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case map[string]interface{}:
+		if id, ok := v["id"].(float64); ok {
+			return int(id)
+		}
+	}
+	return 0
+}
+
+// routeMapReferences returns the names of BGP resources in the namespace that use the route map.
+func routeMapReferences(cl client.Client, routeMap *k8sv1alpha1.RouteMap) ([]string, error) {
+	bgps := &k8sv1alpha1.BGPList{}
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	if err := cl.List(ctx, bgps, client.InNamespace(routeMap.GetNamespace())); err != nil {
+		return nil, err
+	}
+
+	refs := []string{}
+	for _, bgp := range bgps.Items {
+		if bgp.DeletionTimestamp != nil {
+			continue
+		}
+		if bgp.Spec.InboundRouteMap == routeMap.Name || bgp.Spec.OutboundRouteMap == routeMap.Name {
+			refs = append(refs, bgp.Name)
+		}
+	}
+	return refs, nil
+}
+
+// getRouteMapID resolves a route map by name to its Netris ID.
+// If a RouteMap resource with that name exists in the namespace, it must be provisioned first.
+func getRouteMapID(cl client.Client, nStorage *netrisstorage.Storage, namespace, name string) (int, error) {
+	if name == "" {
+		return 0, nil
+	}
+
+	routeMap := &k8sv1alpha1.RouteMap{}
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	if err := cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, routeMap); err != nil {
+		if !errors.IsNotFound(err) {
+			return 0, err
+		}
+	} else if routeMap.Status.Status != "OK" || routeMap.DeletionTimestamp != nil {
+		return 0, fmt.Errorf("waiting for RouteMap '%s' to be provisioned", name)
+	}
+
+	apiRouteMap, ok := nStorage.RouteMapStorage.FindByName(name)
+	if !ok {
+		return 0, fmt.Errorf("route map '%s' not found", name)
+	}
+	return apiRouteMap.ID, nil
+}
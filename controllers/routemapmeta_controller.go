@@ -0,0 +1,246 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/go-logr/logr"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/netrisstorage"
+	"github.com/netrisai/netriswebapi/http"
+	"github.com/netrisai/netriswebapi/v1/types/routemap"
+	api "github.com/netrisai/netriswebapi/v2"
+)
+
+// RouteMapMetaReconciler reconciles a RouteMapMeta object
+type RouteMapMetaReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Cred     *api.Clientset
+	NStorage *netrisstorage.Storage
+}
+
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=routemapmeta,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=routemapmeta/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=k8s.netris.ai,resources=routemapmeta/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop
+func (r *RouteMapMetaReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	debugLogger := r.Log.WithValues("name", req.NamespacedName).V(int(zapcore.WarnLevel))
+
+	routeMapMeta := &k8sv1alpha1.RouteMapMeta{}
+	routeMapCR := &k8sv1alpha1.RouteMap{}
+	routeMapMetaCtx, routeMapMetaCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer routeMapMetaCancel()
+	if err := r.Get(routeMapMetaCtx, req.NamespacedName, routeMapMeta); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	logger := r.Log.WithValues("name", fmt.Sprintf("%s/%s", req.NamespacedName.Namespace, routeMapMeta.Spec.RouteMapName))
+	debugLogger = logger.V(int(zapcore.WarnLevel))
+
+	u := uniReconciler{
+		Client:      r.Client,
+		Logger:      logger,
+		DebugLogger: debugLogger,
+		Cred:        r.Cred,
+		NStorage:    r.NStorage,
+	}
+
+	provisionState := "OK"
+
+	routeMapNN := req.NamespacedName
+	routeMapNN.Name = routeMapMeta.Spec.RouteMapName
+	routeMapNNCtx, routeMapNNCancel := context.WithTimeout(cntxt, contextTimeout)
+	defer routeMapNNCancel()
+	if err := r.Get(routeMapNNCtx, routeMapNN, routeMapCR); err != nil {
+		if errors.IsNotFound(err) {
+			debugLogger.Info(err.Error())
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if routeMapMeta.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if routeMapMeta.Spec.ID == 0 {
+		debugLogger.Info("ID Not found in meta")
+		if routeMapMeta.Spec.Imported {
+			logger.Info("Importing RouteMap")
+			debugLogger.Info("Imported yaml mode. Finding RouteMap by name")
+			if apiRouteMap, ok := r.NStorage.RouteMapStorage.FindByName(routeMapMeta.Spec.RouteMapName); ok {
+				debugLogger.Info("Imported yaml mode. RouteMap found")
+				routeMapMeta.Spec.ID = apiRouteMap.ID
+
+				routeMapMetaPatchCtx, routeMapMetaPatchCancel := context.WithTimeout(cntxt, contextTimeout)
+				defer routeMapMetaPatchCancel()
+				err := r.Patch(routeMapMetaPatchCtx, routeMapMeta.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+				if err != nil {
+					logger.Error(fmt.Errorf("{patch routeMapMeta.Spec.ID} %s", err), "")
+					return u.patchRouteMapStatus(routeMapCR, "Failure", err.Error())
+				}
+				debugLogger.Info("Imported yaml mode. ID patched")
+				logger.Info("RouteMap imported")
+				return ctrl.Result{RequeueAfter: requeueInterval}, nil
+			}
+			logger.Info("RouteMap not found for import")
+			debugLogger.Info("Imported yaml mode. RouteMap not found")
+		}
+
+		logger.Info("Creating RouteMap")
+		if _, err, errMsg := r.createRouteMap(routeMapMeta); err != nil {
+			logger.Error(fmt.Errorf("{createRouteMap} %s", err), "")
+			return u.patchRouteMapStatus(routeMapCR, "Failure", errMsg.Error())
+		}
+		logger.Info("RouteMap Created")
+	} else {
+		if apiRouteMap, ok := r.NStorage.RouteMapStorage.FindByID(routeMapMeta.Spec.ID); ok {
+			debugLogger.Info("Comparing RouteMapMeta with Netris RouteMap")
+
+			if ok := compareRouteMapMetaAPI(routeMapMeta, apiRouteMap, u); ok {
+				debugLogger.Info("Nothing Changed")
+			} else {
+				debugLogger.Info("Go to update RouteMap in Netris")
+				logger.Info("Updating RouteMap")
+				routeMapUpdate := RouteMapMetaToNetris(routeMapMeta)
+
+				js, _ := json.Marshal(routeMapUpdate)
+				debugLogger.Info("routeMapUpdate", "payload", string(js))
+
+				_, err, errMsg := updateRouteMap(routeMapUpdate, r.Cred)
+				if err != nil {
+					logger.Error(fmt.Errorf("{updateRouteMap} %s", err), "")
+					return u.patchRouteMapStatus(routeMapCR, "Failure", errMsg.Error())
+				}
+				logger.Info("RouteMap Updated")
+			}
+		} else {
+			debugLogger.Info("RouteMap not found in Netris")
+			debugLogger.Info("Going to create RouteMap")
+			logger.Info("Creating RouteMap")
+			if _, err, errMsg := r.createRouteMap(routeMapMeta); err != nil {
+				logger.Error(fmt.Errorf("{createRouteMap} %s", err), "")
+				return u.patchRouteMapStatus(routeMapCR, "Failure", errMsg.Error())
+			}
+			logger.Info("RouteMap Created")
+		}
+	}
+
+	return u.patchRouteMapStatus(routeMapCR, provisionState, "Success")
+}
+
+func (r *RouteMapMetaReconciler) createRouteMap(routeMapMeta *k8sv1alpha1.RouteMapMeta) (ctrl.Result, error, error) {
+	debugLogger := r.Log.WithValues(
+		"name", fmt.Sprintf("%s/%s", routeMapMeta.Namespace, routeMapMeta.Spec.RouteMapName),
+		"routeMapName", routeMapMeta.Spec.RouteMapCRGeneration,
+	).V(int(zapcore.WarnLevel))
+
+	routeMapAdd := RouteMapMetaToNetris(routeMapMeta)
+
+	js, _ := json.Marshal(routeMapAdd)
+	debugLogger.Info("routeMapToAdd", "payload", string(js))
+
+	reply, err := r.Cred.RouteMap().Add(routeMapAdd)
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+
+	idStruct := struct {
+		ID int `json:"id"`
+	}{}
+
+	data, err := reply.Parse()
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+
+	if reply.StatusCode != 200 {
+		return ctrl.Result{}, fmt.Errorf(data.Message), fmt.Errorf(data.Message)
+	}
+
+	if d, ok := data.Data.(map[string]interface{}); ok {
+		if id, ok := d["id"].(float64); ok {
+			idStruct.ID = int(id)
+		}
+	}
+
+	if idStruct.ID == 0 {
+		if err := r.NStorage.RouteMapStorage.Download(); err != nil {
+			return ctrl.Result{}, err, err
+		}
+		apiRouteMap, ok := r.NStorage.RouteMapStorage.FindByName(routeMapMeta.Spec.RouteMapName)
+		if !ok {
+			err := fmt.Errorf("RouteMap not found after creation")
+			return ctrl.Result{}, err, err
+		}
+		idStruct.ID = apiRouteMap.ID
+	}
+
+	debugLogger.Info("RouteMap Created", "id", idStruct.ID)
+
+	routeMapMeta.Spec.ID = idStruct.ID
+
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	err = r.Patch(ctx, routeMapMeta.DeepCopyObject(), client.Merge, &client.PatchOptions{})
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+
+	debugLogger.Info("ID patched to meta", "id", idStruct.ID)
+	return ctrl.Result{}, nil, nil
+}
+
+func updateRouteMap(routeMapW *routemap.RouteMap, cred *api.Clientset) (ctrl.Result, error, error) {
+	reply, err := cred.RouteMap().Update(routeMapW)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("{updateRouteMap} %s", err), err
+	}
+	resp, err := http.ParseAPIResponse(reply.Data)
+	if err != nil {
+		return ctrl.Result{}, err, err
+	}
+	if !resp.IsSuccess {
+		return ctrl.Result{}, fmt.Errorf("{updateRouteMap} %s", fmt.Errorf(resp.Message)), fmt.Errorf(resp.Message)
+	}
+
+	return ctrl.Result{}, nil, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RouteMapMetaReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&k8sv1alpha1.RouteMapMeta{}).
+		Complete(r)
+}
@@ -113,7 +113,7 @@ func (r *ServerClusterReconciler) ServerClusterToMeta(cluster *k8sv1alpha1.Serve
 			VPCName:           vpcName,
 			TemplateID:        templateID,
 			TemplateName:      templateName,
-			Tags:              cluster.Spec.Tags,
+			Tags:              objectTags(cluster, cluster.Spec.Tags),
 			Servers:           servers,
 		},
 	}
@@ -130,7 +130,7 @@ func serverClusterCompareFieldsForNewMeta(cluster *k8sv1alpha1.ServerCluster, cl
 	if i, ok := cluster.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
 		reclaim = true
 	}
-	return cluster.GetGeneration() != clusterMeta.Spec.ServerClusterCRGeneration || imported != clusterMeta.Spec.Imported || reclaim != clusterMeta.Spec.Reclaim
+	return cluster.GetGeneration() != clusterMeta.Spec.ServerClusterCRGeneration || imported != clusterMeta.Spec.Imported || reclaim != clusterMeta.Spec.Reclaim || !compareTags(objectTags(cluster, cluster.Spec.Tags), clusterMeta.Spec.Tags)
 }
 
 func serverClusterMustUpdateAnnotations(cluster *k8sv1alpha1.ServerCluster) bool {
@@ -182,7 +182,7 @@ func ServerClusterMetaToNetris(clusterMeta *k8sv1alpha1.ServerClusterMeta) *serv
 			ID:   clusterMeta.Spec.TemplateID,
 			Name: clusterMeta.Spec.TemplateName,
 		},
-		Tags:    clusterMeta.Spec.Tags,
+		Tags:    metaTags(clusterMeta.Spec.Tags),
 		Servers: clusterMeta.Spec.Servers,
 	}
 }
@@ -190,7 +190,7 @@ func ServerClusterMetaToNetris(clusterMeta *k8sv1alpha1.ServerClusterMeta) *serv
 // ServerClusterMetaToNetrisUpdate converts Meta to Netris API update type.
 func ServerClusterMetaToNetrisUpdate(clusterMeta *k8sv1alpha1.ServerClusterMeta) *servercluster.ServerClusterU {
 	return &servercluster.ServerClusterU{
-		Tags:    clusterMeta.Spec.Tags,
+		Tags:    metaTags(clusterMeta.Spec.Tags),
 		Servers: clusterMeta.Spec.Servers,
 	}
 }
@@ -212,5 +212,9 @@ func compareServerClusterMetaAPI(clusterMeta *k8sv1alpha1.ServerClusterMeta, api
 		u.DebugLogger.Info("TemplateID changed", "netrisValue", apiCluster.SrvClusterTemplate.ID, "k8sValue", clusterMeta.Spec.TemplateID)
 		return false
 	}
+	if !compareTags(apiCluster.Tags, clusterMeta.Spec.Tags) {
+		u.DebugLogger.Info("Tags changed", "netrisValue", apiCluster.Tags, "k8sValue", clusterMeta.Spec.Tags)
+		return false
+	}
 	return true
 }
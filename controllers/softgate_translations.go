@@ -84,6 +84,7 @@ func (r *SoftgateReconciler) SoftgateToSoftgateMeta(softgate *k8sv1alpha1.Softga
 			ProfileID:    profileID,
 			MainIP:       softgate.Spec.MainIP,
 			MgmtIP:       softgate.Spec.MgmtIP,
+			Tags:         objectTags(softgate, nil),
 		},
 	}
 
@@ -99,7 +100,7 @@ func softgateCompareFieldsForNewMeta(softgate *k8sv1alpha1.Softgate, softgateMet
 	if i, ok := softgate.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
 		reclaim = true
 	}
-	return softgate.GetGeneration() != softgateMeta.Spec.SoftgateCRGeneration || imported != softgateMeta.Spec.Imported || reclaim != softgateMeta.Spec.Reclaim
+	return softgate.GetGeneration() != softgateMeta.Spec.SoftgateCRGeneration || imported != softgateMeta.Spec.Imported || reclaim != softgateMeta.Spec.Reclaim || !compareTags(objectTags(softgate, nil), softgateMeta.Spec.Tags)
 }
 
 func softgateMustUpdateAnnotations(softgate *k8sv1alpha1.Softgate) bool {
@@ -149,6 +150,7 @@ func SoftgateMetaToNetris(softgateMeta *k8sv1alpha1.SoftgateMeta) (*inventory.HW
 		MainAddress: mainIP,
 		MgmtAddress: mgmtIP,
 		Links:       []inventory.HWLink{},
+		Tags:        metaTags(softgateMeta.Spec.Tags),
 	}
 
 	return softgateAdd, nil
@@ -175,6 +177,7 @@ func SoftgateMetaToNetrisUpdate(softgateMeta *k8sv1alpha1.SoftgateMeta) (*invent
 		MainAddress: mainIP,
 		MgmtAddress: mgmtIP,
 		Links:       []inventory.HWLink{},
+		Tags:        metaTags(softgateMeta.Spec.Tags),
 	}
 
 	return softgateUpdate, nil
@@ -216,5 +219,10 @@ func compareSoftgateMetaAPIESoftgate(softgateMeta *k8sv1alpha1.SoftgateMeta, api
 		return false
 	}
 
+	if !compareTags(apiSoftgate.Tags, softgateMeta.Spec.Tags) {
+		u.DebugLogger.Info("Tags changed", "netrisValue", apiSoftgate.Tags, "k8sValue", softgateMeta.Spec.Tags)
+		return false
+	}
+
 	return true
 }
@@ -70,6 +70,7 @@ func (r *SubnetReconciler) SubnetToSubnetMeta(subnet *k8sv1alpha1.Subnet) (*k8sv
 			Purpose:        subnet.Spec.Purpose,
 			DefaultGateway: subnet.Spec.DefaultGateway,
 			Sites:          sites,
+			Tags:           objectTags(subnet, nil),
 		},
 	}
 
@@ -85,7 +86,7 @@ func subnetCompareFieldsForNewMeta(subnet *k8sv1alpha1.Subnet, subnetMeta *k8sv1
 	if i, ok := subnet.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
 		reclaim = true
 	}
-	return subnet.GetGeneration() != subnetMeta.Spec.SubnetCRGeneration || imported != subnetMeta.Spec.Imported || reclaim != subnetMeta.Spec.Reclaim
+	return subnet.GetGeneration() != subnetMeta.Spec.SubnetCRGeneration || imported != subnetMeta.Spec.Imported || reclaim != subnetMeta.Spec.Reclaim || !compareTags(objectTags(subnet, nil), subnetMeta.Spec.Tags)
 }
 
 func subnetMustUpdateAnnotations(subnet *k8sv1alpha1.Subnet) bool {
@@ -127,7 +128,7 @@ func SubnetMetaToNetris(subnetMeta *k8sv1alpha1.SubnetMeta) (*ipam.Subnet, error
 		Purpose:        subnetMeta.Spec.Purpose,
 		DefaultGateway: subnetMeta.Spec.DefaultGateway,
 		Sites:          sites,
-		Tags:           []string{},
+		Tags:           metaTags(subnetMeta.Spec.Tags),
 	}
 
 	return subnetAdd, nil
@@ -146,6 +147,7 @@ func SubnetMetaToNetrisUpdate(subnetMeta *k8sv1alpha1.SubnetMeta) (*ipam.Subnet,
 		Purpose:        subnetMeta.Spec.Purpose,
 		DefaultGateway: subnetMeta.Spec.DefaultGateway,
 		Sites:          sites,
+		Tags:           metaTags(subnetMeta.Spec.Tags),
 	}
 
 	return subnetAdd, nil
@@ -177,6 +179,11 @@ func compareSubnetMetaAPIESubnet(subnetMeta *k8sv1alpha1.SubnetMeta, apiSubnet *
 		return false
 	}
 
+	if !compareTags(apiSubnet.Tags, subnetMeta.Spec.Tags) {
+		u.DebugLogger.Info("Tags changed", "netrisValue", apiSubnet.Tags, "k8sValue", subnetMeta.Spec.Tags)
+		return false
+	}
+
 	return true
 }
 
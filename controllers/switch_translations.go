@@ -100,6 +100,7 @@ func (r *SwitchReconciler) SwitchToSwitchMeta(switchH *k8sv1alpha1.Switch) (*k8s
 			MgmtIP:      switchH.Spec.MgmtIP,
 			PortsCount:  switchH.Spec.PortsCount,
 			MacAddress:  switchH.Spec.MacAddress,
+			Tags:        objectTags(switchH, nil),
 		},
 	}
 
@@ -115,7 +116,7 @@ func switchCompareFieldsForNewMeta(switchH *k8sv1alpha1.Switch, switchMeta *k8sv
 	if i, ok := switchH.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
 		reclaim = true
 	}
-	return switchH.GetGeneration() != switchMeta.Spec.SwitchCRGeneration || imported != switchMeta.Spec.Imported || reclaim != switchMeta.Spec.Reclaim
+	return switchH.GetGeneration() != switchMeta.Spec.SwitchCRGeneration || imported != switchMeta.Spec.Imported || reclaim != switchMeta.Spec.Reclaim || !compareTags(objectTags(switchH, nil), switchMeta.Spec.Tags)
 }
 
 func switchMustUpdateAnnotations(switchH *k8sv1alpha1.Switch) bool {
@@ -174,6 +175,7 @@ func SwitchMetaToNetris(switchMeta *k8sv1alpha1.SwitchMeta) (*inventory.HWSwitch
 		PortCount:   switchMeta.Spec.PortsCount,
 		MacAddress:  switchMeta.Spec.MacAddress,
 		Links:       []inventory.HWLink{},
+		Tags:        metaTags(switchMeta.Spec.Tags),
 	}
 
 	return switchAdd, nil
@@ -209,6 +211,7 @@ func SwitchMetaToNetrisUpdate(switchMeta *k8sv1alpha1.SwitchMeta) (*inventory.HW
 		PortCount:   switchMeta.Spec.PortsCount,
 		MacAddress:  "",
 		Links:       []inventory.HWLink{},
+		Tags:        metaTags(switchMeta.Spec.Tags),
 	}
 
 	return switchUpdate, nil
@@ -255,6 +258,11 @@ func compareSwitchMetaAPIESwitch(switchMeta *k8sv1alpha1.SwitchMeta, apiSwitch *
 		return false
 	}
 
+	if !compareTags(apiSwitch.Tags, switchMeta.Spec.Tags) {
+		u.DebugLogger.Info("Tags changed", "netrisValue", apiSwitch.Tags, "k8sValue", switchMeta.Spec.Tags)
+		return false
+	}
+
 	if apiSwitch.Profile.ID != switchMeta.Spec.ProfileID {
 		u.DebugLogger.Info("Profile changed", "netrisValue", apiSwitch.Profile.ID, "k8sValue", switchMeta.Spec.ProfileID)
 		return false
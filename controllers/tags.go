@@ -0,0 +1,106 @@
+/*
+Copyright 2025. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/netrisai/netris-operator/configloader"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// tagLabels maps Kubernetes label keys to the Netris tag keys they are published as.
+var tagLabels = parseTagLabels(configloader.Root.TagLabels)
+
+func parseTagLabels(s string) map[string]string {
+	labels := make(map[string]string)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		label, tag := item, item
+		if i := strings.Index(item, "="); i >= 0 {
+			label, tag = strings.TrimSpace(item[:i]), strings.TrimSpace(item[i+1:])
+		}
+		if label == "" {
+			continue
+		}
+		if tag == "" {
+			tag = label
+		}
+		labels[label] = tag
+	}
+	return labels
+}
+
+// objectTags returns the Netris tags for a resource: the tags set in the spec,
+// "tag=value" for every configured label present on the object and, when enabled,
+// "namespace=<namespace>". The result is sorted and never nil.
+func objectTags(obj metav1.Object, specTags []string) []string {
+	set := make(map[string]struct{})
+	for _, tag := range specTags {
+		set[tag] = struct{}{}
+	}
+	objLabels := obj.GetLabels()
+	for label, tag := range tagLabels {
+		if value, ok := objLabels[label]; ok {
+			set[fmt.Sprintf("%s=%s", tag, value)] = struct{}{}
+		}
+	}
+	if configloader.Root.TagNamespace && obj.GetNamespace() != "" {
+		set[fmt.Sprintf("namespace=%s", obj.GetNamespace())] = struct{}{}
+	}
+
+	tags := []string{}
+	for tag := range set {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// compareTags reports whether both tag lists contain the same tags, regardless of order.
+func compareTags(a, b []string) bool {
+	setA := make(map[string]struct{})
+	for _, tag := range a {
+		setA[tag] = struct{}{}
+	}
+	setB := make(map[string]struct{})
+	for _, tag := range b {
+		setB[tag] = struct{}{}
+	}
+	if len(setA) != len(setB) {
+		return false
+	}
+	for tag := range setA {
+		if _, ok := setB[tag]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// metaTags returns tags stored in a meta object as a non-nil slice, as Netris rejects null tags.
+func metaTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
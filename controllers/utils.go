@@ -66,3 +66,12 @@ func regParser(valueMatch []string, subexpNames []string) map[string]string {
 	}
 	return result
 }
+
+// normalizeIP returns the canonical form of an ip address, so that differently
+// written IPv6 addresses compare equal. Invalid addresses are returned as is.
+func normalizeIP(s string) string {
+	if ip := net.ParseIP(s); ip != nil {
+		return ip.String()
+	}
+	return s
+}
@@ -47,10 +47,15 @@ func (r *VNetReconciler) VnetToVnetMeta(vnet *k8sv1alpha1.VNet) (*k8sv1alpha1.VN
 		siteNames = append(siteNames, site.Name)
 		ports = append(ports, site.SwitchPorts...)
 		for _, gateway := range site.Gateways {
+			if gateway.DHCP == "enabled" && gateway.DHCPOptionSet != "" {
+				if err := checkDHCPOptionSetDependency(r.Client, vnet.GetNamespace(), gateway.DHCPOptionSet, dhcpOptionSetsByNames); err != nil {
+					return nil, err
+				}
+			}
 			apiGateways = append(apiGateways, makeGateway(gateway, dhcpOptionSetsByNames))
 		}
 	}
-	prts, err := r.getPortsMeta(ports)
+	prts, err := r.getPortsMeta(vnet.GetNamespace(), ports)
 	if err != nil {
 		return nil, err
 	}
@@ -113,6 +118,7 @@ func (r *VNetReconciler) VnetToVnetMeta(vnet *k8sv1alpha1.VNet) (*k8sv1alpha1.VN
 			VaNativeVLAN: 1,
 			VaVLANs:      "",
 			VlanID:       vnet.Spec.VlanID,
+			Tags:         objectTags(vnet, nil),
 		},
 	}
 
@@ -200,7 +206,7 @@ func (r *VNetMetaReconciler) VnetMetaToNetris(vnetMeta *k8sv1alpha1.VNetMeta) (*
 		Ports:        members,
 		NativeVlan:   1,
 		Vlan:         vlanidInterface,
-		Tags:         []string{},
+		Tags:         metaTags(vnetMeta.Spec.Tags),
 	}
 
 	return vnetAdd, nil
@@ -285,7 +291,7 @@ func VnetMetaToNetrisUpdate(vnetMeta *k8sv1alpha1.VNetMeta) (*vnet.VNetUpdate, e
 		Ports:        members,
 		NativeVlan:   1,
 		Vlan:         vlanidInterface,
-		Tags:         []string{},
+		Tags:         metaTags(vnetMeta.Spec.Tags),
 	}
 
 	return vnetUpdate, nil
@@ -458,6 +464,10 @@ func compareVNetMetaAPIVnet(vnetMeta *k8sv1alpha1.VNetMeta, apiVnet *vnet.VNetDe
 		return false
 	}
 
+	if !compareTags(vnetMeta.Spec.Tags, apiVnet.Tags) {
+		return false
+	}
+
 	return true
 }
 
@@ -482,7 +492,7 @@ func vnetCompareFieldsForNewMeta(vnet *k8sv1alpha1.VNet, vnetMeta *k8sv1alpha1.V
 	if i, ok := vnet.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
 		reclaim = true
 	}
-	return vnet.GetGeneration() != vnetMeta.Spec.VnetCRGeneration || imported != vnetMeta.Spec.Imported || reclaim != vnetMeta.Spec.Reclaim
+	return vnet.GetGeneration() != vnetMeta.Spec.VnetCRGeneration || imported != vnetMeta.Spec.Imported || reclaim != vnetMeta.Spec.Reclaim || !compareTags(objectTags(vnet, nil), vnetMeta.Spec.Tags)
 }
 
 func vnetMustUpdateAnnotations(vnet *k8sv1alpha1.VNet) bool {
@@ -73,7 +73,7 @@ func (r *VPCReconciler) VPCToMeta(vpcCR *k8sv1alpha1.VPC) (*k8sv1alpha1.VPCMeta,
 			AdminTenantID:   adminTenantID,
 			AdminTenantName: adminTenantName,
 			GuestTenants:    guestTenants,
-			Tags:            vpcCR.Spec.Tags,
+			Tags:            objectTags(vpcCR, vpcCR.Spec.Tags),
 		},
 	}
 
@@ -89,7 +89,7 @@ func vpcCompareFieldsForNewMeta(vpcCR *k8sv1alpha1.VPC, vpcMeta *k8sv1alpha1.VPC
 	if i, ok := vpcCR.GetAnnotations()["resource.k8s.netris.ai/reclaimPolicy"]; ok && i == "retain" {
 		reclaim = true
 	}
-	return vpcCR.GetGeneration() != vpcMeta.Spec.VPCCRGeneration || imported != vpcMeta.Spec.Imported || reclaim != vpcMeta.Spec.Reclaim
+	return vpcCR.GetGeneration() != vpcMeta.Spec.VPCCRGeneration || imported != vpcMeta.Spec.Imported || reclaim != vpcMeta.Spec.Reclaim || !compareTags(objectTags(vpcCR, vpcCR.Spec.Tags), vpcMeta.Spec.Tags)
 }
 
 func vpcMustUpdateAnnotations(vpcCR *k8sv1alpha1.VPC) bool {
@@ -138,7 +138,7 @@ func VPCMetaToNetris(vpcMeta *k8sv1alpha1.VPCMeta) *vpc.VPCw {
 			Name: vpcMeta.Spec.AdminTenantName,
 		},
 		GuestTenant: guestTenants,
-		Tags:        vpcMeta.Spec.Tags,
+		Tags:        metaTags(vpcMeta.Spec.Tags),
 	}
 }
 
@@ -155,5 +155,9 @@ func compareVPCMetaAPI(vpcMeta *k8sv1alpha1.VPCMeta, apiVPC *vpc.VPC, u uniRecon
 		u.DebugLogger.Info("GuestTenants count changed", "netrisValue", len(apiVPC.GuestTenant), "k8sValue", len(vpcMeta.Spec.GuestTenants))
 		return false
 	}
+	if !compareTags(apiVPC.Tags, vpcMeta.Spec.Tags) {
+		u.DebugLogger.Info("Tags changed", "netrisValue", apiVPC.Tags, "k8sValue", vpcMeta.Spec.Tags)
+		return false
+	}
 	return true
 }
@@ -117,8 +117,36 @@ Create netris-opeator controller envs
   value: {{ .Values.requeueInterval | default 15 | quote }}
 - name: NOPERATOR_CALICO_ASN_RANGE
   value: {{ .Values.calicoASNRange | default "4230000000-4239999999" }}
+- name: NOPERATOR_CILIUM_ENABLED
+  value: {{ .Values.ciliumEnabled | default false | quote }}
+- name: NOPERATOR_CILIUM_ASN_RANGE
+  value: {{ .Values.ciliumASNRange | default "4230000000-4239999999" | quote }}
+- name: NOPERATOR_METALLB_ENABLED
+  value: {{ .Values.metallbEnabled | default false | quote }}
+- name: NOPERATOR_METALLB_NAMESPACE
+  value: {{ .Values.metallbNamespace | default "metallb-system" | quote }}
+- name: NOPERATOR_METALLB_ASN_RANGE
+  value: {{ .Values.metallbASNRange | default "4230000000-4239999999" | quote }}
 - name: NOPERATOR_L4LB_TENANT
   value: {{ .Values.l4lbTenant | default "" | quote }}
 - name: NOPERATOR_VPC_ID
   value: {{ .Values.vpcid | default 1 | quote }}
+- name: NOPERATOR_TAG_LABELS
+  value: {{ .Values.tagLabels | default "" | quote }}
+- name: NOPERATOR_TAG_NAMESPACE
+  value: {{ .Values.tagNamespace | default false | quote }}
+- name: NOPERATOR_LB_CLASS
+  value: {{ .Values.loadBalancerClass | default "netris.ai/l4lb" | quote }}
+- name: NOPERATOR_LB_CLASS_ONLY
+  value: {{ .Values.loadBalancerClassOnly | default false | quote }}
+- name: NOPERATOR_LB_MULTISITE_POLICY
+  value: {{ .Values.loadBalancerMultiSitePolicy | default "reject" | quote }}
+- name: NOPERATOR_LB_EXCLUDE_NODE_LABEL
+  value: {{ .Values.loadBalancerExcludeNodeLabel | default "node.kubernetes.io/exclude-from-external-load-balancers" | quote }}
+- name: NOPERATOR_LB_NODE_GRACE_PERIOD
+  value: {{ .Values.loadBalancerNodeGracePeriod | default 30 | quote }}
+- name: NOPERATOR_NAMESPACE
+  valueFrom:
+    fieldRef:
+      fieldPath: metadata.namespace
 {{- end -}}
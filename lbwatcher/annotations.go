@@ -0,0 +1,230 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lbwatcher
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	annotationPrefix       = "lb.k8s.netris.ai/"
+	annotationSite         = annotationPrefix + "site"
+	annotationTenant       = annotationPrefix + "tenant"
+	annotationVPC          = annotationPrefix + "vpc"
+	annotationCheckType    = annotationPrefix + "check-type"
+	annotationCheckPath    = annotationPrefix + "check-path"
+	annotationCheckTimeout = annotationPrefix + "check-timeout"
+	annotationState        = annotationPrefix + "state"
+	annotationLBIPs        = annotationPrefix + "load-balancer-ips"
+	annotationSharedIP     = annotationPrefix + "allow-shared-ip"
+
+	annotationMultiSitePolicy = annotationPrefix + "multisite-policy"
+)
+
+const (
+	defaultCheckType    = "tcp"
+	defaultCheckTimeout = 2000
+	defaultState        = "active"
+)
+
+// lbParams holds the L4LB parameters a Service can set through annotations
+// or inherit from its LoadBalancerPolicy or ClusterLoadBalancerPolicy.
+type lbParams struct {
+	Site         string
+	Tenant       string
+	VPC          string
+	CheckType    string
+	CheckPath    string
+	CheckTimeout int
+	State        string
+
+	// LoadBalancerIPs are the requested frontend IPs per IP family.
+	LoadBalancerIPs map[v1.IPFamily]string
+	// SharedIPKey lets Services with the same key and non-overlapping ports share a frontend IP.
+	SharedIPKey string
+	// FrontendSubnet is the subnet the frontend IPs must belong to, set by the policy.
+	FrontendSubnet *net.IPNet
+
+	MultiSitePolicy string
+}
+
+// serviceLBParams reads the lb.k8s.netris.ai annotations of the Service. The policy
+// of the Service, if any, provides the defaults that the annotations override.
+// Invalid values are replaced by the defaults and returned as warnings.
+func (w *Watcher) serviceLBParams(svc *v1.Service, policy *lbPolicy) (lbParams, []string) {
+	params := lbParams{
+		CheckType:    defaultCheckType,
+		CheckTimeout: defaultCheckTimeout,
+		State:        defaultState,
+
+		LoadBalancerIPs: map[v1.IPFamily]string{},
+
+		MultiSitePolicy: w.Options.MultiSitePolicy,
+	}
+	if params.MultiSitePolicy != multiSitePolicySplit {
+		params.MultiSitePolicy = multiSitePolicyReject
+	}
+	var warnings []string
+	annotations := svc.GetAnnotations()
+
+	if policy != nil {
+		source := policy.Source
+		if site := policy.Spec.Site; site != "" {
+			if _, ok := w.NStorage.SitesStorage.FindByName(site); ok {
+				params.Site = site
+			} else {
+				warnings = append(warnings, fmt.Sprintf("%s: site '%s' not found", source, site))
+			}
+		}
+		if tenant := policy.Spec.Tenant; tenant != "" {
+			if _, ok := w.NStorage.TenantsStorage.FindByName(tenant); ok {
+				params.Tenant = tenant
+			} else {
+				warnings = append(warnings, fmt.Sprintf("%s: tenant '%s' not found", source, tenant))
+			}
+		}
+		if vpc := policy.Spec.VPC; vpc != "" {
+			if _, ok := w.NStorage.VPCStorage.FindByName(vpc); ok {
+				params.VPC = vpc
+			} else {
+				warnings = append(warnings, fmt.Sprintf("%s: vpc '%s' not found", source, vpc))
+			}
+		}
+		if policy.Spec.Check.Type != "" {
+			params.CheckType = policy.Spec.Check.Type
+		}
+		if policy.Spec.Check.RequestPath != "" {
+			params.CheckPath = policy.Spec.Check.RequestPath
+		}
+		if policy.Spec.Check.Timeout > 0 {
+			params.CheckTimeout = policy.Spec.Check.Timeout
+		}
+		if subnet := policy.Spec.FrontendSubnet; subnet != "" {
+			if _, ipNet, err := net.ParseCIDR(subnet); err == nil {
+				params.FrontendSubnet = ipNet
+			} else {
+				warnings = append(warnings, fmt.Sprintf("%s: invalid frontend subnet '%s'", source, subnet))
+			}
+		}
+	}
+
+	if site, ok := annotations[annotationSite]; ok && site != "" {
+		if _, ok := w.NStorage.SitesStorage.FindByName(site); ok {
+			params.Site = site
+		} else {
+			warnings = append(warnings, fmt.Sprintf("%s: site '%s' not found", annotationSite, site))
+		}
+	}
+
+	if tenant, ok := annotations[annotationTenant]; ok && tenant != "" {
+		if _, ok := w.NStorage.TenantsStorage.FindByName(tenant); ok {
+			params.Tenant = tenant
+		} else {
+			warnings = append(warnings, fmt.Sprintf("%s: tenant '%s' not found", annotationTenant, tenant))
+		}
+	}
+
+	if vpc, ok := annotations[annotationVPC]; ok && vpc != "" {
+		if _, ok := w.NStorage.VPCStorage.FindByName(vpc); ok {
+			params.VPC = vpc
+		} else {
+			warnings = append(warnings, fmt.Sprintf("%s: vpc '%s' not found", annotationVPC, vpc))
+		}
+	}
+
+	if checkType, ok := annotations[annotationCheckType]; ok {
+		checkType = strings.ToLower(checkType)
+		if checkType == "tcp" || checkType == "http" || checkType == "none" {
+			params.CheckType = checkType
+		} else {
+			warnings = append(warnings, fmt.Sprintf("%s: invalid value '%s', allowed values are tcp, http and none", annotationCheckType, checkType))
+		}
+	}
+
+	if checkPath, ok := annotations[annotationCheckPath]; ok && checkPath != "" {
+		if params.CheckType != "http" {
+			warnings = append(warnings, fmt.Sprintf("%s: only applicable with http check type", annotationCheckPath))
+		} else if !strings.HasPrefix(checkPath, "/") {
+			warnings = append(warnings, fmt.Sprintf("%s: invalid value '%s', must start with '/'", annotationCheckPath, checkPath))
+		} else {
+			params.CheckPath = checkPath
+		}
+	}
+	if params.CheckType != "http" {
+		params.CheckPath = ""
+	} else if params.CheckPath == "" {
+		params.CheckPath = "/"
+	}
+
+	if checkTimeout, ok := annotations[annotationCheckTimeout]; ok {
+		if timeout, err := strconv.Atoi(checkTimeout); err == nil && timeout > 0 {
+			params.CheckTimeout = timeout
+		} else {
+			warnings = append(warnings, fmt.Sprintf("%s: invalid value '%s', must be a positive number of milliseconds", annotationCheckTimeout, checkTimeout))
+		}
+	}
+
+	if state, ok := annotations[annotationState]; ok {
+		state = strings.ToLower(state)
+		if state == "active" || state == "disable" {
+			params.State = state
+		} else {
+			warnings = append(warnings, fmt.Sprintf("%s: invalid value '%s', allowed values are active and disable", annotationState, state))
+		}
+	}
+
+	if lbIPs, ok := annotations[annotationLBIPs]; ok && lbIPs != "" {
+		for _, ip := range strings.Split(lbIPs, ",") {
+			ip = strings.TrimSpace(ip)
+			family := ipStringFamily(ip)
+			if family == "" {
+				warnings = append(warnings, fmt.Sprintf("%s: invalid IP address '%s'", annotationLBIPs, ip))
+			} else if _, ok := params.LoadBalancerIPs[family]; ok {
+				warnings = append(warnings, fmt.Sprintf("%s: more than one %s address", annotationLBIPs, family))
+			} else {
+				params.LoadBalancerIPs[family] = ip
+			}
+		}
+	}
+	if svc.Spec.LoadBalancerIP != "" {
+		if family := ipStringFamily(svc.Spec.LoadBalancerIP); family != "" {
+			if _, ok := params.LoadBalancerIPs[family]; !ok {
+				params.LoadBalancerIPs[family] = svc.Spec.LoadBalancerIP
+			}
+		}
+	}
+
+	if key, ok := annotations[annotationSharedIP]; ok {
+		params.SharedIPKey = strings.TrimSpace(key)
+	}
+
+	if policy, ok := annotations[annotationMultiSitePolicy]; ok {
+		policy = strings.ToLower(policy)
+		if policy == multiSitePolicySplit || policy == multiSitePolicyReject {
+			params.MultiSitePolicy = policy
+		} else {
+			warnings = append(warnings, fmt.Sprintf("%s: invalid value '%s', allowed values are split and reject", annotationMultiSitePolicy, policy))
+		}
+	}
+
+	return params, warnings
+}
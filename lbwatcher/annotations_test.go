@@ -0,0 +1,308 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lbwatcher
+
+import (
+	"net"
+	nethttp "net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/netrisstorage"
+	"github.com/netrisai/netriswebapi/http"
+	"github.com/netrisai/netriswebapi/v1/types/tenant"
+	api "github.com/netrisai/netriswebapi/v2"
+	"github.com/netrisai/netriswebapi/v2/types/site"
+	"github.com/netrisai/netriswebapi/v2/types/vpc"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// testLBStorage returns the storage with the sites, tenants and VPCs of the tests. The storages that
+// download on a miss talk to a Netris API answering every request with an error.
+func testLBStorage(t *testing.T) *netrisstorage.Storage {
+	nStorage := netrisstorage.NewStorage(nil)
+	nStorage.SitesStorage.Sites = []*site.Site{{ID: 1, Name: "site1"}, {ID: 2, Name: "site2"}}
+	nStorage.TenantsStorage.Tenants = []*tenant.Tenant{{ID: 1, Name: "tenant1"}, {ID: 2, Name: "tenant2"}}
+	nStorage.VPCStorage.VPCs = []*vpc.VPC{{ID: 1, Name: "vpc1"}, {ID: 2, Name: "vpc2"}}
+
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.WriteHeader(nethttp.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+	cred, err := http.NewHTTPCredentials(server.URL, "", "", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	netrisstorage.Cred = &api.Clientset{Client: cred}
+	t.Cleanup(func() { netrisstorage.Cred = nil })
+	return nStorage
+}
+
+func TestServiceLBParams(t *testing.T) {
+	w := &Watcher{NStorage: testLBStorage(t)}
+
+	params := func(change func(p *lbParams)) lbParams {
+		p := lbParams{
+			CheckType:       defaultCheckType,
+			CheckTimeout:    defaultCheckTimeout,
+			State:           defaultState,
+			LoadBalancerIPs: map[v1.IPFamily]string{},
+			MultiSitePolicy: multiSitePolicyReject,
+		}
+		if change != nil {
+			change(&p)
+		}
+		return p
+	}
+
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		loadBalancerIP string
+		policy         *lbPolicy
+		want           lbParams
+		wantWarnings   []string
+	}{
+		{
+			name: "defaults",
+			want: params(nil),
+		},
+		{
+			name:        "site, tenant and vpc",
+			annotations: map[string]string{annotationSite: "site1", annotationTenant: "tenant1", annotationVPC: "vpc1"},
+			want: params(func(p *lbParams) {
+				p.Site, p.Tenant, p.VPC = "site1", "tenant1", "vpc1"
+			}),
+		},
+		{
+			name:         "unknown site, tenant and vpc",
+			annotations:  map[string]string{annotationSite: "nosite", annotationTenant: "notenant", annotationVPC: "novpc"},
+			want:         params(nil),
+			wantWarnings: []string{"site 'nosite' not found", "tenant 'notenant' not found", "vpc 'novpc' not found"},
+		},
+		{
+			name:        "http check with path and timeout",
+			annotations: map[string]string{annotationCheckType: "HTTP", annotationCheckPath: "/healthz", annotationCheckTimeout: "500"},
+			want: params(func(p *lbParams) {
+				p.CheckType, p.CheckPath, p.CheckTimeout = "http", "/healthz", 500
+			}),
+		},
+		{
+			name:        "http check without path",
+			annotations: map[string]string{annotationCheckType: "http"},
+			want:        params(func(p *lbParams) { p.CheckType, p.CheckPath = "http", "/" }),
+		},
+		{
+			name:         "invalid check type",
+			annotations:  map[string]string{annotationCheckType: "udp"},
+			want:         params(nil),
+			wantWarnings: []string{"check-type: invalid value 'udp'"},
+		},
+		{
+			name:         "check path without http check",
+			annotations:  map[string]string{annotationCheckPath: "/healthz"},
+			want:         params(nil),
+			wantWarnings: []string{"check-path: only applicable with http check type"},
+		},
+		{
+			name:         "relative check path",
+			annotations:  map[string]string{annotationCheckType: "http", annotationCheckPath: "healthz"},
+			want:         params(func(p *lbParams) { p.CheckType, p.CheckPath = "http", "/" }),
+			wantWarnings: []string{"check-path: invalid value 'healthz'"},
+		},
+		{
+			name:         "invalid check timeouts",
+			annotations:  map[string]string{annotationCheckTimeout: "0"},
+			want:         params(nil),
+			wantWarnings: []string{"check-timeout: invalid value '0'"},
+		},
+		{
+			name:         "non numeric check timeout",
+			annotations:  map[string]string{annotationCheckTimeout: "2s"},
+			want:         params(nil),
+			wantWarnings: []string{"check-timeout: invalid value '2s'"},
+		},
+		{
+			name:        "disabled state",
+			annotations: map[string]string{annotationState: "Disable"},
+			want:        params(func(p *lbParams) { p.State = "disable" }),
+		},
+		{
+			name:         "invalid state",
+			annotations:  map[string]string{annotationState: "disabled"},
+			want:         params(nil),
+			wantWarnings: []string{"state: invalid value 'disabled'"},
+		},
+		{
+			name:        "load balancer IPs of both families",
+			annotations: map[string]string{annotationLBIPs: "192.0.2.10, 2001:db8::10"},
+			want: params(func(p *lbParams) {
+				p.LoadBalancerIPs = map[v1.IPFamily]string{v1.IPv4Protocol: "192.0.2.10", v1.IPv6Protocol: "2001:db8::10"}
+			}),
+		},
+		{
+			name:         "invalid and duplicate load balancer IPs",
+			annotations:  map[string]string{annotationLBIPs: "192.0.2.10,192.0.2.11,not-an-ip"},
+			want:         params(func(p *lbParams) { p.LoadBalancerIPs = map[v1.IPFamily]string{v1.IPv4Protocol: "192.0.2.10"} }),
+			wantWarnings: []string{"more than one IPv4 address", "invalid IP address 'not-an-ip'"},
+		},
+		{
+			name:           "spec.loadBalancerIP fills the missing family only",
+			annotations:    map[string]string{annotationLBIPs: "2001:db8::10"},
+			loadBalancerIP: "192.0.2.20",
+			want: params(func(p *lbParams) {
+				p.LoadBalancerIPs = map[v1.IPFamily]string{v1.IPv4Protocol: "192.0.2.20", v1.IPv6Protocol: "2001:db8::10"}
+			}),
+		},
+		{
+			name:        "shared IP key and multi-site policy",
+			annotations: map[string]string{annotationSharedIP: " web ", annotationMultiSitePolicy: "Split"},
+			want:        params(func(p *lbParams) { p.SharedIPKey, p.MultiSitePolicy = "web", multiSitePolicySplit }),
+		},
+		{
+			name:         "invalid multi-site policy",
+			annotations:  map[string]string{annotationMultiSitePolicy: "spread"},
+			want:         params(nil),
+			wantWarnings: []string{"multisite-policy: invalid value 'spread'"},
+		},
+		{
+			name:        "annotations override the policy",
+			annotations: map[string]string{annotationSite: "site1", annotationVPC: "vpc1"},
+			policy: &lbPolicy{Source: "LoadBalancerPolicy default/p", Spec: k8sv1alpha1.LoadBalancerPolicySpec{
+				Site: "site2", Tenant: "tenant2", VPC: "vpc2", FrontendSubnet: "192.0.2.0/24",
+				Check: k8sv1alpha1.L4LBCheck{Type: "http", RequestPath: "/ready", Timeout: 1000},
+			}},
+			want: params(func(p *lbParams) {
+				p.Site, p.Tenant, p.VPC = "site1", "tenant2", "vpc1"
+				p.CheckType, p.CheckPath, p.CheckTimeout = "http", "/ready", 1000
+				_, p.FrontendSubnet, _ = net.ParseCIDR("192.0.2.0/24")
+			}),
+		},
+		{
+			name: "invalid policy values",
+			policy: &lbPolicy{Source: "LoadBalancerPolicy default/p", Spec: k8sv1alpha1.LoadBalancerPolicySpec{
+				Site: "nosite", VPC: "novpc", FrontendSubnet: "192.0.2.0",
+			}},
+			want: params(nil),
+			wantWarnings: []string{
+				"LoadBalancerPolicy default/p: site 'nosite' not found",
+				"LoadBalancerPolicy default/p: vpc 'novpc' not found",
+				"LoadBalancerPolicy default/p: invalid frontend subnet '192.0.2.0'",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Annotations: tt.annotations},
+				Spec:       v1.ServiceSpec{LoadBalancerIP: tt.loadBalancerIP},
+			}
+			got, warnings := w.serviceLBParams(svc, tt.policy)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("params = %+v, want %+v", got, tt.want)
+			}
+			if len(warnings) != len(tt.wantWarnings) {
+				t.Fatalf("warnings = %q, want %q", warnings, tt.wantWarnings)
+			}
+			for i, want := range tt.wantWarnings {
+				if !strings.Contains(warnings[i], want) {
+					t.Errorf("warning %d = %q, want it to contain %q", i, warnings[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestCompareLoadBalancersDrift(t *testing.T) {
+	lb := func(tenant, vpc, site string) *k8sv1alpha1.L4LB {
+		l := &k8sv1alpha1.L4LB{ObjectMeta: metav1.ObjectMeta{Name: "web-tcp-80", Namespace: "default", Annotations: map[string]string{}}}
+		l.SetServiceUID("uid")
+		l.Spec.OwnerTenant = tenant
+		l.Spec.VPC = vpc
+		l.Spec.Site = site
+		l.Spec.Frontend.IP = "192.0.2.10"
+		l.Spec.Backend = []k8sv1alpha1.L4LBBackend{"10.0.0.10:30080"}
+		return l
+	}
+	existing := *lb("tenant1", "vpc1", "site1")
+
+	tests := []struct {
+		name      string
+		generated *k8sv1alpha1.L4LB
+		want      *k8sv1alpha1.L4LBSpec
+	}{
+		{
+			name:      "nothing changed",
+			generated: lb("tenant1", "vpc1", "site1"),
+		},
+		{
+			name:      "tenant backfilled from Netris is kept without annotation",
+			generated: lb("", "vpc1", "site1"),
+		},
+		{
+			name:      "site backfilled from Netris is kept without annotation",
+			generated: lb("tenant1", "vpc1", ""),
+		},
+		{
+			name:      "tenant drift",
+			generated: lb("tenant2", "vpc1", "site1"),
+			want:      &lb("tenant2", "vpc1", "site1").Spec,
+		},
+		{
+			name:      "vpc drift",
+			generated: lb("tenant1", "vpc2", "site1"),
+			want:      &lb("tenant1", "vpc2", "site1").Spec,
+		},
+		{
+			name:      "vpc removed",
+			generated: lb("tenant1", "", "site1"),
+			want:      &lb("tenant1", "", "site1").Spec,
+		},
+		{
+			name:      "site drift",
+			generated: lb("tenant1", "vpc1", "site2"),
+			want:      &lb("tenant1", "vpc1", "site2").Spec,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toCreate, toUpdate, toDelete, _, conflicts := compareLoadBalancers(
+				[]k8sv1alpha1.L4LB{*existing.DeepCopy()}, []*k8sv1alpha1.L4LB{tt.generated}, nil)
+			if len(toCreate) != 0 || len(toDelete) != 0 || len(conflicts) != 0 {
+				t.Fatalf("create %v, delete %v, conflicts %v, want none", toCreate, toDelete, conflicts)
+			}
+			if tt.want == nil {
+				if len(toUpdate) != 0 {
+					t.Errorf("updated %+v, want no update", toUpdate[0].Spec)
+				}
+				return
+			}
+			if len(toUpdate) != 1 {
+				t.Fatalf("updated %d L4LBs, want 1", len(toUpdate))
+			}
+			if !reflect.DeepEqual(toUpdate[0].Spec, *tt.want) {
+				t.Errorf("updated spec = %+v, want %+v", toUpdate[0].Spec, *tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lbwatcher
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// endpointSliceResource is read through the dynamic client: discovery.k8s.io/v1beta1,
+// the newest version of the typed API the operator is built with, is gone since Kubernetes 1.25.
+var endpointSliceResource = schema.GroupVersionResource{
+	Group:    discoveryv1beta1.GroupName,
+	Version:  "v1",
+	Resource: "endpointslices",
+}
+
+// endpointExt holds the discovery.k8s.io/v1 endpoint fields missing from the typed v1beta1 API.
+type endpointExt struct {
+	NodeName    string
+	Terminating bool
+}
+
+// getServiceBackendIPs returns the node IPs that should receive traffic for the Service.
+// Only ready, non-terminating endpoints of the Service EndpointSlices are taken into account,
+// so selector-less Services with manually managed (mirrored) endpoints work as well.
+// With externalTrafficPolicy Local only the nodes hosting such endpoints are returned,
+// with Cluster every eligible node can forward the traffic and all of them are returned.
+// Unschedulable, excluded and NotReady nodes are dropped after the node grace period.
+// Only endpoints and node addresses of the given IP family are considered.
+func (w *Watcher) getServiceBackendIPs(svc *v1.Service, family v1.IPFamily) ([]string, error) {
+	selector := labels.SelectorFromSet(labels.Set{discoveryv1beta1.LabelServiceName: svc.GetName()})
+	objs, err := w.endpointSliceLister.ByNamespace(svc.GetNamespace()).List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("{getServiceBackendIPs} %s", err)
+	}
+
+	hasEndpoints := false
+	endpointNodes := map[string]struct{}{}
+	for _, obj := range objs {
+		slice, exts, err := endpointSliceFromUnstructured(obj)
+		if err != nil {
+			return nil, fmt.Errorf("{getServiceBackendIPs} %s", err)
+		}
+		if !sliceMatchesFamily(slice, family) {
+			continue
+		}
+		for i, endpoint := range slice.Endpoints {
+			if !endpointServing(endpoint, exts[i]) {
+				continue
+			}
+			hasEndpoints = true
+			if exts[i].NodeName != "" {
+				endpointNodes[exts[i].NodeName] = struct{}{}
+			}
+		}
+	}
+
+	if !hasEndpoints {
+		return nil, nil
+	}
+
+	var nodes []*v1.Node
+	if svc.Spec.ExternalTrafficPolicy == v1.ServiceExternalTrafficPolicyTypeLocal {
+		for nodeName := range endpointNodes {
+			node, err := w.nodeLister.Get(nodeName)
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, fmt.Errorf("{getServiceBackendIPs} %s", err)
+			}
+			nodes = append(nodes, node)
+		}
+	} else {
+		nodes, err = w.nodeLister.List(labels.Everything())
+		if err != nil {
+			return nil, fmt.Errorf("{getServiceBackendIPs} %s", err)
+		}
+	}
+
+	ips := []string{}
+	for _, node := range nodes {
+		if !w.nodeEligible(node) {
+			continue
+		}
+		if ip := nodeInternalIP(node, family); ip != "" {
+			ips = append(ips, ip)
+		}
+	}
+	sort.Strings(ips)
+	return ips, nil
+}
+
+// endpointServing reports whether the endpoint is ready and does not belong to a terminating pod.
+func endpointServing(endpoint discoveryv1beta1.Endpoint, ext endpointExt) bool {
+	if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+		return false
+	}
+	return !ext.Terminating
+}
+
+// endpointSliceFromUnstructured converts the discovery.k8s.io/v1 EndpointSlice. The fields
+// the typed v1beta1 API lacks are returned separately, one entry per endpoint.
+func endpointSliceFromUnstructured(obj runtime.Object) (*discoveryv1beta1.EndpointSlice, []endpointExt, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, nil, fmt.Errorf("{endpointSliceFromUnstructured} unexpected object type %T", obj)
+	}
+	slice := &discoveryv1beta1.EndpointSlice{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), slice); err != nil {
+		return nil, nil, fmt.Errorf("{endpointSliceFromUnstructured} %s", err)
+	}
+	exts := make([]endpointExt, len(slice.Endpoints))
+	endpoints, _, _ := unstructured.NestedSlice(u.Object, "endpoints")
+	for i, endpoint := range endpoints {
+		e, ok := endpoint.(map[string]interface{})
+		if !ok || i >= len(exts) {
+			continue
+		}
+		exts[i].NodeName, _, _ = unstructured.NestedString(e, "nodeName")
+		exts[i].Terminating, _, _ = unstructured.NestedBool(e, "conditions", "terminating")
+	}
+	return slice, exts, nil
+}
+
+func nodeReady(node *v1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// nodeInternalIP returns the first InternalIP of the node of the given family.
+func nodeInternalIP(node *v1.Node, family v1.IPFamily) string {
+	for _, address := range node.Status.Addresses {
+		if address.Type == v1.NodeInternalIP && ipStringFamily(address.Address) == family {
+			return address.Address
+		}
+	}
+	return ""
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lbwatcher
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestEndpointSliceFromUnstructured(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion":  "discovery.k8s.io/v1",
+		"kind":        "EndpointSlice",
+		"metadata":    map[string]interface{}{"name": "web-abc", "namespace": "default"},
+		"addressType": "IPv4",
+		"endpoints": []interface{}{
+			map[string]interface{}{
+				"addresses":  []interface{}{"10.1.0.10"},
+				"conditions": map[string]interface{}{"ready": true},
+				"nodeName":   "node1",
+			},
+			map[string]interface{}{
+				"addresses":  []interface{}{"10.1.0.11"},
+				"conditions": map[string]interface{}{"ready": false, "serving": true, "terminating": true},
+				"nodeName":   "node2",
+			},
+			map[string]interface{}{
+				"addresses":  []interface{}{"10.1.0.12"},
+				"conditions": map[string]interface{}{},
+			},
+		},
+	}}
+
+	slice, exts, err := endpointSliceFromUnstructured(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sliceMatchesFamily(slice, v1.IPv4Protocol) || sliceMatchesFamily(slice, v1.IPv6Protocol) {
+		t.Errorf("address type %s matched the wrong family", slice.AddressType)
+	}
+	if len(slice.Endpoints) != 3 || len(exts) != 3 {
+		t.Fatalf("got %d endpoints and %d extensions, want 3", len(slice.Endpoints), len(exts))
+	}
+
+	want := []struct {
+		nodeName string
+		serving  bool
+	}{
+		{"node1", true},
+		{"node2", false},
+		{"", true},
+	}
+	for i, w := range want {
+		if exts[i].NodeName != w.nodeName {
+			t.Errorf("endpoint %d nodeName = %q, want %q", i, exts[i].NodeName, w.nodeName)
+		}
+		if got := endpointServing(slice.Endpoints[i], exts[i]); got != w.serving {
+			t.Errorf("endpoint %d serving = %v, want %v", i, got, w.serving)
+		}
+	}
+}
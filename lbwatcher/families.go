@@ -0,0 +1,112 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lbwatcher
+
+import (
+	"net"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+)
+
+// serviceSpecExt holds the Service spec fields that are newer than the typed API
+// the operator is built with and therefore are read from the unstructured object.
+type serviceSpecExt struct {
+	LoadBalancerClass string
+	IPFamilies        []v1.IPFamily
+}
+
+// serviceIPFamilies returns the IP families of the Service. Clusters without dual-stack
+// support have no spec.ipFamilies, the singular spec.ipFamily or the ClusterIP is used then.
+func serviceIPFamilies(svc *v1.Service, ext serviceSpecExt) []v1.IPFamily {
+	families := []v1.IPFamily{}
+	seen := map[v1.IPFamily]bool{}
+	for _, family := range ext.IPFamilies {
+		if (family == v1.IPv4Protocol || family == v1.IPv6Protocol) && !seen[family] {
+			seen[family] = true
+			families = append(families, family)
+		}
+	}
+	if len(families) > 0 {
+		return families
+	}
+	if svc.Spec.IPFamily != nil {
+		return []v1.IPFamily{*svc.Spec.IPFamily}
+	}
+	if ip := net.ParseIP(svc.Spec.ClusterIP); ip != nil {
+		return []v1.IPFamily{ipFamily(ip)}
+	}
+	return []v1.IPFamily{v1.IPv4Protocol}
+}
+
+func ipFamily(ip net.IP) v1.IPFamily {
+	if ip.To4() == nil {
+		return v1.IPv6Protocol
+	}
+	return v1.IPv4Protocol
+}
+
+// ipStringFamily returns the family of the IP string, or an empty string if it's not an IP.
+func ipStringFamily(s string) v1.IPFamily {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return ""
+	}
+	return ipFamily(ip)
+}
+
+// lbFamily returns the IP family of an existing L4LB, detected by its backends or frontend.
+func lbFamily(lb *k8sv1alpha1.L4LB) v1.IPFamily {
+	for _, backend := range lb.Spec.Backend {
+		if host, _, err := net.SplitHostPort(string(backend)); err == nil {
+			if family := ipStringFamily(host); family != "" {
+				return family
+			}
+		}
+	}
+	if family := ipStringFamily(lb.Spec.Frontend.IP); family != "" {
+		return family
+	}
+	return v1.IPv4Protocol
+}
+
+// sliceMatchesFamily reports whether the EndpointSlice carries addresses of the family.
+// Slices of the deprecated IP address type are matched by their first address.
+func sliceMatchesFamily(slice *discoveryv1beta1.EndpointSlice, family v1.IPFamily) bool {
+	switch slice.AddressType {
+	case discoveryv1beta1.AddressTypeIPv4:
+		return family == v1.IPv4Protocol
+	case discoveryv1beta1.AddressTypeIPv6:
+		return family == v1.IPv6Protocol
+	case discoveryv1beta1.AddressTypeIP:
+		for _, endpoint := range slice.Endpoints {
+			for _, address := range endpoint.Addresses {
+				return ipStringFamily(address) == family
+			}
+		}
+	}
+	return false
+}
+
+// familySuffix is appended to the names of IPv6 L4LBs, so that IPv4 L4LBs keep their names.
+func familySuffix(family v1.IPFamily) string {
+	if family == v1.IPv6Protocol {
+		return "-ipv6"
+	}
+	return ""
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lbwatcher
+
+import (
+	"reflect"
+	"testing"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+)
+
+func TestServiceIPFamilies(t *testing.T) {
+	ipv6 := v1.IPv6Protocol
+
+	tests := []struct {
+		name string
+		svc  v1.ServiceSpec
+		ext  serviceSpecExt
+		want []v1.IPFamily
+	}{
+		{
+			name: "single-stack IPv4",
+			ext:  serviceSpecExt{IPFamilies: []v1.IPFamily{v1.IPv4Protocol}},
+			want: []v1.IPFamily{v1.IPv4Protocol},
+		},
+		{
+			name: "single-stack IPv6",
+			ext:  serviceSpecExt{IPFamilies: []v1.IPFamily{v1.IPv6Protocol}},
+			want: []v1.IPFamily{v1.IPv6Protocol},
+		},
+		{
+			name: "dual-stack keeps the order",
+			ext:  serviceSpecExt{IPFamilies: []v1.IPFamily{v1.IPv6Protocol, v1.IPv4Protocol}},
+			want: []v1.IPFamily{v1.IPv6Protocol, v1.IPv4Protocol},
+		},
+		{
+			name: "unknown and duplicate families are dropped",
+			ext:  serviceSpecExt{IPFamilies: []v1.IPFamily{v1.IPv4Protocol, "IPv5", v1.IPv4Protocol}},
+			want: []v1.IPFamily{v1.IPv4Protocol},
+		},
+		{
+			name: "singular ipFamily",
+			svc:  v1.ServiceSpec{IPFamily: &ipv6, ClusterIP: "10.96.0.10"},
+			want: []v1.IPFamily{v1.IPv6Protocol},
+		},
+		{
+			name: "IPv6 ClusterIP",
+			svc:  v1.ServiceSpec{ClusterIP: "fd00:10:96::10"},
+			want: []v1.IPFamily{v1.IPv6Protocol},
+		},
+		{
+			name: "headless defaults to IPv4",
+			svc:  v1.ServiceSpec{ClusterIP: v1.ClusterIPNone},
+			want: []v1.IPFamily{v1.IPv4Protocol},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serviceIPFamilies(&v1.Service{Spec: tt.svc}, tt.ext); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serviceIPFamilies = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLBFamily(t *testing.T) {
+	tests := []struct {
+		name     string
+		frontend string
+		backends []k8sv1alpha1.L4LBBackend
+		want     v1.IPFamily
+	}{
+		{
+			name:     "IPv4 backends",
+			backends: []k8sv1alpha1.L4LBBackend{"10.0.0.10:30080"},
+			want:     v1.IPv4Protocol,
+		},
+		{
+			name:     "IPv6 backends",
+			backends: []k8sv1alpha1.L4LBBackend{"[2001:db8::10]:30080"},
+			want:     v1.IPv6Protocol,
+		},
+		{
+			name:     "invalid backends fall back to the frontend",
+			frontend: "2001:db8::1",
+			backends: []k8sv1alpha1.L4LBBackend{"2001:db8::10:30080"},
+			want:     v1.IPv6Protocol,
+		},
+		{
+			name:     "IPv6 frontend without backends",
+			frontend: "2001:db8::1",
+			want:     v1.IPv6Protocol,
+		},
+		{
+			name: "automatic frontend without backends",
+			want: v1.IPv4Protocol,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lb := &k8sv1alpha1.L4LB{}
+			lb.Spec.Frontend.IP = tt.frontend
+			lb.Spec.Backend = tt.backends
+			if got := lbFamily(lb); got != tt.want {
+				t.Errorf("lbFamily = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSliceMatchesFamily(t *testing.T) {
+	slice := func(addressType discoveryv1beta1.AddressType, addresses ...string) *discoveryv1beta1.EndpointSlice {
+		return &discoveryv1beta1.EndpointSlice{
+			AddressType: addressType,
+			Endpoints:   []discoveryv1beta1.Endpoint{{Addresses: addresses}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		slice  *discoveryv1beta1.EndpointSlice
+		family v1.IPFamily
+		want   bool
+	}{
+		{name: "IPv4 slice for IPv4", slice: slice(discoveryv1beta1.AddressTypeIPv4, "10.244.0.10"), family: v1.IPv4Protocol, want: true},
+		{name: "IPv4 slice for IPv6", slice: slice(discoveryv1beta1.AddressTypeIPv4, "10.244.0.10"), family: v1.IPv6Protocol},
+		{name: "IPv6 slice for IPv6", slice: slice(discoveryv1beta1.AddressTypeIPv6, "fd00:10:244::10"), family: v1.IPv6Protocol, want: true},
+		{name: "IPv6 slice for IPv4", slice: slice(discoveryv1beta1.AddressTypeIPv6, "fd00:10:244::10"), family: v1.IPv4Protocol},
+		{name: "IP slice with IPv4 addresses", slice: slice(discoveryv1beta1.AddressTypeIP, "10.244.0.10"), family: v1.IPv4Protocol, want: true},
+		{name: "IP slice with IPv6 addresses", slice: slice(discoveryv1beta1.AddressTypeIP, "fd00:10:244::10"), family: v1.IPv4Protocol},
+		{name: "empty IP slice", slice: slice(discoveryv1beta1.AddressTypeIP), family: v1.IPv4Protocol},
+		{name: "FQDN slice", slice: slice(discoveryv1beta1.AddressTypeFQDN, "example.com"), family: v1.IPv4Protocol},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sliceMatchesFamily(tt.slice, tt.family); got != tt.want {
+				t.Errorf("sliceMatchesFamily = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareBackendsIPv6(t *testing.T) {
+	existing := []k8sv1alpha1.L4LBBackend{"[2001:db8::10]:30080", "[2001:db8::11]:30080"}
+
+	if !compareBackends(existing, []k8sv1alpha1.L4LBBackend{"[2001:db8::11]:30080", "[2001:db8::10]:30080"}) {
+		t.Error("reordered IPv6 backends reported as changed")
+	}
+	if compareBackends(existing, []k8sv1alpha1.L4LBBackend{"[2001:db8::10]:30080", "[2001:db8::11]:30081"}) {
+		t.Error("changed IPv6 backend port not reported")
+	}
+	if compareBackends(existing, []k8sv1alpha1.L4LBBackend{"[2001:db8::10]:30080"}) {
+		t.Error("removed IPv6 backend not reported")
+	}
+}
@@ -20,7 +20,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -29,15 +31,25 @@ import (
 	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
 	"github.com/netrisai/netris-operator/netrisstorage"
 	"go.uber.org/zap/zapcore"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
+const defaultLoadBalancerClass = "netris.ai/l4lb"
+
 var (
 	requeueInterval = time.Duration(10 * time.Second)
 	logger          logr.Logger
@@ -52,17 +64,14 @@ func NewWatcher(nStorage *netrisstorage.Storage, mgr manager.Manager, options Op
 		return nil, fmt.Errorf("Please provide NStorage")
 	}
 	watcher := &Watcher{
-		NStorage: nStorage,
-		MGR:      mgr,
-		Options:  options,
+		NStorage:      nStorage,
+		MGR:           mgr,
+		Options:       options,
+		excludedNodes: map[string]time.Time{},
 	}
 	return watcher, nil
 }
 
-func (w *Watcher) start(clientset *kubernetes.Clientset, cl client.Client, recorder record.EventRecorder) {
-	w.loadBalancerProcess(clientset, cl, recorder)
-}
-
 // Start .
 func (w *Watcher) Start() {
 	if w.Options.LogLevel == "debug" {
@@ -82,15 +91,23 @@ func (w *Watcher) Start() {
 	clientset, err := getClientset()
 	if err != nil {
 		logger.Error(err, "")
+		return
 	}
-	cl := w.MGR.GetClient()
-	recorder, _, _ := eventRecorder(clientset)
+	w.clientset = clientset
+	w.client = w.MGR.GetClient()
+	w.recorder, _, _ = eventRecorder(clientset)
+	w.queue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "lbwatcher")
+	defer w.queue.ShutDown()
+
+	stop := make(chan struct{})
+	defer close(stop)
 
-	ticker := time.NewTicker(requeueInterval)
-	w.start(clientset, cl, recorder)
-	for {
-		<-ticker.C
-		w.start(clientset, cl, recorder)
+	if err := w.setupInformers(stop); err != nil {
+		logger.Error(err, "")
+		return
+	}
+
+	for w.processNextItem() {
 	}
 }
 
@@ -98,6 +115,218 @@ func getClientset() (*kubernetes.Clientset, error) {
 	return kubernetes.NewForConfig(ctrl.GetConfigOrDie())
 }
 
+// setupInformers registers event handlers for Services, EndpointSlices, L4LBs and LoadBalancerPolicies
+// and waits until their caches are synced. The informers resync every requeueInterval,
+// so each Service is still periodically reconciled against the cached state.
+func (w *Watcher) setupInformers(stop <-chan struct{}) error {
+	factory := informers.NewSharedInformerFactory(w.clientset, requeueInterval)
+
+	// Services and EndpointSlices are watched through the dynamic client, so that spec.loadBalancerClass
+	// and discovery.k8s.io/v1, which are newer than the typed API the operator is built with, are available.
+	dynamicClient, err := dynamic.NewForConfig(ctrl.GetConfigOrDie())
+	if err != nil {
+		return fmt.Errorf("{setupInformers} %s", err)
+	}
+	dynamicFactory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, requeueInterval)
+
+	serviceInformer := dynamicFactory.ForResource(v1.SchemeGroupVersion.WithResource("services"))
+	serviceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    w.enqueueService,
+		UpdateFunc: func(_, obj interface{}) { w.enqueueService(obj) },
+		DeleteFunc: w.enqueueService,
+	})
+	w.serviceLister = serviceInformer.Lister()
+
+	namespaceInformer := factory.Core().V1().Namespaces()
+	namespaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldNamespace, ok := oldObj.(*v1.Namespace)
+			if !ok {
+				return
+			}
+			newNamespace, ok := newObj.(*v1.Namespace)
+			if !ok {
+				return
+			}
+			// LoadBalancerPolicies select namespaces by labels.
+			if !reflect.DeepEqual(oldNamespace.GetLabels(), newNamespace.GetLabels()) {
+				w.enqueueLoadBalancerServices()
+			}
+		},
+	})
+	w.namespaceLister = namespaceInformer.Lister()
+
+	nodeInformer := factory.Core().V1().Nodes()
+	nodeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(_ interface{}) { w.enqueueLoadBalancerServices() },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldNode, ok := oldObj.(*v1.Node)
+			if !ok {
+				return
+			}
+			newNode, ok := newObj.(*v1.Node)
+			if !ok {
+				return
+			}
+			if w.trackNodeExclusion(oldNode, newNode) {
+				w.enqueueLoadBalancerServices()
+				// Drop the node from the backends once its grace period is over.
+				if w.nodeExclusionReason(newNode) != "" {
+					w.enqueueLoadBalancerServicesAfter(w.nodeGracePeriod())
+				}
+				return
+			}
+			if nodeInternalIP(oldNode, v1.IPv4Protocol) != nodeInternalIP(newNode, v1.IPv4Protocol) ||
+				nodeInternalIP(oldNode, v1.IPv6Protocol) != nodeInternalIP(newNode, v1.IPv6Protocol) {
+				w.enqueueLoadBalancerServices()
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if node, ok := obj.(*v1.Node); ok {
+				w.forgetNode(node)
+			}
+			w.enqueueLoadBalancerServices()
+		},
+	})
+	w.nodeLister = nodeInformer.Lister()
+
+	endpointSliceInformer := dynamicFactory.ForResource(endpointSliceResource)
+	endpointSliceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    w.enqueueEndpointSliceService,
+		UpdateFunc: func(_, obj interface{}) { w.enqueueEndpointSliceService(obj) },
+		DeleteFunc: w.enqueueEndpointSliceService,
+	})
+	w.endpointSliceLister = endpointSliceInformer.Lister()
+
+	l4lbInformer, err := w.MGR.GetCache().GetInformer(cntxt, &k8sv1alpha1.L4LB{})
+	if err != nil {
+		return fmt.Errorf("{setupInformers} %s", err)
+	}
+	l4lbInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    w.enqueueL4LBService,
+		UpdateFunc: func(_, obj interface{}) { w.enqueueL4LBService(obj) },
+		DeleteFunc: w.enqueueL4LBService,
+	})
+
+	policyInformer, err := w.MGR.GetCache().GetInformer(cntxt, &k8sv1alpha1.LoadBalancerPolicy{})
+	if err != nil {
+		return fmt.Errorf("{setupInformers} %s", err)
+	}
+	policyInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(_ interface{}) { w.enqueueLoadBalancerServices() },
+		UpdateFunc: func(_, _ interface{}) { w.enqueueLoadBalancerServices() },
+		DeleteFunc: func(_ interface{}) { w.enqueueLoadBalancerServices() },
+	})
+
+	clusterPolicyInformer, err := w.MGR.GetCache().GetInformer(cntxt, &k8sv1alpha1.ClusterLoadBalancerPolicy{})
+	if err != nil {
+		return fmt.Errorf("{setupInformers} %s", err)
+	}
+	clusterPolicyInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(_ interface{}) { w.enqueueLoadBalancerServices() },
+		UpdateFunc: func(_, _ interface{}) { w.enqueueLoadBalancerServices() },
+		DeleteFunc: func(_ interface{}) { w.enqueueLoadBalancerServices() },
+	})
+
+	factory.Start(stop)
+	dynamicFactory.Start(stop)
+	for informer, synced := range factory.WaitForCacheSync(stop) {
+		if !synced {
+			return fmt.Errorf("{setupInformers} failed to sync %s informer", informer)
+		}
+	}
+	for resource, synced := range dynamicFactory.WaitForCacheSync(stop) {
+		if !synced {
+			return fmt.Errorf("{setupInformers} failed to sync %s informer", resource.Resource)
+		}
+	}
+	if !w.MGR.GetCache().WaitForCacheSync(stop) {
+		return fmt.Errorf("{setupInformers} failed to sync L4LB and policy informers")
+	}
+	return nil
+}
+
+func (w *Watcher) enqueueService(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		logger.Error(fmt.Errorf("{enqueueService} %s", err), "")
+		return
+	}
+	w.queue.Add(key)
+}
+
+// enqueueLoadBalancerServices enqueues every LoadBalancer Service, used when the node set changes.
+func (w *Watcher) enqueueLoadBalancerServices() {
+	w.enqueueLoadBalancerServicesAfter(0)
+}
+
+func (w *Watcher) enqueueLoadBalancerServicesAfter(duration time.Duration) {
+	services, err := w.listServices()
+	if err != nil {
+		logger.Error(fmt.Errorf("{enqueueLoadBalancerServices} %s", err), "")
+		return
+	}
+	for _, svc := range services {
+		if svc.Spec.Type != v1.ServiceTypeLoadBalancer {
+			continue
+		}
+		key, err := cache.MetaNamespaceKeyFunc(svc)
+		if err != nil {
+			logger.Error(fmt.Errorf("{enqueueLoadBalancerServices} %s", err), "")
+			continue
+		}
+		w.queue.AddAfter(key, duration)
+	}
+}
+
+func (w *Watcher) enqueueEndpointSliceService(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	slice, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	if name := slice.GetLabels()[discoveryv1beta1.LabelServiceName]; name != "" {
+		w.queue.Add(fmt.Sprintf("%s/%s", slice.GetNamespace(), name))
+	}
+}
+
+func (w *Watcher) enqueueL4LBService(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	lb, ok := obj.(*k8sv1alpha1.L4LB)
+	if !ok {
+		return
+	}
+	if lb.GetServiceName() != "" && lb.GetServiceNamespace() != "" {
+		w.queue.Add(fmt.Sprintf("%s/%s", lb.GetServiceNamespace(), lb.GetServiceName()))
+	}
+}
+
+func (w *Watcher) processNextItem() bool {
+	key, quit := w.queue.Get()
+	if quit {
+		return false
+	}
+	defer w.queue.Done(key)
+
+	errors := w.loadBalancerProcess(key.(string))
+	for _, err := range errors {
+		logger.Error(err, "", "service", key)
+	}
+	if len(errors) > 0 {
+		w.queue.AddRateLimited(key)
+		return true
+	}
+	w.queue.Forget(key)
+	return true
+}
+
 func filterL4LBs(LBs []k8sv1alpha1.L4LB) []k8sv1alpha1.L4LB {
 	lbList := []k8sv1alpha1.L4LB{}
 	for _, lb := range LBs {
@@ -108,37 +337,112 @@ func filterL4LBs(LBs []k8sv1alpha1.L4LB) []k8sv1alpha1.L4LB {
 	return lbList
 }
 
-func (w *Watcher) loadBalancerProcess(clientset *kubernetes.Clientset, cl client.Client, recorder record.EventRecorder) {
-	debugLogger.Info("Generating load balancers from k8s...")
+// loadBalancerProcess reconciles the L4LBs of a single Service identified by its namespace/name key.
+func (w *Watcher) loadBalancerProcess(key string) []error {
+	debugLogger.Info("Generating load balancers from k8s...", "service", key)
 	var errors []error = nil
-	lbTimeout := "2000"
 
-	l4lbs, err := getL4LBs(cl)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
-		logger.Error(err, "")
+		return []error{fmt.Errorf("{loadBalancerProcess} %s", err)}
 	}
 
-	if l4lbs == nil {
-		logger.Error(fmt.Errorf("CRD Not found"), "")
-		return
+	svc, svcExt, err := w.getService(namespace, name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return []error{fmt.Errorf("{loadBalancerProcess} %s", err)}
+		}
+		svc = nil
+	}
+
+	l4lbs, err := getL4LBs(w.client, namespace)
+	if err != nil {
+		return []error{err}
 	}
 
-	filteerdL4LBs := filterL4LBs(l4lbs.Items)
+	serviceL4LBs := []k8sv1alpha1.L4LB{}
+	for _, lb := range filterL4LBs(l4lbs.Items) {
+		if lb.GetServiceNamespace() == namespace && lb.GetServiceName() == name {
+			serviceL4LBs = append(serviceL4LBs, lb)
+		}
+	}
 
 	ipAuto := make(map[string]string)
-	for _, lb := range filteerdL4LBs {
+	for _, lb := range serviceL4LBs {
 		if uid := lb.GetServiceUID(); uid != "" {
-			ipAuto[uid] = lb.Spec.Frontend.IP
+			ipAuto[autoIPKey(uid, lb.Spec.Site, lbFamily(&lb))] = lb.Spec.Frontend.IP
 		}
 	}
 
-	serviceLBs, err := w.generateLoadBalancers(clientset, ipAuto, lbTimeout)
-	if err != nil {
-		logger.Error(err, "")
-		return
+	serviceLBs := []*k8sv1alpha1.L4LB{}
+	sharedLBs := []k8sv1alpha1.L4LB{}
+	claimed := svc != nil && svc.Spec.Type == v1.ServiceTypeLoadBalancer && w.claimsLoadBalancerClass(svcExt.LoadBalancerClass)
+	if claimed {
+		policy, err := w.servicePolicy(svc)
+		if err != nil {
+			return []error{err}
+		}
+		params, warnings := w.serviceLBParams(svc, policy)
+		for _, warning := range warnings {
+			if err := createEvent(w.clientset, w.recorder, svc.GetNamespace(), svc.GetName(), "InvalidAnnotation", warning); err != nil {
+				errors = append(errors, fmt.Errorf("{lbEventsPatcher} %s", err))
+			}
+		}
+		if notAllowed := frontendIPsAllowed(params); notAllowed != "" {
+			logger.Info("Service rejected", "service", key, "reason", notAllowed)
+			if err := createEvent(w.clientset, w.recorder, svc.GetNamespace(), svc.GetName(), "FrontendIPNotAllowed", notAllowed); err != nil {
+				errors = append(errors, fmt.Errorf("{lbEventsPatcher} %s", err))
+			}
+			return errors
+		}
+		// Automatically assigned IPs can't be steered into the subnet, they are reported only.
+		for _, lb := range serviceL4LBs {
+			if ip := net.ParseIP(lb.Spec.Frontend.IP); ip != nil && params.FrontendSubnet != nil && !params.FrontendSubnet.Contains(ip) {
+				message := fmt.Sprintf("assigned frontend IP %s is outside of the allowed subnet %s", ip, params.FrontendSubnet.String())
+				if err := createEvent(w.clientset, w.recorder, svc.GetNamespace(), svc.GetName(), "FrontendIPNotAllowed", message); err != nil {
+					errors = append(errors, fmt.Errorf("{lbEventsPatcher} %s", err))
+				}
+				break
+			}
+		}
+
+		if params.SharedIPKey != "" {
+			sharedLBs, err = w.getSharedL4LBs(params.SharedIPKey, string(svc.GetUID()))
+			if err != nil {
+				return []error{err}
+			}
+			if len(serviceL4LBs) == 0 && sharedIPPending(sharedLBs) {
+				debugLogger.Info("Waiting for the shared frontend IP", "service", key, "key", params.SharedIPKey)
+				return errors
+			}
+			for _, lb := range sharedLBs {
+				ipKey := autoIPKey(string(svc.GetUID()), lb.Spec.Site, lbFamily(&lb))
+				if _, ok := ipAuto[ipKey]; !ok && lb.Spec.Frontend.IP != "" {
+					ipAuto[ipKey] = lb.Spec.Frontend.IP
+				}
+			}
+		}
+
+		serviceLBs, err = w.generateLoadBalancers(svc, serviceIPFamilies(svc, svcExt), params, ipAuto, serviceL4LBs)
+		if err != nil {
+			if multiSiteErr, ok := err.(*multiSiteError); ok {
+				logger.Info("Service rejected", "service", key, "reason", multiSiteErr.Error())
+				if err := createEvent(w.clientset, w.recorder, svc.GetNamespace(), svc.GetName(), "MultipleSites", multiSiteErr.Error()); err != nil {
+					errors = append(errors, fmt.Errorf("{lbEventsPatcher} %s", err))
+				}
+				return errors
+			}
+			return []error{err}
+		}
 	}
 
-	lbsToCreate, lbsToUpdate, lbsToDelete, ingressIPsMap := compareLoadBalancers(filteerdL4LBs, serviceLBs)
+	lbsToCreate, lbsToUpdate, lbsToDelete, ingressIPsMap, conflicts := compareLoadBalancers(serviceL4LBs, serviceLBs, sharedLBs)
+	for _, conflict := range conflicts {
+		logger.Info("Shared IP conflict", "service", key, "conflict", conflict)
+		if err := createEvent(w.clientset, w.recorder, namespace, name, "SharedIPConflict", conflict); err != nil {
+			errors = append(errors, fmt.Errorf("{lbEventsPatcher} %s", err))
+		}
+	}
 
 	js, _ := json.Marshal(lbsToCreate)
 	debugLogger.Info("Load balancers for create", "List", string(js))
@@ -149,20 +453,13 @@ func (w *Watcher) loadBalancerProcess(clientset *kubernetes.Clientset, cl client
 	js, _ = json.Marshal(ingressIPsMap)
 	debugLogger.Info("Ingress addresses for k8s", "List", string(js))
 
-	lbsByUID := make(map[string][]*k8sv1alpha1.L4LB)
-	for _, lb := range lbsToCreate {
-		lbsByUID[lb.GetServiceUID()] = append(lbsByUID[lb.GetServiceUID()], lb)
-	}
+	errors = append(errors, deleteL4LBs(w.client, lbsToDelete)...)
 
-	errors = append(errors, deleteL4LBs(cl, lbsToDelete)...)
-
-	errs := updateL4LBs(cl, lbsToUpdate, ipAuto)
+	errs := updateL4LBs(w.client, lbsToUpdate, ipAuto)
 	errors = append(errors, errs...)
 
-	for _, lbs := range lbsByUID {
-		errs = createL4LBs(cl, lbs, ipAuto)
-		errors = append(errors, errs...)
-	}
+	errs = createL4LBs(w.client, lbsToCreate, ipAuto)
+	errors = append(errors, errs...)
 
 	for _, serviceLB := range serviceLBs {
 		ingressIPs := []string{}
@@ -170,25 +467,34 @@ func (w *Watcher) loadBalancerProcess(clientset *kubernetes.Clientset, cl client
 			for ip := range ingress {
 				ingressIPs = append(ingressIPs, ip)
 			}
-			_, err := assignIngress(clientset, ingressIPs, serviceLB.GetServiceNamespace(), serviceLB.GetServiceName())
+			_, err := assignIngress(w.clientset, ingressIPs, serviceLB.GetServiceNamespace(), serviceLB.GetServiceName())
 			if err != nil {
 				errors = append(errors, err)
 			}
+			break
 		}
 	}
 
-	for _, lb := range l4lbs.Items {
+	for _, lb := range serviceL4LBs {
 		if lb.Status.Status == "Failure" {
-			err := createEvent(clientset, recorder, lb.GetServiceNamespace(), lb.GetServiceName(), lb.Status.Status, lb.Status.Message)
+			err := createEvent(w.clientset, w.recorder, lb.GetServiceNamespace(), lb.GetServiceName(), lb.Status.Status, lb.Status.Message)
 			if err != nil {
 				errors = append(errors, fmt.Errorf("{lbEventsPatcher} %s", err))
 			}
 		}
 	}
 
-	for _, err := range errors {
-		logger.Error(err, "")
+	if claimed {
+		state, message := serviceLBState(serviceL4LBs, serviceLBs, len(lbsToCreate)+len(lbsToUpdate) > 0)
+		if len(conflicts) > 0 {
+			state, message = serviceStateDegraded, strings.Join(conflicts, "; ")
+		}
+		if err := w.publishServiceState(svc, state, message); err != nil {
+			errors = append(errors, err)
+		}
 	}
+
+	return errors
 }
 
 func deleteL4LBs(cl client.Client, lbs []k8sv1alpha1.L4LB) []error {
@@ -242,8 +548,13 @@ func updateL4LB(cl client.Client, lb k8sv1alpha1.L4LB) error {
 	return cl.Update(ctx, lb.DeepCopyObject(), &client.UpdateOptions{})
 }
 
-func compareLoadBalancers(LBs []k8sv1alpha1.L4LB, serviceLBs []*k8sv1alpha1.L4LB) ([]*k8sv1alpha1.L4LB, []k8sv1alpha1.L4LB, []k8sv1alpha1.L4LB, map[string]map[string]int) {
+// compareLoadBalancers compares the existing L4LBs of a Service with the generated ones.
+// sharedLBs are the L4LBs of other Services sharing the frontend IP, generated L4LBs whose
+// frontend port is already used by them are neither created nor updated and are returned as conflicts.
+func compareLoadBalancers(LBs []k8sv1alpha1.L4LB, serviceLBs []*k8sv1alpha1.L4LB, sharedLBs []k8sv1alpha1.L4LB) ([]*k8sv1alpha1.L4LB, []k8sv1alpha1.L4LB, []k8sv1alpha1.L4LB, map[string]map[string]int, []string) {
 	LBsMap := map[string]k8sv1alpha1.L4LB{}
+	sharedFrontends := map[string]k8sv1alpha1.L4LB{}
+	conflicts := []string{}
 	IPsMap := make(map[string]string)
 	serviceIngressMap := map[string]map[string]int{}
 	lbIngressMap := map[string]map[string]int{}
@@ -265,10 +576,14 @@ func compareLoadBalancers(LBs []k8sv1alpha1.L4LB, serviceLBs []*k8sv1alpha1.L4LB
 		}
 	}
 
+	for _, lb := range sharedLBs {
+		sharedFrontends[frontendKey(&lb)] = lb
+	}
+
 	for _, lb := range LBs {
 		LBsMap[lb.Name] = lb
 		if l, ok := serviceLBsMap[lb.Name]; ok {
-			IPsMap[l.GetServiceUID()] = lb.Spec.Frontend.IP
+			IPsMap[autoIPKey(l.GetServiceUID(), l.Spec.Site, lbFamily(l))] = lb.Spec.Frontend.IP
 		}
 	}
 
@@ -291,8 +606,28 @@ func compareLoadBalancers(LBs []k8sv1alpha1.L4LB, serviceLBs []*k8sv1alpha1.L4LB
 					update = true
 				}
 
-				if serviceLB.Spec.Check.Timeout != lb.Spec.Check.Timeout {
-					lb.Spec.Check.Timeout = serviceLB.Spec.Check.Timeout
+				if serviceLB.Spec.Check != lb.Spec.Check {
+					lb.Spec.Check = serviceLB.Spec.Check
+					update = true
+				}
+
+				if serviceLB.Spec.State != lb.Spec.State {
+					lb.Spec.State = serviceLB.Spec.State
+					update = true
+				}
+
+				if serviceLB.Spec.OwnerTenant != "" && serviceLB.Spec.OwnerTenant != lb.Spec.OwnerTenant {
+					lb.Spec.OwnerTenant = serviceLB.Spec.OwnerTenant
+					update = true
+				}
+
+				if serviceLB.Spec.VPC != lb.Spec.VPC {
+					lb.Spec.VPC = serviceLB.Spec.VPC
+					update = true
+				}
+
+				if serviceLB.Spec.Site != "" && serviceLB.Spec.Site != lb.Spec.Site {
+					lb.Spec.Site = serviceLB.Spec.Site
 					update = true
 				}
 
@@ -300,13 +635,26 @@ func compareLoadBalancers(LBs []k8sv1alpha1.L4LB, serviceLBs []*k8sv1alpha1.L4LB
 					lb.Spec.Backend = serviceLB.Spec.Backend
 					update = true
 				}
+
+				if serviceLB.GetSharedIPKey() != lb.GetSharedIPKey() {
+					lb.SetSharedIPKey(serviceLB.GetSharedIPKey())
+					update = true
+				}
 				if update {
+					if conflict := sharedFrontendConflict(&lb, sharedFrontends); conflict != "" {
+						conflicts = append(conflicts, conflict)
+						continue
+					}
 					lbsToUpdate = append(lbsToUpdate, lb)
 				}
 			} else {
-				if ip, ok := IPsMap[serviceLB.GetServiceUID()]; ok {
+				if ip, ok := IPsMap[autoIPKey(serviceLB.GetServiceUID(), serviceLB.Spec.Site, lbFamily(serviceLB))]; ok {
 					serviceLB.Spec.Frontend.IP = ip
 				}
+				if conflict := sharedFrontendConflict(serviceLB, sharedFrontends); conflict != "" {
+					conflicts = append(conflicts, conflict)
+					continue
+				}
 				lbsToCreate = append(lbsToCreate, serviceLB)
 			}
 		}
@@ -328,7 +676,8 @@ func compareLoadBalancers(LBs []k8sv1alpha1.L4LB, serviceLBs []*k8sv1alpha1.L4LB
 		}
 	}
 
-	return lbsToCreate, lbsToUpdate, lbsToDelete, ingressToUpdate
+	sort.Strings(conflicts)
+	return lbsToCreate, lbsToUpdate, lbsToDelete, ingressToUpdate, conflicts
 }
 
 func compareBackends(lbBackends []k8sv1alpha1.L4LBBackend, serviceLBBackends []k8sv1alpha1.L4LBBackend) bool {
@@ -345,12 +694,12 @@ func compareBackends(lbBackends []k8sv1alpha1.L4LBBackend, serviceLBBackends []k
 	return reflect.DeepEqual(lbBackendMap, serviceLBBackendMap)
 }
 
-func getL4LBs(cl client.Client) (*k8sv1alpha1.L4LBList, error) {
+func getL4LBs(cl client.Client, namespace string) (*k8sv1alpha1.L4LBList, error) {
 	l4lb := &k8sv1alpha1.L4LBList{}
 
 	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
 	defer cancel()
-	err := cl.List(ctx, l4lb, &client.ListOptions{})
+	err := cl.List(ctx, l4lb, &client.ListOptions{Namespace: namespace})
 	if err != nil {
 		return nil, fmt.Errorf("{getL4LBs} %s", err)
 	}
@@ -358,128 +707,118 @@ func getL4LBs(cl client.Client) (*k8sv1alpha1.L4LBList, error) {
 	return l4lb, nil
 }
 
-func (w *Watcher) generateLoadBalancers(clientset *kubernetes.Clientset, autoIPs map[string]string, lbTimeout string) ([]*k8sv1alpha1.L4LB, error) {
+// generateLoadBalancers builds the L4LBs of the Service: one per port, IP family and site.
+// When no endpoint of a family is ready, the existing L4LBs of that family keep their last backends.
+func (w *Watcher) generateLoadBalancers(svc *v1.Service, families []v1.IPFamily, params lbParams, autoIPs map[string]string, existing []k8sv1alpha1.L4LB) ([]*k8sv1alpha1.L4LB, error) {
 	lbList := []*k8sv1alpha1.L4LB{}
-	serviceList, err := getServices(clientset, "")
-	if err != nil {
-		return lbList, fmt.Errorf("{generateLoadBalancers} %s", err)
-	}
 
-	timeout, err := strconv.Atoi(lbTimeout)
-	if err != nil {
-		return lbList, fmt.Errorf("{generateLoadBalancers} %s", err)
+	if svc.Spec.Type != v1.ServiceTypeLoadBalancer {
+		return lbList, nil
 	}
 
-	var siteName string
-
-	for _, svc := range serviceList.Items {
-		if svc.Spec.Type == "LoadBalancer" {
-			selectors := []string{}
-			hostIPs := map[string]int{}
-			for key, value := range svc.Spec.Selector {
-				selectors = append(selectors, fmt.Sprintf("%s=%s", key, value))
-			}
-
-			debugLogger.Info("Getting k8s pods...", "service", svc.Name, "namespace", svc.Namespace)
-			podList, err := getPodsByLabelSeector(clientset, svc.Namespace, strings.Join(selectors, ","))
-			if err != nil {
-				return lbList, fmt.Errorf("{generateLoadBalancers} %s", err)
-			}
+	var ingressIPs []string
 
-			for _, pod := range podList.Items {
-				if pod.Status.HostIP != "" {
-					hostIPs[pod.Status.HostIP] = 1
-				}
-			}
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		ingressIPs = append(ingressIPs, ingress.IP)
+	}
+	ingressIPsString := strings.Join(ingressIPs, ",")
 
-			var lbIPs []lbIP
+	for _, family := range families {
+		debugLogger.Info("Getting k8s endpoints...", "service", svc.Name, "namespace", svc.Namespace, "family", family)
+		hostIPS, err := w.getServiceBackendIPs(svc, family)
+		if err != nil {
+			return lbList, fmt.Errorf("{generateLoadBalancers} %s", err)
+		}
 
-			var ingressIPs []string
+		var lbIPs []lbIP
 
-			for _, ingress := range svc.Status.LoadBalancer.Ingress {
-				ingressIPs = append(ingressIPs, ingress.IP)
+		for _, port := range svc.Spec.Ports {
+			lbIP := lbIP{
+				Name:     port.Name,
+				IP:       params.LoadBalancerIPs[family],
+				Port:     int(port.Port),
+				NodePort: int(port.NodePort),
+				Protocol: string(port.Protocol),
 			}
-			ingressIPsString := strings.Join(ingressIPs, ",")
-
-			for _, port := range svc.Spec.Ports {
-				lbIP := lbIP{
-					Name:     port.Name,
-					IP:       svc.Spec.LoadBalancerIP,
-					Port:     int(port.Port),
-					NodePort: int(port.NodePort),
-					Protocol: string(port.Protocol),
-				}
-				if len(svc.Spec.LoadBalancerIP) > 0 {
-					lbIP.IP = svc.Spec.LoadBalancerIP
-				} else {
-					lbIP.Automatic = true
-				}
-				lbIPs = append(lbIPs, lbIP)
+			if lbIP.IP == "" {
+				lbIP.Automatic = true
 			}
+			lbIPs = append(lbIPs, lbIP)
+		}
 
-			var hostIPS []string
-
-			for hostIP := range hostIPs {
-				hostIPS = append(hostIPS, hostIP)
+		groups := w.groupBackendsBySite(hostIPS, params.Site)
+		if len(groups) == 0 {
+			debugLogger.Info("No ready endpoints, keeping the last backends", "service", svc.Name, "namespace", svc.Namespace, "family", family)
+			groups = lastBackendGroups(existing, family)
+		}
+		if len(groups) > 1 {
+			if params.MultiSitePolicy != multiSitePolicySplit {
+				return lbList, &multiSiteError{sites: siteNames(groups), reason: fmt.Sprintf("rejected by %s policy, set %s to %s to create one load balancer per site", params.MultiSitePolicy, annotationMultiSitePolicy, multiSitePolicySplit)}
 			}
+			if params.LoadBalancerIPs[family] != "" {
+				return lbList, &multiSiteError{sites: siteNames(groups), reason: "loadBalancerIP can not be used for more than one site"}
+			}
+		}
 
-			if len(lbIPs) > 0 && len(hostIPS) > 0 {
-				for i, lbIP := range lbIPs {
-					frontendIP := lbIP.IP
-					if lbIP.IP == "" {
-						if ip, ok := autoIPs[string(svc.GetUID())]; ok && ip != "" {
-							frontendIP = ip
-						} else if i > 0 {
-							break
-						}
-					}
-					backends := []k8sv1alpha1.L4LBBackend{}
-					for _, hostIP := range hostIPS {
-						if siteName == "" {
-							site, _, err := w.findSiteByIP(hostIP)
-							if err != nil {
-								fmt.Println(err)
-							}
-							siteName = site.Name
-						}
-						backend := fmt.Sprintf("%s:%d", hostIP, lbIP.NodePort)
-						backends = append(backends, k8sv1alpha1.L4LBBackend(backend))
+		for _, group := range groups {
+			for i, lbIP := range lbIPs {
+				frontendIP := lbIP.IP
+				if lbIP.IP == "" {
+					if ip, ok := autoIPs[autoIPKey(string(svc.GetUID()), group.Site, family)]; ok && ip != "" {
+						frontendIP = ip
+					} else if i > 0 {
+						break
 					}
+				}
+				backends := []k8sv1alpha1.L4LBBackend{}
+				for _, hostIP := range group.IPs {
+					backend := net.JoinHostPort(hostIP, strconv.Itoa(lbIP.NodePort))
+					backends = append(backends, k8sv1alpha1.L4LBBackend(backend))
+				}
 
-					lb := &k8sv1alpha1.L4LB{
-						ObjectMeta: metav1.ObjectMeta{
-							Name:        strings.ToLower(fmt.Sprintf("%s-%s-%s-%s-%d", svc.GetName(), svc.GetNamespace(), svc.GetUID(), lbIP.Protocol, lbIP.Port)),
-							Namespace:   svc.GetNamespace(),
-							Annotations: make(map[string]string),
-						},
-						TypeMeta: metav1.TypeMeta{
-							Kind:       "L4LB",
-							APIVersion: "k8s.netris.ai/v1alpha1",
+				name := strings.ToLower(fmt.Sprintf("%s-%s-%s-%s-%d", svc.GetName(), svc.GetNamespace(), svc.GetUID(), lbIP.Protocol, lbIP.Port))
+				if len(groups) > 1 {
+					name = fmt.Sprintf("%s-%s", name, siteSuffix(group.Site))
+				}
+				name += familySuffix(family)
+
+				lb := &k8sv1alpha1.L4LB{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        name,
+						Namespace:   svc.GetNamespace(),
+						Annotations: make(map[string]string),
+					},
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "L4LB",
+						APIVersion: "k8s.netris.ai/v1alpha1",
+					},
+					Spec: k8sv1alpha1.L4LBSpec{
+						Site:        group.Site,
+						OwnerTenant: params.Tenant,
+						VPC:         params.VPC,
+						Protocol:    strings.ToLower(lbIP.Protocol),
+						Frontend: k8sv1alpha1.L4LBFrontend{
+							Port: lbIP.Port,
+							IP:   frontendIP,
 						},
-						Spec: k8sv1alpha1.L4LBSpec{
-							Site:     siteName,
-							Protocol: strings.ToLower(lbIP.Protocol),
-							Frontend: k8sv1alpha1.L4LBFrontend{
-								Port: lbIP.Port,
-								IP:   frontendIP,
-							},
-							State: "active",
-							Check: k8sv1alpha1.L4LBCheck{
-								Type:    "tcp",
-								Timeout: timeout,
-							},
-							Backend: backends,
+						State: params.State,
+						Check: k8sv1alpha1.L4LBCheck{
+							Type:        params.CheckType,
+							Timeout:     params.CheckTimeout,
+							RequestPath: params.CheckPath,
 						},
-					}
+						Backend: backends,
+					},
+				}
 
-					lb.SetServiceName(svc.GetName())
-					lb.SetServiceNamespace(svc.GetNamespace())
-					lb.SetServiceUID(string(svc.GetUID()))
-					lb.SetServiceIngressIPs(ingressIPsString)
-					lb.SetImportFlag("true")
+				lb.SetServiceName(svc.GetName())
+				lb.SetServiceNamespace(svc.GetNamespace())
+				lb.SetServiceUID(string(svc.GetUID()))
+				lb.SetServiceIngressIPs(ingressIPsString)
+				lb.SetSharedIPKey(params.SharedIPKey)
+				lb.SetImportFlag("true")
 
-					lbList = append(lbList, lb)
-				}
+				lbList = append(lbList, lb)
 			}
 		}
 	}
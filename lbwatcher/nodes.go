@@ -0,0 +1,90 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lbwatcher
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const defaultExcludeNodeLabel = "node.kubernetes.io/exclude-from-external-load-balancers"
+
+// nodeExclusionReason returns the reason the node should not receive load balancer traffic,
+// or an empty string if it should.
+func (w *Watcher) nodeExclusionReason(node *v1.Node) string {
+	if !nodeReady(node) {
+		return "NotReady"
+	}
+	if node.Spec.Unschedulable {
+		return "Unschedulable"
+	}
+	label := w.Options.ExcludeNodeLabel
+	if label == "" {
+		label = defaultExcludeNodeLabel
+	}
+	if _, ok := node.GetLabels()[label]; ok {
+		return "Excluded"
+	}
+	return ""
+}
+
+// nodeEligible reports whether the node can be an L4LB backend. Excluded nodes are kept
+// until the grace period passes, so short condition flaps don't reconfigure the load balancers.
+func (w *Watcher) nodeEligible(node *v1.Node) bool {
+	if w.nodeExclusionReason(node) == "" {
+		return true
+	}
+	w.nodesMu.Lock()
+	since, ok := w.excludedNodes[node.GetName()]
+	w.nodesMu.Unlock()
+	if !ok {
+		// The node was already excluded when the watcher started.
+		return false
+	}
+	return time.Since(since) < w.nodeGracePeriod()
+}
+
+// trackNodeExclusion records when the node got excluded and reports whether its exclusion changed.
+func (w *Watcher) trackNodeExclusion(oldNode, newNode *v1.Node) bool {
+	oldExcluded := w.nodeExclusionReason(oldNode) != ""
+	newExcluded := w.nodeExclusionReason(newNode) != ""
+	if oldExcluded == newExcluded {
+		return false
+	}
+	w.nodesMu.Lock()
+	defer w.nodesMu.Unlock()
+	if newExcluded {
+		w.excludedNodes[newNode.GetName()] = time.Now()
+	} else {
+		delete(w.excludedNodes, newNode.GetName())
+	}
+	return true
+}
+
+func (w *Watcher) forgetNode(node *v1.Node) {
+	w.nodesMu.Lock()
+	defer w.nodesMu.Unlock()
+	delete(w.excludedNodes, node.GetName())
+}
+
+func (w *Watcher) nodeGracePeriod() time.Duration {
+	if w.Options.NodeGracePeriod <= 0 {
+		return 0
+	}
+	return time.Duration(w.Options.NodeGracePeriod) * time.Second
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lbwatcher
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testNode(name string, ready bool, change func(node *v1.Node)) *v1.Node {
+	status := v1.ConditionTrue
+	if !ready {
+		status = v1.ConditionFalse
+	}
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{}},
+		Status:     v1.NodeStatus{Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: status}}},
+	}
+	if change != nil {
+		change(node)
+	}
+	return node
+}
+
+func TestNodeExclusionReason(t *testing.T) {
+	tests := []struct {
+		name         string
+		excludeLabel string
+		node         *v1.Node
+		want         string
+	}{
+		{
+			name: "ready",
+			node: testNode("node1", true, nil),
+		},
+		{
+			name: "not ready",
+			node: testNode("node1", false, nil),
+			want: "NotReady",
+		},
+		{
+			name: "without ready condition",
+			node: testNode("node1", true, func(node *v1.Node) { node.Status.Conditions = nil }),
+			want: "NotReady",
+		},
+		{
+			name: "cordoned",
+			node: testNode("node1", true, func(node *v1.Node) { node.Spec.Unschedulable = true }),
+			want: "Unschedulable",
+		},
+		{
+			name: "default exclusion label",
+			node: testNode("node1", true, func(node *v1.Node) { node.Labels[defaultExcludeNodeLabel] = "" }),
+			want: "Excluded",
+		},
+		{
+			name:         "custom exclusion label",
+			excludeLabel: "example.com/no-lb",
+			node:         testNode("node1", true, func(node *v1.Node) { node.Labels["example.com/no-lb"] = "true" }),
+			want:         "Excluded",
+		},
+		{
+			name:         "default label ignored with a custom exclusion label",
+			excludeLabel: "example.com/no-lb",
+			node:         testNode("node1", true, func(node *v1.Node) { node.Labels[defaultExcludeNodeLabel] = "" }),
+		},
+		{
+			name: "not ready takes precedence",
+			node: testNode("node1", false, func(node *v1.Node) { node.Spec.Unschedulable = true }),
+			want: "NotReady",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Watcher{Options: Options{ExcludeNodeLabel: tt.excludeLabel}}
+			if got := w.nodeExclusionReason(tt.node); got != tt.want {
+				t.Errorf("nodeExclusionReason = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeGracePeriod(t *testing.T) {
+	for seconds, want := range map[int]time.Duration{-1: 0, 0: 0, 30: 30 * time.Second} {
+		w := &Watcher{Options: Options{NodeGracePeriod: seconds}}
+		if got := w.nodeGracePeriod(); got != want {
+			t.Errorf("nodeGracePeriod(%d) = %v, want %v", seconds, got, want)
+		}
+	}
+}
+
+func TestNodeEligible(t *testing.T) {
+	ready := testNode("node1", true, nil)
+	notReady := testNode("node1", false, nil)
+	cordoned := testNode("node1", true, func(node *v1.Node) { node.Spec.Unschedulable = true })
+
+	newWatcher := func(grace int) *Watcher {
+		return &Watcher{Options: Options{NodeGracePeriod: grace}, excludedNodes: map[string]time.Time{}}
+	}
+
+	t.Run("ready node", func(t *testing.T) {
+		w := newWatcher(30)
+		if !w.nodeEligible(ready) {
+			t.Error("ready node is not eligible")
+		}
+	})
+
+	t.Run("excluded before the watcher started", func(t *testing.T) {
+		w := newWatcher(30)
+		if w.nodeEligible(notReady) {
+			t.Error("node excluded before the watcher started is eligible")
+		}
+	})
+
+	t.Run("without grace period", func(t *testing.T) {
+		w := newWatcher(0)
+		if !w.trackNodeExclusion(ready, notReady) {
+			t.Fatal("exclusion change not reported")
+		}
+		if w.nodeEligible(notReady) {
+			t.Error("excluded node is eligible without grace period")
+		}
+	})
+
+	t.Run("inside the grace period", func(t *testing.T) {
+		w := newWatcher(30)
+		w.trackNodeExclusion(ready, cordoned)
+		if !w.nodeEligible(cordoned) {
+			t.Error("cordoned node is not eligible inside the grace period")
+		}
+	})
+
+	t.Run("grace period expired", func(t *testing.T) {
+		w := newWatcher(30)
+		w.trackNodeExclusion(ready, notReady)
+		w.excludedNodes["node1"] = time.Now().Add(-31 * time.Second)
+		if w.nodeEligible(notReady) {
+			t.Error("node is eligible after the grace period expired")
+		}
+	})
+
+	t.Run("recovered inside the grace period", func(t *testing.T) {
+		w := newWatcher(30)
+		w.trackNodeExclusion(ready, notReady)
+		if !w.trackNodeExclusion(notReady, ready) {
+			t.Fatal("recovery not reported")
+		}
+		if _, ok := w.excludedNodes["node1"]; ok {
+			t.Error("recovered node is still tracked as excluded")
+		}
+		if !w.nodeEligible(ready) {
+			t.Error("recovered node is not eligible")
+		}
+		// A new exclusion starts a new grace period.
+		w.trackNodeExclusion(ready, notReady)
+		if !w.nodeEligible(notReady) {
+			t.Error("node excluded again is not eligible inside the new grace period")
+		}
+	})
+}
+
+func TestTrackNodeExclusion(t *testing.T) {
+	ready := testNode("node1", true, nil)
+	notReady := testNode("node1", false, nil)
+	cordoned := testNode("node1", true, func(node *v1.Node) { node.Spec.Unschedulable = true })
+	labeled := testNode("node1", true, func(node *v1.Node) { node.Labels[defaultExcludeNodeLabel] = "" })
+
+	tests := []struct {
+		name         string
+		oldNode      *v1.Node
+		newNode      *v1.Node
+		changed      bool
+		wantExcluded bool
+	}{
+		{name: "stays ready", oldNode: ready, newNode: ready},
+		{name: "becomes not ready", oldNode: ready, newNode: notReady, changed: true, wantExcluded: true},
+		{name: "cordoned", oldNode: ready, newNode: cordoned, changed: true, wantExcluded: true},
+		{name: "exclusion label added", oldNode: ready, newNode: labeled, changed: true, wantExcluded: true},
+		{name: "exclusion reason changes", oldNode: notReady, newNode: cordoned},
+		{name: "recovers", oldNode: notReady, newNode: ready, changed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Watcher{excludedNodes: map[string]time.Time{}}
+			if got := w.trackNodeExclusion(tt.oldNode, tt.newNode); got != tt.changed {
+				t.Errorf("trackNodeExclusion = %v, want %v", got, tt.changed)
+			}
+			if _, ok := w.excludedNodes["node1"]; ok != tt.wantExcluded {
+				t.Errorf("excluded = %v, want %v", ok, tt.wantExcluded)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lbwatcher
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// lbPolicy is a LoadBalancerPolicy or a ClusterLoadBalancerPolicy that selects a Service.
+type lbPolicy struct {
+	Source     string
+	Namespaced bool
+	Name       string
+	Spec       k8sv1alpha1.LoadBalancerPolicySpec
+}
+
+// servicePolicy returns the policy that applies to the Service, or nil if there is none.
+// A LoadBalancerPolicy applies to the Services of its own namespace only, a ClusterLoadBalancerPolicy
+// to the Services of the namespaces selected by its namespaceSelector. If several policies select
+// the Service, the one with the highest priority wins, then the LoadBalancerPolicy.
+func (w *Watcher) servicePolicy(svc *v1.Service) (*lbPolicy, error) {
+	policies := &k8sv1alpha1.LoadBalancerPolicyList{}
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	if err := w.client.List(ctx, policies, &client.ListOptions{Namespace: svc.GetNamespace()}); err != nil {
+		return nil, fmt.Errorf("{servicePolicy} %s", err)
+	}
+	clusterPolicies := &k8sv1alpha1.ClusterLoadBalancerPolicyList{}
+	if err := w.client.List(ctx, clusterPolicies, &client.ListOptions{}); err != nil {
+		return nil, fmt.Errorf("{servicePolicy} %s", err)
+	}
+
+	matched := []lbPolicy{}
+	for _, policy := range policies.Items {
+		if policy.Spec.ServiceSelector != nil && !selectorMatches(policy.Spec.ServiceSelector, svc.GetLabels()) {
+			continue
+		}
+		matched = append(matched, lbPolicy{
+			Source:     fmt.Sprintf("LoadBalancerPolicy %s/%s", policy.GetNamespace(), policy.GetName()),
+			Namespaced: true,
+			Name:       policy.GetName(),
+			Spec:       policy.Spec,
+		})
+	}
+
+	if len(clusterPolicies.Items) > 0 {
+		var namespaceLabels labels.Set
+		if namespace, err := w.namespaceLister.Get(svc.GetNamespace()); err == nil {
+			namespaceLabels = namespace.GetLabels()
+		}
+		for _, policy := range clusterPolicies.Items {
+			if policy.Spec.NamespaceSelector != nil && !selectorMatches(policy.Spec.NamespaceSelector, namespaceLabels) {
+				continue
+			}
+			if policy.Spec.ServiceSelector != nil && !selectorMatches(policy.Spec.ServiceSelector, svc.GetLabels()) {
+				continue
+			}
+			matched = append(matched, lbPolicy{
+				Source: fmt.Sprintf("ClusterLoadBalancerPolicy %s", policy.GetName()),
+				Name:   policy.GetName(),
+				Spec:   policy.Spec.LoadBalancerPolicySpec,
+			})
+		}
+	}
+	if len(matched) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].Spec.Priority != matched[j].Spec.Priority {
+			return matched[i].Spec.Priority > matched[j].Spec.Priority
+		}
+		if matched[i].Namespaced != matched[j].Namespaced {
+			return matched[i].Namespaced
+		}
+		return matched[i].Name < matched[j].Name
+	})
+	return &matched[0], nil
+}
+
+func selectorMatches(labelSelector *metav1.LabelSelector, set labels.Set) bool {
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		debugLogger.Info("Invalid label selector", "error", err.Error())
+		return false
+	}
+	return selector.Matches(set)
+}
+
+// frontendIPsAllowed returns an error message if an explicitly requested frontend IP
+// is outside of the frontend subnet allowed by the policy.
+func frontendIPsAllowed(params lbParams) string {
+	if params.FrontendSubnet == nil {
+		return ""
+	}
+	for _, ip := range params.LoadBalancerIPs {
+		if !params.FrontendSubnet.Contains(net.ParseIP(ip)) {
+			return fmt.Sprintf("frontend IP %s is outside of the allowed subnet %s", ip, params.FrontendSubnet.String())
+		}
+	}
+	return ""
+}
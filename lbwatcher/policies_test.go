@@ -0,0 +1,123 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lbwatcher
+
+import (
+	"testing"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestServicePolicy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := k8sv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	policy := func(namespace, name string, priority int) *k8sv1alpha1.LoadBalancerPolicy {
+		return &k8sv1alpha1.LoadBalancerPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       k8sv1alpha1.LoadBalancerPolicySpec{Priority: priority},
+		}
+	}
+	clusterPolicy := func(name string, priority int, team string) *k8sv1alpha1.ClusterLoadBalancerPolicy {
+		p := &k8sv1alpha1.ClusterLoadBalancerPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		p.Spec.Priority = priority
+		if team != "" {
+			p.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": team}}
+		}
+		return p
+	}
+
+	namespaces := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for name, team := range map[string]string{"team-a": "a", "team-b": "b", "other": ""} {
+		ns := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"team": team}}}
+		if err := namespaces.Add(ns); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		objs      []runtime.Object
+		want      string
+	}{
+		{
+			name:      "policy of another namespace is ignored",
+			namespace: "team-a",
+			objs:      []runtime.Object{policy("team-b", "b", 100)},
+		},
+		{
+			name:      "own namespace policy applies",
+			namespace: "team-a",
+			objs:      []runtime.Object{policy("team-b", "b", 100), policy("team-a", "a", 0)},
+			want:      "LoadBalancerPolicy team-a/a",
+		},
+		{
+			name:      "cluster policy with namespace selector",
+			namespace: "team-a",
+			objs:      []runtime.Object{clusterPolicy("team-b", 0, "b"), clusterPolicy("team-a", 0, "a")},
+			want:      "ClusterLoadBalancerPolicy team-a",
+		},
+		{
+			name:      "cluster policy without namespace selector applies everywhere",
+			namespace: "other",
+			objs:      []runtime.Object{clusterPolicy("team-a", 0, "a"), clusterPolicy("default", 0, "")},
+			want:      "ClusterLoadBalancerPolicy default",
+		},
+		{
+			name:      "namespace policy wins on equal priority",
+			namespace: "team-a",
+			objs:      []runtime.Object{clusterPolicy("default", 0, ""), policy("team-a", "a", 0)},
+			want:      "LoadBalancerPolicy team-a/a",
+		},
+		{
+			name:      "higher priority wins",
+			namespace: "team-a",
+			objs:      []runtime.Object{clusterPolicy("default", 10, ""), policy("team-a", "a", 0)},
+			want:      "ClusterLoadBalancerPolicy default",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Watcher{
+				client:          ctrlfake.NewFakeClientWithScheme(scheme, tt.objs...),
+				namespaceLister: corelisters.NewNamespaceLister(namespaces),
+			}
+			svc := &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: tt.namespace}}
+			got, err := w.servicePolicy(svc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			source := ""
+			if got != nil {
+				source = got.Source
+			}
+			if source != tt.want {
+				t.Errorf("servicePolicy() = %q, want %q", source, tt.want)
+			}
+		})
+	}
+}
@@ -23,6 +23,9 @@ import (
 	"github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -32,16 +35,63 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func getServices(clientset *kubernetes.Clientset, namespace string) (*v1.ServiceList, error) {
-	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
-	defer cancel()
-	services, err := clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+// getService returns the Service from the informer cache together with its
+// spec.loadBalancerClass and spec.ipFamilies.
+func (w *Watcher) getService(namespace, name string) (*v1.Service, serviceSpecExt, error) {
+	obj, err := w.serviceLister.ByNamespace(namespace).Get(name)
+	if err != nil {
+		return nil, serviceSpecExt{}, err
+	}
+	return serviceFromUnstructured(obj)
+}
+
+func (w *Watcher) listServices() ([]*v1.Service, error) {
+	objs, err := w.serviceLister.List(labels.Everything())
 	if err != nil {
-		return services, fmt.Errorf("{getServices} %s", err)
+		return nil, err
+	}
+	services := []*v1.Service{}
+	for _, obj := range objs {
+		svc, _, err := serviceFromUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, svc)
 	}
 	return services, nil
 }
 
+func serviceFromUnstructured(obj runtime.Object) (*v1.Service, serviceSpecExt, error) {
+	ext := serviceSpecExt{}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, ext, fmt.Errorf("{serviceFromUnstructured} unexpected object type %T", obj)
+	}
+	svc := &v1.Service{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), svc); err != nil {
+		return nil, ext, fmt.Errorf("{serviceFromUnstructured} %s", err)
+	}
+	ext.LoadBalancerClass, _, _ = unstructured.NestedString(u.Object, "spec", "loadBalancerClass")
+	families, _, _ := unstructured.NestedStringSlice(u.Object, "spec", "ipFamilies")
+	for _, family := range families {
+		ext.IPFamilies = append(ext.IPFamilies, v1.IPFamily(family))
+	}
+	return svc, ext, nil
+}
+
+// claimsLoadBalancerClass reports whether Services with the given spec.loadBalancerClass
+// are handled by the watcher. Services of other classes belong to other implementations.
+func (w *Watcher) claimsLoadBalancerClass(lbClass string) bool {
+	if lbClass == "" {
+		return !w.Options.LoadBalancerClassOnly
+	}
+	class := w.Options.LoadBalancerClass
+	if class == "" {
+		class = defaultLoadBalancerClass
+	}
+	return lbClass == class
+}
+
 func assignIngress(clientset *kubernetes.Clientset, ips []string, namespace string, name string) (*v1.Service, error) {
 	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
 	defer cancel()
@@ -84,6 +134,10 @@ func eventRecorder(kubeClient *kubernetes.Clientset) (record.EventRecorder, watc
 }
 
 func createEvent(clientset *kubernetes.Clientset, recorder record.EventRecorder, namespace, name, reason, message string) error {
+	return createEventWithType(clientset, recorder, namespace, name, v1.EventTypeWarning, reason, message)
+}
+
+func createEventWithType(clientset *kubernetes.Clientset, recorder record.EventRecorder, namespace, name, eventType, reason, message string) error {
 	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
 	defer cancel()
 	service, err := clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
@@ -101,7 +155,7 @@ func createEvent(clientset *kubernetes.Clientset, recorder record.EventRecorder,
 		}
 		return nil
 	}
-	recorder.Event(ref, v1.EventTypeWarning, reason, message)
+	recorder.Event(ref, eventType, reason, message)
 
 	return nil
 }
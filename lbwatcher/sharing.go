@@ -0,0 +1,67 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lbwatcher
+
+import (
+	"fmt"
+	"strings"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+)
+
+// getSharedL4LBs returns the L4LBs of other Services, in any namespace, that share
+// their frontend IP under the given key.
+func (w *Watcher) getSharedL4LBs(key, serviceUID string) ([]k8sv1alpha1.L4LB, error) {
+	l4lbs, err := getL4LBs(w.client, "")
+	if err != nil {
+		return nil, fmt.Errorf("{getSharedL4LBs} %s", err)
+	}
+	lbList := []k8sv1alpha1.L4LB{}
+	for _, lb := range filterL4LBs(l4lbs.Items) {
+		if lb.GetSharedIPKey() == key && lb.GetServiceUID() != serviceUID {
+			lbList = append(lbList, lb)
+		}
+	}
+	return lbList, nil
+}
+
+// sharedIPPending reports whether a shared L4LB is still waiting for its automatically
+// assigned frontend IP. Other Services wait for it instead of getting an IP of their own.
+func sharedIPPending(lbs []k8sv1alpha1.L4LB) bool {
+	for _, lb := range lbs {
+		if lb.Spec.Frontend.IP == "" && lb.Status.Status != "Failure" {
+			return true
+		}
+	}
+	return false
+}
+
+func frontendKey(lb *k8sv1alpha1.L4LB) string {
+	return fmt.Sprintf("%s/%s/%d", lb.Spec.Frontend.IP, strings.ToLower(lb.Spec.Protocol), lb.Spec.Frontend.Port)
+}
+
+// sharedFrontendConflict returns a description of the conflict, if the frontend IP, protocol
+// and port of the L4LB are already used by an L4LB of another Service.
+func sharedFrontendConflict(lb *k8sv1alpha1.L4LB, sharedFrontends map[string]k8sv1alpha1.L4LB) string {
+	if lb.Spec.Frontend.IP == "" {
+		return ""
+	}
+	if shared, ok := sharedFrontends[frontendKey(lb)]; ok {
+		return fmt.Sprintf("port %s/%d of shared IP %s is already used by Service %s/%s", strings.ToLower(lb.Spec.Protocol), lb.Spec.Frontend.Port, lb.Spec.Frontend.IP, shared.GetServiceNamespace(), shared.GetServiceName())
+	}
+	return ""
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lbwatcher
+
+import (
+	"strings"
+	"testing"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func sharedLB(service, site, ip, protocol string, port int) k8sv1alpha1.L4LB {
+	lb := k8sv1alpha1.L4LB{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}}
+	lb.SetServiceName(service)
+	lb.SetServiceNamespace("default")
+	lb.SetSharedIPKey("web")
+	lb.Spec.Site = site
+	lb.Spec.Protocol = protocol
+	lb.Spec.Frontend.IP = ip
+	lb.Spec.Frontend.Port = port
+	return lb
+}
+
+func TestFrontendKey(t *testing.T) {
+	lb := sharedLB("web", "site1", "192.0.2.10", "TCP", 80)
+	if got, want := frontendKey(&lb), "192.0.2.10/tcp/80"; got != want {
+		t.Errorf("frontendKey = %q, want %q", got, want)
+	}
+}
+
+func TestSharedFrontendConflict(t *testing.T) {
+	shared := sharedLB("api", "site1", "192.0.2.10", "tcp", 80)
+	sharedFrontends := map[string]k8sv1alpha1.L4LB{frontendKey(&shared): shared}
+
+	tests := []struct {
+		name     string
+		lb       k8sv1alpha1.L4LB
+		conflict string
+	}{
+		{
+			name: "same key and different port",
+			lb:   sharedLB("web", "site1", "192.0.2.10", "tcp", 443),
+		},
+		{
+			name: "same key and different protocol",
+			lb:   sharedLB("web", "site1", "192.0.2.10", "udp", 80),
+		},
+		{
+			name:     "same key, port and protocol",
+			lb:       sharedLB("web", "site1", "192.0.2.10", "TCP", 80),
+			conflict: "port tcp/80 of shared IP 192.0.2.10 is already used by Service default/api",
+		},
+		{
+			name: "mismatched site gets its own frontend IP",
+			lb:   sharedLB("web", "site2", "198.51.100.10", "tcp", 80),
+		},
+		{
+			name:     "mismatched site with the same frontend IP",
+			lb:       sharedLB("web", "site2", "192.0.2.10", "tcp", 80),
+			conflict: "port tcp/80 of shared IP 192.0.2.10 is already used by Service default/api",
+		},
+		{
+			name: "frontend IP not assigned yet",
+			lb:   sharedLB("web", "site1", "", "tcp", 80),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sharedFrontendConflict(&tt.lb, sharedFrontends); got != tt.conflict {
+				t.Errorf("sharedFrontendConflict = %q, want %q", got, tt.conflict)
+			}
+		})
+	}
+}
+
+func TestSharedIPPending(t *testing.T) {
+	failed := sharedLB("api", "site1", "", "tcp", 80)
+	failed.Status.Status = "Failure"
+
+	tests := []struct {
+		name string
+		lbs  []k8sv1alpha1.L4LB
+		want bool
+	}{
+		{
+			name: "no shared L4LBs",
+		},
+		{
+			name: "frontend IP assigned",
+			lbs:  []k8sv1alpha1.L4LB{sharedLB("api", "site1", "192.0.2.10", "tcp", 80)},
+		},
+		{
+			name: "frontend IP not assigned yet",
+			lbs:  []k8sv1alpha1.L4LB{sharedLB("api", "site1", "192.0.2.10", "tcp", 80), sharedLB("api", "site1", "", "tcp", 443)},
+			want: true,
+		},
+		{
+			name: "frontend IP assignment failed",
+			lbs:  []k8sv1alpha1.L4LB{failed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sharedIPPending(tt.lbs); got != tt.want {
+				t.Errorf("sharedIPPending = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareLoadBalancersSharedIP(t *testing.T) {
+	shared := sharedLB("api", "site1", "192.0.2.10", "tcp", 80)
+	lb := sharedLB("web", "site1", "192.0.2.10", "tcp", 80)
+	lb.SetServiceUID("uid")
+	lb.Name = "web-tcp-80"
+
+	toCreate, _, _, _, conflicts := compareLoadBalancers(nil, []*k8sv1alpha1.L4LB{&lb}, []k8sv1alpha1.L4LB{shared})
+	if len(toCreate) != 0 {
+		t.Errorf("created %d L4LBs, want none", len(toCreate))
+	}
+	if len(conflicts) != 1 || !strings.Contains(conflicts[0], "already used by Service default/api") {
+		t.Errorf("conflicts = %q, want the port of Service default/api", conflicts)
+	}
+
+	lb.Spec.Frontend.Port = 443
+	toCreate, _, _, _, conflicts = compareLoadBalancers(nil, []*k8sv1alpha1.L4LB{&lb}, []k8sv1alpha1.L4LB{shared})
+	if len(toCreate) != 1 || len(conflicts) != 0 {
+		t.Errorf("created %d L4LBs with conflicts %q, want 1 and none", len(toCreate), conflicts)
+	}
+}
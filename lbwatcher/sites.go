@@ -0,0 +1,148 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lbwatcher
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// multiSitePolicySplit creates one L4LB per site when Service backends span several sites.
+	multiSitePolicySplit = "split"
+	// multiSitePolicyReject leaves such Services unprovisioned and reports a Warning event.
+	multiSitePolicyReject = "reject"
+)
+
+var siteNameReg = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// siteBackends is a group of backend IPs that belong to the same Netris site.
+type siteBackends struct {
+	Site string
+	IPs  []string
+}
+
+// multiSiteError is returned when Service backends span several sites and the policy rejects it.
+type multiSiteError struct {
+	sites  []string
+	reason string
+}
+
+func (e *multiSiteError) Error() string {
+	return fmt.Sprintf("backends span multiple sites (%s): %s", strings.Join(e.sites, ", "), e.reason)
+}
+
+// groupBackendsBySite groups the backend IPs by the site of the subnet they belong to.
+// IPs whose site cannot be detected join the only detected site, if there is exactly one.
+// If site is set, all backends are assigned to it.
+func (w *Watcher) groupBackendsBySite(ips []string, site string) []siteBackends {
+	if len(ips) == 0 {
+		return nil
+	}
+	if site != "" {
+		return []siteBackends{{Site: site, IPs: ips}}
+	}
+
+	bySite := map[string][]string{}
+	unknown := []string{}
+	for _, ip := range ips {
+		s, _, err := w.findSiteByIP(ip)
+		if err != nil || s.Name == "" {
+			debugLogger.Info("Site not found for backend", "ip", ip)
+			unknown = append(unknown, ip)
+			continue
+		}
+		bySite[s.Name] = append(bySite[s.Name], ip)
+	}
+
+	if len(bySite) == 0 {
+		return []siteBackends{{IPs: unknown}}
+	}
+	if len(bySite) == 1 {
+		for s := range bySite {
+			bySite[s] = append(bySite[s], unknown...)
+		}
+	} else if len(unknown) > 0 {
+		logger.Info("Ignoring backends with unknown site", "ips", unknown)
+	}
+
+	groups := []siteBackends{}
+	for s, sIPs := range bySite {
+		sort.Strings(sIPs)
+		groups = append(groups, siteBackends{Site: s, IPs: sIPs})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Site < groups[j].Site })
+	return groups
+}
+
+// lastBackendGroups rebuilds the site groups from the backends of the existing L4LBs of the IP family.
+// It keeps the load balancers of a Service whose endpoints are temporarily not ready.
+func lastBackendGroups(lbs []k8sv1alpha1.L4LB, family v1.IPFamily) []siteBackends {
+	bySite := map[string]map[string]struct{}{}
+	for i := range lbs {
+		if lbFamily(&lbs[i]) != family {
+			continue
+		}
+		site := lbs[i].Spec.Site
+		if _, ok := bySite[site]; !ok {
+			bySite[site] = map[string]struct{}{}
+		}
+		for _, backend := range lbs[i].Spec.Backend {
+			host, _, err := net.SplitHostPort(string(backend))
+			if err != nil {
+				continue
+			}
+			bySite[site][host] = struct{}{}
+		}
+	}
+
+	groups := []siteBackends{}
+	for site, hosts := range bySite {
+		ips := []string{}
+		for ip := range hosts {
+			ips = append(ips, ip)
+		}
+		sort.Strings(ips)
+		groups = append(groups, siteBackends{Site: site, IPs: ips})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Site < groups[j].Site })
+	return groups
+}
+
+func siteNames(groups []siteBackends) []string {
+	names := []string{}
+	for _, group := range groups {
+		names = append(names, group.Site)
+	}
+	return names
+}
+
+// siteSuffix makes a site name usable as a part of an object name.
+func siteSuffix(site string) string {
+	return strings.Trim(siteNameReg.ReplaceAllString(strings.ToLower(site), "-"), "-")
+}
+
+// autoIPKey is the key of automatically assigned frontend IPs, which are per Service, site and IP family.
+func autoIPKey(uid, site string, family v1.IPFamily) string {
+	return fmt.Sprintf("%s/%s/%s", uid, site, family)
+}
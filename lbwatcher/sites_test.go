@@ -0,0 +1,62 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lbwatcher
+
+import (
+	"reflect"
+	"testing"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestLastBackendGroups(t *testing.T) {
+	lb := func(site, frontend string, backends ...string) k8sv1alpha1.L4LB {
+		l := k8sv1alpha1.L4LB{}
+		l.Spec.Site = site
+		l.Spec.Frontend.IP = frontend
+		for _, backend := range backends {
+			l.Spec.Backend = append(l.Spec.Backend, k8sv1alpha1.L4LBBackend(backend))
+		}
+		return l
+	}
+	lbs := []k8sv1alpha1.L4LB{
+		lb("site-b", "192.0.2.1", "10.0.2.10:30080"),
+		lb("site-a", "192.0.2.2", "10.0.1.11:30080", "10.0.1.10:30080"),
+		lb("site-a", "192.0.2.2", "10.0.1.10:30443"),
+		lb("site-a", "2001:db8::1", "[2001:db8:1::10]:30080"),
+		lb("site-c", "192.0.2.3"),
+	}
+
+	want := []siteBackends{
+		{Site: "site-a", IPs: []string{"10.0.1.10", "10.0.1.11"}},
+		{Site: "site-b", IPs: []string{"10.0.2.10"}},
+		{Site: "site-c", IPs: []string{}},
+	}
+	if got := lastBackendGroups(lbs, v1.IPv4Protocol); !reflect.DeepEqual(got, want) {
+		t.Errorf("IPv4 groups = %v, want %v", got, want)
+	}
+
+	want = []siteBackends{{Site: "site-a", IPs: []string{"2001:db8:1::10"}}}
+	if got := lastBackendGroups(lbs, v1.IPv6Protocol); !reflect.DeepEqual(got, want) {
+		t.Errorf("IPv6 groups = %v, want %v", got, want)
+	}
+
+	if got := lastBackendGroups(nil, v1.IPv4Protocol); len(got) != 0 {
+		t.Errorf("groups without L4LBs = %v, want none", got)
+	}
+}
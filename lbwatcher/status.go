@@ -0,0 +1,130 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lbwatcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	annotationStatus        = annotationPrefix + "status"
+	annotationStatusMessage = annotationPrefix + "status-message"
+)
+
+const (
+	serviceStateProvisioning = "Provisioning"
+	serviceStateActive       = "Active"
+	serviceStateDegraded     = "Degraded"
+)
+
+// serviceLBState summarizes the L4LBs of a Service. The Service is Degraded if an L4LB failed
+// or has unhealthy backends, Provisioning until all its L4LBs exist in Netris with a frontend IP,
+// and Active otherwise.
+func serviceLBState(LBs []k8sv1alpha1.L4LB, serviceLBs []*k8sv1alpha1.L4LB, pending bool) (string, string) {
+	if len(serviceLBs) == 0 {
+		return serviceStateProvisioning, "no ready backends"
+	}
+
+	LBsMap := map[string]k8sv1alpha1.L4LB{}
+	for _, lb := range LBs {
+		LBsMap[lb.Name] = lb
+	}
+
+	degraded := []string{}
+	provisioning := pending
+	frontends := []string{}
+	for _, serviceLB := range serviceLBs {
+		lb, ok := LBsMap[serviceLB.Name]
+		if !ok || lb.Spec.Frontend.IP == "" || lb.Status.Status == "" || lb.Status.Status == "Provisioning" {
+			provisioning = true
+			continue
+		}
+		frontend := net.JoinHostPort(lb.Spec.Frontend.IP, lb.Status.Port)
+		if lb.Status.Status == "Failure" {
+			degraded = append(degraded, fmt.Sprintf("%s: %s", frontend, lb.Status.Message))
+			continue
+		}
+		unhealthy := []string{}
+		for _, backend := range lb.Status.Backends {
+			if backend.Health == k8sv1alpha1.L4LBBackendUnhealthy {
+				unhealthy = append(unhealthy, string(backend.Backend))
+			}
+		}
+		if len(unhealthy) > 0 {
+			degraded = append(degraded, fmt.Sprintf("%s: unhealthy backends %s", frontend, strings.Join(unhealthy, ", ")))
+			continue
+		}
+		frontends = append(frontends, frontend)
+	}
+
+	if len(degraded) > 0 {
+		sort.Strings(degraded)
+		return serviceStateDegraded, strings.Join(degraded, "; ")
+	}
+	if provisioning {
+		return serviceStateProvisioning, "waiting for the load balancers to be provisioned"
+	}
+	sort.Strings(frontends)
+	return serviceStateActive, fmt.Sprintf("load balancers %s are active", strings.Join(frontends, ", "))
+}
+
+// publishServiceState stores the state in the Service annotations and emits an Event when it changes.
+func (w *Watcher) publishServiceState(svc *v1.Service, state, message string) error {
+	annotations := svc.GetAnnotations()
+	if annotations[annotationStatus] == state && annotations[annotationStatusMessage] == message {
+		return nil
+	}
+	changed := annotations[annotationStatus] != state
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				annotationStatus:        state,
+				annotationStatusMessage: message,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("{publishServiceState} %s", err)
+	}
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	if _, err := w.clientset.CoreV1().Services(svc.GetNamespace()).Patch(ctx, svc.GetName(), types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("{publishServiceState} %s", err)
+	}
+
+	if !changed {
+		return nil
+	}
+	switch state {
+	case serviceStateActive:
+		return createEventWithType(w.clientset, w.recorder, svc.GetNamespace(), svc.GetName(), v1.EventTypeNormal, "Provisioned", message)
+	case serviceStateDegraded:
+		return createEvent(w.clientset, w.recorder, svc.GetNamespace(), svc.GetName(), "Degraded", message)
+	}
+	return nil
+}
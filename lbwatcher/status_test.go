@@ -0,0 +1,131 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lbwatcher
+
+import (
+	"testing"
+
+	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestServiceLBState(t *testing.T) {
+	generated := func(names ...string) []*k8sv1alpha1.L4LB {
+		lbs := []*k8sv1alpha1.L4LB{}
+		for _, name := range names {
+			lbs = append(lbs, &k8sv1alpha1.L4LB{ObjectMeta: metav1.ObjectMeta{Name: name}})
+		}
+		return lbs
+	}
+	lb := func(name, ip, status string, health ...string) k8sv1alpha1.L4LB {
+		l := k8sv1alpha1.L4LB{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		l.Spec.Frontend.IP = ip
+		l.Status.Status = status
+		l.Status.Port = "80/TCP"
+		for i, h := range health {
+			backend := k8sv1alpha1.L4LBBackend([]string{"10.0.0.10:30080", "10.0.0.11:30080"}[i])
+			l.Status.Backends = append(l.Status.Backends, k8sv1alpha1.L4LBBackendStatus{Backend: backend, Health: h})
+		}
+		return l
+	}
+	failed := lb("web-tcp-80", "192.0.2.10", "Failure")
+	failed.Status.Message = "port is busy"
+
+	tests := []struct {
+		name        string
+		LBs         []k8sv1alpha1.L4LB
+		serviceLBs  []*k8sv1alpha1.L4LB
+		pending     bool
+		wantState   string
+		wantMessage string
+	}{
+		{
+			name:        "no backends",
+			wantState:   serviceStateProvisioning,
+			wantMessage: "no ready backends",
+		},
+		{
+			name:        "L4LB not created yet",
+			serviceLBs:  generated("web-tcp-80"),
+			wantState:   serviceStateProvisioning,
+			wantMessage: "waiting for the load balancers to be provisioned",
+		},
+		{
+			name:        "frontend IP not assigned yet",
+			LBs:         []k8sv1alpha1.L4LB{lb("web-tcp-80", "", "Provisioning")},
+			serviceLBs:  generated("web-tcp-80"),
+			wantState:   serviceStateProvisioning,
+			wantMessage: "waiting for the load balancers to be provisioned",
+		},
+		{
+			name:        "provisioning in Netris",
+			LBs:         []k8sv1alpha1.L4LB{lb("web-tcp-80", "192.0.2.10", "Provisioning")},
+			serviceLBs:  generated("web-tcp-80"),
+			wantState:   serviceStateProvisioning,
+			wantMessage: "waiting for the load balancers to be provisioned",
+		},
+		{
+			name:        "other L4LBs still pending",
+			LBs:         []k8sv1alpha1.L4LB{lb("web-tcp-80", "192.0.2.10", "OK", k8sv1alpha1.L4LBBackendHealthy)},
+			serviceLBs:  generated("web-tcp-80"),
+			pending:     true,
+			wantState:   serviceStateProvisioning,
+			wantMessage: "waiting for the load balancers to be provisioned",
+		},
+		{
+			name: "active",
+			LBs: []k8sv1alpha1.L4LB{
+				lb("web-tcp-80", "192.0.2.10", "OK", k8sv1alpha1.L4LBBackendHealthy, k8sv1alpha1.L4LBBackendUnknown),
+				lb("web-tcp-80-v6", "2001:db8::10", "OK", k8sv1alpha1.L4LBBackendHealthy),
+			},
+			serviceLBs:  generated("web-tcp-80", "web-tcp-80-v6"),
+			wantState:   serviceStateActive,
+			wantMessage: "load balancers 192.0.2.10:80/TCP, [2001:db8::10]:80/TCP are active",
+		},
+		{
+			name:        "unhealthy backends",
+			LBs:         []k8sv1alpha1.L4LB{lb("web-tcp-80", "192.0.2.10", "OK", k8sv1alpha1.L4LBBackendHealthy, k8sv1alpha1.L4LBBackendUnhealthy)},
+			serviceLBs:  generated("web-tcp-80"),
+			wantState:   serviceStateDegraded,
+			wantMessage: "192.0.2.10:80/TCP: unhealthy backends 10.0.0.11:30080",
+		},
+		{
+			name:        "failed L4LB",
+			LBs:         []k8sv1alpha1.L4LB{failed},
+			serviceLBs:  generated("web-tcp-80"),
+			wantState:   serviceStateDegraded,
+			wantMessage: "192.0.2.10:80/TCP: port is busy",
+		},
+		{
+			name:        "degraded takes precedence over provisioning",
+			LBs:         []k8sv1alpha1.L4LB{failed},
+			serviceLBs:  generated("web-tcp-80", "web-tcp-80-v6"),
+			pending:     true,
+			wantState:   serviceStateDegraded,
+			wantMessage: "192.0.2.10:80/TCP: port is busy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, message := serviceLBState(tt.LBs, tt.serviceLBs, tt.pending)
+			if state != tt.wantState || message != tt.wantMessage {
+				t.Errorf("serviceLBState = %q, %q, want %q, %q", state, message, tt.wantState, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -17,7 +17,16 @@ limitations under the License.
 package lbwatcher
 
 import (
+	"sync"
+	"time"
+
 	"github.com/netrisai/netris-operator/netrisstorage"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
@@ -26,6 +35,18 @@ type Watcher struct {
 	Options  Options
 	NStorage *netrisstorage.Storage
 	MGR      manager.Manager
+
+	clientset           *kubernetes.Clientset
+	client              client.Client
+	recorder            record.EventRecorder
+	queue               workqueue.RateLimitingInterface
+	serviceLister       cache.GenericLister
+	nodeLister          corelisters.NodeLister
+	namespaceLister     corelisters.NamespaceLister
+	endpointSliceLister cache.GenericLister
+
+	nodesMu       sync.Mutex
+	excludedNodes map[string]time.Time
 }
 
 type lbIP struct {
@@ -41,4 +62,15 @@ type lbIP struct {
 type Options struct {
 	LogLevel        string
 	RequeueInterval int
+
+	// LoadBalancerClass is the spec.loadBalancerClass handled by the watcher.
+	LoadBalancerClass string
+	// LoadBalancerClassOnly disables handling of Services without spec.loadBalancerClass.
+	LoadBalancerClassOnly bool
+	// MultiSitePolicy decides what to do with Services whose backends span several sites: split or reject.
+	MultiSitePolicy string
+	// ExcludeNodeLabel is the label of nodes that must not be used as L4LB backends.
+	ExcludeNodeLabel string
+	// NodeGracePeriod is the number of seconds an excluded node is kept in the L4LB backends.
+	NodeGracePeriod int
 }
@@ -33,9 +33,11 @@ import (
 
 	k8sv1alpha1 "github.com/netrisai/netris-operator/api/v1alpha1"
 	"github.com/netrisai/netris-operator/calicowatcher"
+	"github.com/netrisai/netris-operator/ciliumwatcher"
 	"github.com/netrisai/netris-operator/configloader"
 	"github.com/netrisai/netris-operator/controllers"
 	"github.com/netrisai/netris-operator/lbwatcher"
+	"github.com/netrisai/netris-operator/metallbwatcher"
 	"github.com/netrisai/netris-operator/netrisstorage"
 	// +kubebuilder:scaffold:imports
 )
@@ -434,6 +436,106 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "VPCMeta")
 		os.Exit(1)
 	}
+	if err = (&controllers.LAGReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("LAG"),
+		Scheme:   mgr.GetScheme(),
+		Cred:     cred,
+		NStorage: nStorage,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "LAG")
+		os.Exit(1)
+	}
+	if err = (&controllers.LAGMetaReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("LAGMeta"),
+		Scheme:   mgr.GetScheme(),
+		Cred:     cred,
+		NStorage: nStorage,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "LAGMeta")
+		os.Exit(1)
+	}
+	if err = (&controllers.DHCPOptionSetReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("DHCPOptionSet"),
+		Scheme:   mgr.GetScheme(),
+		Cred:     cred,
+		NStorage: nStorage,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DHCPOptionSet")
+		os.Exit(1)
+	}
+	if err = (&controllers.DHCPOptionSetMetaReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("DHCPOptionSetMeta"),
+		Scheme:   mgr.GetScheme(),
+		Cred:     cred,
+		NStorage: nStorage,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DHCPOptionSetMeta")
+		os.Exit(1)
+	}
+	if err = (&controllers.RouteMapReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("RouteMap"),
+		Scheme:   mgr.GetScheme(),
+		Cred:     cred,
+		NStorage: nStorage,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RouteMap")
+		os.Exit(1)
+	}
+	if err = (&controllers.RouteMapMetaReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("RouteMapMeta"),
+		Scheme:   mgr.GetScheme(),
+		Cred:     cred,
+		NStorage: nStorage,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RouteMapMeta")
+		os.Exit(1)
+	}
+	if err = (&controllers.BGPObjectReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("BGPObject"),
+		Scheme:   mgr.GetScheme(),
+		Cred:     cred,
+		NStorage: nStorage,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BGPObject")
+		os.Exit(1)
+	}
+	if err = (&controllers.BGPObjectMetaReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("BGPObjectMeta"),
+		Scheme:   mgr.GetScheme(),
+		Cred:     cred,
+		NStorage: nStorage,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BGPObjectMeta")
+		os.Exit(1)
+	}
+	if err = (&controllers.NetrisObjectReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("NetrisObject"),
+		Scheme:   mgr.GetScheme(),
+		Cred:     cred,
+		NStorage: nStorage,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NetrisObject")
+		os.Exit(1)
+	}
+	if err = (&controllers.NetrisObjectMetaReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("NetrisObjectMeta"),
+		Scheme:   mgr.GetScheme(),
+		Cred:     cred,
+		NStorage: nStorage,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NetrisObjectMeta")
+		os.Exit(1)
+	}
 
 	// +kubebuilder:scaffold:builder
 
@@ -442,20 +544,60 @@ func main() {
 		watcherLogLevel = "debug"
 	}
 
-	lbWatcher, err := lbwatcher.NewWatcher(nStorage, mgr, lbwatcher.Options{LogLevel: watcherLogLevel, RequeueInterval: configloader.Root.RequeueInterval})
+	lbWatcher, err := lbwatcher.NewWatcher(nStorage, mgr, lbwatcher.Options{
+		LogLevel:              watcherLogLevel,
+		RequeueInterval:       configloader.Root.RequeueInterval,
+		LoadBalancerClass:     configloader.Root.LBClass,
+		LoadBalancerClassOnly: configloader.Root.LBClassOnly,
+		MultiSitePolicy:       configloader.Root.LBMultiSite,
+		ExcludeNodeLabel:      configloader.Root.LBExcludeLabel,
+		NodeGracePeriod:       configloader.Root.LBNodeGrace,
+	})
 	if err != nil {
 		setupLog.Error(err, "problem running lbwatcher")
 		os.Exit(1)
 	}
 	go lbWatcher.Start()
 
-	cWatcher, err := calicowatcher.NewWatcher(nStorage, mgr, calicowatcher.Options{LogLevel: watcherLogLevel, RequeueInterval: configloader.Root.RequeueInterval})
+	cWatcher, err := calicowatcher.NewWatcher(nStorage, mgr, calicowatcher.Options{
+		LogLevel:        watcherLogLevel,
+		RequeueInterval: configloader.Root.RequeueInterval,
+		ASNRange:        configloader.Root.CalicoASNRange,
+		Namespace:       configloader.Root.Namespace,
+	})
 	if err != nil {
 		setupLog.Error(err, "problem running calicowatcher")
 		os.Exit(1)
 	}
 	go cWatcher.Start()
 
+	ciliumWatcher, err := ciliumwatcher.NewWatcher(nStorage, mgr, ciliumwatcher.Options{
+		LogLevel:        watcherLogLevel,
+		RequeueInterval: configloader.Root.RequeueInterval,
+		Enabled:         configloader.Root.CiliumEnabled,
+		ASNRange:        configloader.Root.CiliumASNRange,
+		Namespace:       configloader.Root.Namespace,
+	})
+	if err != nil {
+		setupLog.Error(err, "problem running ciliumwatcher")
+		os.Exit(1)
+	}
+	go ciliumWatcher.Start()
+
+	metallbWatcher, err := metallbwatcher.NewWatcher(nStorage, mgr, metallbwatcher.Options{
+		LogLevel:          watcherLogLevel,
+		RequeueInterval:   configloader.Root.RequeueInterval,
+		Enabled:           configloader.Root.MetalLBEnabled,
+		Namespace:         configloader.Root.MetalLBNS,
+		ASNRange:          configloader.Root.MetalLBASNRange,
+		OperatorNamespace: configloader.Root.Namespace,
+	})
+	if err != nil {
+		setupLog.Error(err, "problem running metallbwatcher")
+		os.Exit(1)
+	}
+	go metallbWatcher.Start()
+
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
@@ -0,0 +1,327 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metallbwatcher
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/metallbwatcher/metallb"
+	"github.com/netrisai/netris-operator/netrisstorage"
+	"github.com/netrisai/netris-operator/watchercore"
+	"go.uber.org/zap/zapcore"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+var (
+	requeueInterval = time.Duration(10 * time.Second)
+	logger          = ctrl.Log.WithName("MetalLBWatcher")
+	debugLogger     = logger.V(int(zapcore.WarnLevel))
+	cntxt           = context.Background()
+	contextTimeout  = requeueInterval
+)
+
+const defaultNamespace = "metallb-system"
+
+// Watcher is the main structure in order to manage metallbwatcher
+type Watcher struct {
+	Options   Options
+	NStorage  *netrisstorage.Storage
+	MGR       manager.Manager
+	MetalLB   *metallb.MetalLB
+	client    client.Client
+	clientset kubernetes.Interface
+	ledger    *watchercore.ASNLedger
+	bgps      *watchercore.BGPs
+	data      data
+	stop      chan struct{}
+
+	metallbNotFound bool
+}
+
+type data struct {
+	deleteMode    bool
+	generatedBGPs []*v1alpha1.BGP
+
+	pools          []*metallb.IPAddressPool
+	advertisements []*metallb.BGPAdvertisement
+	nodes          map[string]*v1.Node
+	speakers       []string
+	nodesMap       map[string]*nodeIP
+	asnStart       int
+	asnEnd         int
+}
+
+// Options is the main options struct.
+type Options struct {
+	RequeueInterval int
+	LogLevel        string
+	// Enabled turns the integration on, when it's off everything created by the watcher is removed.
+	Enabled bool
+	// Namespace is the namespace MetalLB is installed in.
+	Namespace string
+	// ASNRange is the "start-end" range of the speaker AS numbers.
+	ASNRange string
+	// OperatorNamespace is the namespace of the operator, where the AS number ledger is kept.
+	OperatorNamespace string
+}
+
+// NewWatcher is the main initialization function.
+func NewWatcher(nStorage *netrisstorage.Storage, mgr manager.Manager, options Options) (*Watcher, error) {
+	if nStorage == nil {
+		return nil, fmt.Errorf("please provide NStorage")
+	}
+	if options.Namespace == "" {
+		options.Namespace = defaultNamespace
+	}
+
+	watcher := &Watcher{
+		NStorage: nStorage,
+		MGR:      mgr,
+		Options:  options,
+		stop:     make(chan struct{}),
+	}
+	return watcher, nil
+}
+
+// initClients creates the clients that weren't provided yet.
+func (w *Watcher) initClients() error {
+	if w.client == nil {
+		w.client = w.MGR.GetClient()
+	}
+	if w.clientset == nil || w.MetalLB == nil {
+		restConfig := ctrl.GetConfigOrDie()
+		if w.clientset == nil {
+			clientset, err := kubernetes.NewForConfig(restConfig)
+			if err != nil {
+				return err
+			}
+			w.clientset = clientset
+		}
+		if w.MetalLB == nil {
+			dynClient, err := dynamic.NewForConfig(restConfig)
+			if err != nil {
+				return err
+			}
+			w.MetalLB = metallb.New(dynClient, metallb.Options{ContextTimeout: w.Options.RequeueInterval})
+		}
+	}
+	if w.ledger == nil {
+		w.ledger = &watchercore.ASNLedger{
+			Clientset:      w.clientset,
+			Logger:         logger,
+			Name:           asnLedgerName,
+			Namespace:      w.asnLedgerNamespace(),
+			Annotation:     asnAnnotation,
+			ContextTimeout: contextTimeout,
+		}
+	}
+	if w.bgps == nil {
+		w.bgps = &watchercore.BGPs{
+			Client:         w.client,
+			Logger:         logger,
+			Annotation:     watcherAnnotation,
+			ContextTimeout: contextTimeout,
+		}
+	}
+	return nil
+}
+
+func (w *Watcher) start() {
+	if err := w.initClients(); err != nil {
+		logger.Error(err, "")
+		return
+	}
+	w.data = data{}
+	asnRange := w.Options.ASNRange
+	if len(asnRange) == 0 {
+		asnRange = watchercore.DefaultASNRange
+	}
+	a, b, err := watchercore.ParseASNRange(asnRange)
+	if err != nil {
+		logger.Error(err, "")
+		return
+	}
+	w.data.asnStart = a
+	w.data.asnEnd = b
+
+	if err := w.mainProcessing(); err != nil {
+		logger.Error(err, "")
+	}
+}
+
+// Start .
+func (w *Watcher) Start() {
+	if w.Options.LogLevel == "debug" {
+		logger = zap.New(zap.Level(zapcore.DebugLevel), zap.UseDevMode(false))
+	} else {
+		logger = zap.New(zap.UseDevMode(false), zap.StacktraceLevel(zapcore.DPanicLevel))
+	}
+
+	logger = ctrl.Log.WithName("MetalLBWatcher")
+	debugLogger = logger.V(int(zapcore.WarnLevel))
+
+	if w.Options.RequeueInterval > 0 {
+		requeueInterval = time.Duration(time.Duration(w.Options.RequeueInterval) * time.Second)
+		contextTimeout = requeueInterval
+	}
+
+	// The processing runs periodically, so MetalLB installed after the operator is picked up.
+	wait.Until(w.start, requeueInterval, w.stop)
+}
+
+func (w *Watcher) mainProcessing() error {
+	var err error
+	if w.data.pools, err = w.MetalLB.GetIPAddressPools(w.Options.Namespace); err != nil {
+		if metallb.IsMissingResource(err) {
+			if !w.metallbNotFound {
+				logger.Info("MetalLB not detected, waiting for it to be installed")
+				w.metallbNotFound = true
+			}
+			return nil
+		}
+		return err
+	}
+	if w.metallbNotFound {
+		logger.Info("MetalLB detected")
+		w.metallbNotFound = false
+	}
+
+	if !w.Options.Enabled {
+		w.data.deleteMode = true
+	}
+
+	if w.data.deleteMode {
+		debugLogger.Info("MetalLB integration is disabled", "deleteMode", w.data.deleteMode)
+		debugLogger.Info("Clearing Netris staff", "deleteMode", w.data.deleteMode)
+		return w.deleteProcess()
+	}
+	debugLogger.Info("MetalLB integration is enabled", "deleteMode", w.data.deleteMode)
+	debugLogger.Info("Creating Netris staff", "deleteMode", w.data.deleteMode)
+	return w.process()
+}
+
+func (w *Watcher) process() error {
+	debugLogger.Info("Getting BGP advertisements", "deleteMode", w.data.deleteMode)
+	advertisements, err := w.MetalLB.GetBGPAdvertisements(w.Options.Namespace)
+	if err != nil {
+		return err
+	}
+	w.data.advertisements = advertisements
+
+	debugLogger.Info("Getting Nodes", "deleteMode", w.data.deleteMode)
+	if err := w.getNodes(); err != nil {
+		return err
+	}
+
+	debugLogger.Info("Getting speakers", "deleteMode", w.data.deleteMode)
+	if err := w.getSpeakers(); err != nil {
+		return err
+	}
+
+	debugLogger.Info("Filling Nodes AS numbers", "deleteMode", w.data.deleteMode)
+	if err := w.fillNodesASNs(); err != nil {
+		return err
+	}
+
+	debugLogger.Info("Nodes Processing", "deleteMode", w.data.deleteMode)
+	if err := w.nodesProcessing(); err != nil {
+		return err
+	}
+
+	debugLogger.Info("Generating BGPs", "deleteMode", w.data.deleteMode)
+	w.generateBGPs()
+
+	if err := w.bgpsProcessing(); err != nil {
+		return err
+	}
+
+	debugLogger.Info("BGP peers processing", "deleteMode", w.data.deleteMode)
+	return w.peersProcessing()
+}
+
+func (w *Watcher) deleteProcess() error {
+	w.data.generatedBGPs = []*v1alpha1.BGP{}
+	w.data.nodesMap = map[string]*nodeIP{}
+
+	if err := w.bgpsProcessing(); err != nil {
+		return err
+	}
+
+	debugLogger.Info("BGP peers processing", "deleteMode", w.data.deleteMode)
+	if err := w.peersProcessing(); err != nil {
+		return err
+	}
+
+	debugLogger.Info("Getting Nodes", "deleteMode", w.data.deleteMode)
+	if err := w.getNodes(); err != nil {
+		return err
+	}
+
+	debugLogger.Info("Deleting Nodes ASN annotation", "deleteMode", w.data.deleteMode)
+	return w.deleteNodesASNs()
+}
+
+// bgpsProcessing brings the BGPs created by the watcher in line with the generated ones.
+func (w *Watcher) bgpsProcessing() error {
+	debugLogger.Info("BGPs processing", "deleteMode", w.data.deleteMode)
+	return w.bgps.Sync(w.data.generatedBGPs)
+}
+
+// watcherAnnotation marks the BGPs generated by the watcher.
+const watcherAnnotation = "k8s.netris.ai/metallbwatcher"
+
+func (w *Watcher) generateBGPs() {
+	generatedBGPs := []*v1alpha1.BGP{}
+	for _, name := range w.sortedNodeNames() {
+		node := w.data.nodesMap[name]
+		for _, gw := range node.Gateways {
+			generatedBGPs = append(generatedBGPs, generateBGP(name, node, gw))
+		}
+	}
+	w.data.generatedBGPs = generatedBGPs
+}
+
+// generateBGP generates the BGP session of the speaker address with its VNet gateway.
+// Only the addresses of the pools the speaker announces are accepted, and nothing is sent
+// back, so the fabric routes never end up in the speaker's routing table.
+func generateBGP(name string, node *nodeIP, gw *watchercore.Gateway) *v1alpha1.BGP {
+	anyRoute, maxLength := "0.0.0.0/0", 32
+	if gw.IPv6 {
+		anyRoute, maxLength = "::/0", 128
+	}
+
+	PrefixListInboundList := []string{}
+	for _, cidr := range node.PoolCIDRs {
+		if watchercore.IsIPv6CIDR(cidr) != gw.IPv6 {
+			continue
+		}
+		PrefixListInboundList = append(PrefixListInboundList, fmt.Sprintf("permit %s le %d", cidr, maxLength))
+	}
+	PrefixListOutboundList := []string{fmt.Sprintf("deny %s le %d", anyRoute, maxLength)}
+
+	return watchercore.GenerateBGP(fmt.Sprintf("metallb-%s-%s", name, gw.NodeIP), node.ASN, gw, PrefixListInboundList, PrefixListOutboundList, watcherAnnotation)
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metallbwatcher
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/metallbwatcher/metallb"
+	"github.com/netrisai/netris-operator/watchercore/watchercoretest"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestAddressToCIDRs(t *testing.T) {
+	tests := []struct {
+		addr    string
+		want    []string
+		wantErr bool
+	}{
+		{addr: "192.168.10.7/24", want: []string{"192.168.10.0/24"}},
+		{addr: "192.168.10.0-192.168.10.255", want: []string{"192.168.10.0/24"}},
+		{addr: "192.168.10.5-192.168.10.12", want: []string{"192.168.10.5/32", "192.168.10.6/31", "192.168.10.8/30", "192.168.10.12/32"}},
+		{addr: "fd00:10::-fd00:10::ff", want: []string{"fd00:10::/120"}},
+		{addr: "192.168.10.12-192.168.10.5", wantErr: true},
+		{addr: "192.168.10.0-fd00::1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := addressToCIDRs(tt.addr)
+		if (err != nil) != tt.wantErr || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("addressToCIDRs(%s) = %v, %v, want %v, error %v", tt.addr, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func testObject(apiVersion, kind, name string, lbls map[string]interface{}, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name, "namespace": defaultNamespace, "labels": lbls},
+		"spec":       spec,
+	}}
+}
+
+func testSpeaker(node string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "speaker-" + node, Namespace: defaultNamespace, Labels: map[string]string{"component": "speaker"}},
+		Spec:       v1.PodSpec{NodeName: node},
+	}
+}
+
+func TestProcess(t *testing.T) {
+	w, err := NewWatcher(watchercoretest.Storage(t, "10.0.0.0/24"), nil, Options{Enabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.client = watchercoretest.NewClient(t)
+	w.clientset = kubefake.NewSimpleClientset(
+		watchercoretest.Node("node1", map[string]string{"edge": "true"}, nil, "10.0.0.11"),
+		watchercoretest.Node("node2", nil, nil, "10.0.0.12"),
+		// node3 doesn't run a speaker.
+		watchercoretest.Node("node3", nil, nil, "10.0.0.13"),
+		testSpeaker("node1"),
+		testSpeaker("node2"),
+	)
+	w.MetalLB = metallb.New(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(),
+		testObject("metallb.io/v1beta1", "IPAddressPool", "public", map[string]interface{}{"zone": "public"},
+			map[string]interface{}{"addresses": []interface{}{"192.168.10.0-192.168.10.255"}}),
+		testObject("metallb.io/v1beta1", "IPAddressPool", "private", nil,
+			map[string]interface{}{"addresses": []interface{}{"172.16.0.0/24"}}),
+		testObject("metallb.io/v1beta1", "BGPAdvertisement", "public", nil,
+			map[string]interface{}{"ipAddressPoolSelectors": []interface{}{map[string]interface{}{"matchLabels": map[string]interface{}{"zone": "public"}}}}),
+		testObject("metallb.io/v1beta1", "BGPAdvertisement", "private", nil,
+			map[string]interface{}{
+				"ipAddressPools": []interface{}{"private"},
+				"nodeSelectors":  []interface{}{map[string]interface{}{"matchLabels": map[string]interface{}{"edge": "true"}}},
+			}),
+	), metallb.Options{})
+
+	w.start()
+
+	got := make(map[string]v1alpha1.BGPSpec)
+	for name, bgp := range watchercoretest.BGPs(t, w.client) {
+		got[name] = bgp.Spec
+	}
+	want := map[string]v1alpha1.BGPSpec{
+		"metallb-node1-10.0.0.11": {
+			Site:               "site1",
+			NeighborAS:         4230000000,
+			Transport:          v1alpha1.BGPTransport{Type: "vnet", Name: "vnet1"},
+			LocalIP:            "10.0.0.1/24",
+			RemoteIP:           "10.0.0.11/24",
+			PrefixListInbound:  []string{"permit 172.16.0.0/24 le 32", "permit 192.168.10.0/24 le 32"},
+			PrefixListOutbound: []string{"deny 0.0.0.0/0 le 32"},
+		},
+		"metallb-node2-10.0.0.12": {
+			Site:               "site1",
+			NeighborAS:         4230000001,
+			Transport:          v1alpha1.BGPTransport{Type: "vnet", Name: "vnet1"},
+			LocalIP:            "10.0.0.1/24",
+			RemoteIP:           "10.0.0.12/24",
+			PrefixListInbound:  []string{"permit 192.168.10.0/24 le 32"},
+			PrefixListOutbound: []string{"deny 0.0.0.0/0 le 32"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BGPs = %+v, want %+v", got, want)
+	}
+
+	ledger, err := w.clientset.CoreV1().ConfigMaps("default").Get(context.Background(), asnLedgerName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ledger.Data) != 2 {
+		t.Errorf("ASN ledger = %v, want node1 and node2", ledger.Data)
+	}
+
+	peers, err := w.MetalLB.GetBGPPeers(defaultNamespace, peerLabel+"=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(peers) != 2 {
+		t.Fatalf("BGPPeers = %+v, want one per speaker", peers)
+	}
+	wantPeer := metallb.BGPPeerSpec{
+		MyASN:         4230000000,
+		ASN:           65001,
+		Address:       "10.0.0.1",
+		NodeSelectors: []metav1.LabelSelector{{MatchLabels: map[string]string{"kubernetes.io/hostname": "node1"}}},
+	}
+	if peers[0].Name != "netris-node1-10.0.0.11" || !reflect.DeepEqual(peers[0].Spec, wantPeer) {
+		t.Errorf("BGPPeer %s = %+v, want netris-node1-10.0.0.11 %+v", peers[0].Name, peers[0].Spec, wantPeer)
+	}
+
+	// Disabling the integration removes everything.
+	w.Options.Enabled = false
+	w.start()
+	if bgps := watchercoretest.BGPs(t, w.client); len(bgps) != 0 {
+		t.Errorf("BGPs after disabling = %+v, want none", bgps)
+	}
+	if peers, _ := w.MetalLB.GetBGPPeers(defaultNamespace, peerLabel+"=true"); len(peers) != 0 {
+		t.Errorf("BGPPeers after disabling = %+v, want none", peers)
+	}
+	node, _ := w.clientset.CoreV1().Nodes().Get(context.Background(), "node1", metav1.GetOptions{})
+	if asn, ok := node.GetAnnotations()[asnAnnotation]; ok {
+		t.Errorf("node1 ASN after disabling = %s, want none", asn)
+	}
+	if _, err := w.clientset.CoreV1().ConfigMaps("default").Get(context.Background(), asnLedgerName, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Errorf("ASN ledger after disabling: %v, want not found", err)
+	}
+}
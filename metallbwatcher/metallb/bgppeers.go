@@ -0,0 +1,134 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metallb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// BGPPeer is the MetalLB BGP session of the speakers with a router.
+type BGPPeer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              BGPPeerSpec `json:"spec"`
+}
+
+// BGPPeerSpec contains the fields of the peer managed by the operator,
+// the defaults filled by the API server are dropped.
+type BGPPeerSpec struct {
+	MyASN         int64                  `json:"myASN"`
+	ASN           int64                  `json:"peerASN"`
+	Address       string                 `json:"peerAddress"`
+	NodeSelectors []metav1.LabelSelector `json:"nodeSelectors,omitempty"`
+}
+
+var bgpPeerResource = schema.GroupVersionResource{
+	Group:    "metallb.io",
+	Version:  "v1beta2",
+	Resource: "bgppeers",
+}
+
+// GetBGPPeers returns the peers of the namespace matching the label selector.
+func (m *MetalLB) GetBGPPeers(namespace, selector string) ([]*BGPPeer, error) {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+
+	list, err := m.client.Resource(bgpPeerResource).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+
+	var peers []*BGPPeer
+	for _, item := range list.Items {
+		js, err := item.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("{GetBGPPeers} %s", err)
+		}
+
+		var peer *BGPPeer
+		err = json.Unmarshal(js, &peer)
+		if err != nil {
+			return nil, fmt.Errorf("{GetBGPPeers} %s", err)
+		}
+		peers = append(peers, peer)
+	}
+	return peers, nil
+}
+
+// CreateBGPPeer .
+func (m *MetalLB) CreateBGPPeer(peer *BGPPeer) error {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+
+	obj, err := peerToUnstructured(peer)
+	if err != nil {
+		return fmt.Errorf("{CreateBGPPeer} %s", err)
+	}
+
+	_, err = m.client.Resource(bgpPeerResource).Namespace(peer.Namespace).Create(ctx, obj, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("{CreateBGPPeer} %s", err)
+	}
+	return nil
+}
+
+// UpdateBGPPeer .
+func (m *MetalLB) UpdateBGPPeer(peer *BGPPeer) error {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+
+	obj, err := peerToUnstructured(peer)
+	if err != nil {
+		return fmt.Errorf("{UpdateBGPPeer} %s", err)
+	}
+
+	_, err = m.client.Resource(bgpPeerResource).Namespace(peer.Namespace).Update(ctx, obj, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("{UpdateBGPPeer} %s", err)
+	}
+	return nil
+}
+
+// DeleteBGPPeer .
+func (m *MetalLB) DeleteBGPPeer(namespace, name string) error {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+
+	err := m.client.Resource(bgpPeerResource).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("{DeleteBGPPeer} %s", err)
+	}
+	return nil
+}
+
+func peerToUnstructured(peer *BGPPeer) (*unstructured.Unstructured, error) {
+	peer.APIVersion = bgpPeerResource.GroupVersion().String()
+	peer.Kind = "BGPPeer"
+
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(peer)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: m}, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metallb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// IPAddressPool is the MetalLB pool of the LoadBalancer addresses.
+type IPAddressPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              IPAddressPoolSpec `json:"spec"`
+}
+
+// IPAddressPoolSpec .
+type IPAddressPoolSpec struct {
+	// Addresses are CIDRs or "first-last" address ranges.
+	Addresses []string `json:"addresses"`
+}
+
+// BGPAdvertisement selects the pools and the nodes announcing them over BGP.
+type BGPAdvertisement struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              BGPAdvertisementSpec `json:"spec,omitempty"`
+}
+
+// BGPAdvertisementSpec .
+type BGPAdvertisementSpec struct {
+	// IPAddressPools and IPAddressPoolSelectors select the pools, all pools are advertised if both are empty.
+	IPAddressPools         []string               `json:"ipAddressPools,omitempty"`
+	IPAddressPoolSelectors []metav1.LabelSelector `json:"ipAddressPoolSelectors,omitempty"`
+	// NodeSelectors limit the nodes announcing the pools, all nodes announce them if empty.
+	NodeSelectors []metav1.LabelSelector `json:"nodeSelectors,omitempty"`
+}
+
+var ipAddressPoolResource = schema.GroupVersionResource{
+	Group:    "metallb.io",
+	Version:  "v1beta1",
+	Resource: "ipaddresspools",
+}
+
+var bgpAdvertisementResource = schema.GroupVersionResource{
+	Group:    "metallb.io",
+	Version:  "v1beta1",
+	Resource: "bgpadvertisements",
+}
+
+// GetIPAddressPools .
+func (m *MetalLB) GetIPAddressPools(namespace string) ([]*IPAddressPool, error) {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+
+	list, err := m.client.Resource(ipAddressPoolResource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var pools []*IPAddressPool
+	for _, item := range list.Items {
+		js, err := item.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("{GetIPAddressPools} %s", err)
+		}
+
+		var pool *IPAddressPool
+		err = json.Unmarshal(js, &pool)
+		if err != nil {
+			return nil, fmt.Errorf("{GetIPAddressPools} %s", err)
+		}
+		pools = append(pools, pool)
+	}
+	return pools, nil
+}
+
+// GetBGPAdvertisements .
+func (m *MetalLB) GetBGPAdvertisements(namespace string) ([]*BGPAdvertisement, error) {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+
+	list, err := m.client.Resource(bgpAdvertisementResource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var advertisements []*BGPAdvertisement
+	for _, item := range list.Items {
+		js, err := item.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("{GetBGPAdvertisements} %s", err)
+		}
+
+		var advertisement *BGPAdvertisement
+		err = json.Unmarshal(js, &advertisement)
+		if err != nil {
+			return nil, fmt.Errorf("{GetBGPAdvertisements} %s", err)
+		}
+		advertisements = append(advertisements, advertisement)
+	}
+	return advertisements, nil
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metallb
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/dynamic"
+)
+
+var (
+	cntxt          = context.Background()
+	contextTimeout = time.Duration(10 * time.Second)
+)
+
+// MetalLB .
+type MetalLB struct {
+	options Options
+	client  dynamic.Interface
+}
+
+// Options .
+type Options struct {
+	ContextTimeout int
+}
+
+// New creates the new metallb client on top of the dynamic client.
+func New(client dynamic.Interface, options Options) *MetalLB {
+	if options.ContextTimeout > 0 {
+		contextTimeout = time.Duration(time.Duration(options.ContextTimeout) * time.Second)
+	}
+	return &MetalLB{
+		options: options,
+		client:  client,
+	}
+}
+
+// IsMissingResource error message parser for missing metallb case.
+func IsMissingResource(err error) bool {
+	return apierrors.IsNotFound(err) || err.Error() == "the server could not find the requested resource"
+}
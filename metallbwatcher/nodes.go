@@ -0,0 +1,177 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metallbwatcher
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strconv"
+
+	"github.com/netrisai/netris-operator/watchercore"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// speakerLabels are the labels of the speaker pods in the manifests and in the Helm chart of MetalLB.
+var speakerLabels = map[string]string{
+	"component":                   "speaker",
+	"app.kubernetes.io/component": "speaker",
+}
+
+// nodeIP is a speaker node with its AS number, the pools it announces and the VNet gateways of its addresses.
+type nodeIP struct {
+	ASN       int
+	Hostname  string
+	PoolCIDRs []string
+	Gateways  []*watchercore.Gateway
+}
+
+func (w *Watcher) getNodes() error {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	nodes, err := w.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	w.data.nodes = make(map[string]*v1.Node)
+	for i := range nodes.Items {
+		w.data.nodes[nodes.Items[i].Name] = &nodes.Items[i]
+	}
+	return nil
+}
+
+// getSpeakers finds the nodes running the MetalLB speaker.
+func (w *Watcher) getSpeakers() error {
+	ctx, cancel := context.WithTimeout(cntxt, contextTimeout)
+	defer cancel()
+	pods, err := w.clientset.CoreV1().Pods(w.Options.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	speakers := make(map[string]bool)
+	for _, pod := range pods.Items {
+		if !isSpeaker(&pod) || pod.Spec.NodeName == "" {
+			continue
+		}
+		if _, ok := w.data.nodes[pod.Spec.NodeName]; ok {
+			speakers[pod.Spec.NodeName] = true
+		}
+	}
+
+	w.data.speakers = []string{}
+	for name := range speakers {
+		w.data.speakers = append(w.data.speakers, name)
+	}
+	sort.Strings(w.data.speakers)
+	return nil
+}
+
+func isSpeaker(pod *v1.Pod) bool {
+	for key, value := range speakerLabels {
+		if pod.GetLabels()[key] == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Watcher) nodesProcessing() error {
+	nodesMap := make(map[string]*nodeIP)
+
+	for _, name := range w.data.speakers {
+		node := w.data.nodes[name]
+
+		asn, err := strconv.Atoi(node.GetAnnotations()[asnAnnotation])
+		if err != nil {
+			logger.Info("Skipping node", "node", node.Name, "error", "couldn't get as number")
+			continue
+		}
+
+		hostname := node.GetLabels()["kubernetes.io/hostname"]
+		if hostname == "" {
+			hostname = node.Name
+		}
+
+		poolCIDRs, err := w.nodePoolCIDRs(node)
+		if err != nil {
+			return err
+		}
+
+		tmpNode := &nodeIP{
+			ASN:       asn,
+			Hostname:  hostname,
+			PoolCIDRs: poolCIDRs,
+		}
+
+		for _, addr := range nodeAddresses(node) {
+			gw, err := watchercore.FindGateway(w.NStorage, addr)
+			if err != nil {
+				logger.Info("Skipping node address", "node", node.Name, "address", addr.String(), "error", err.Error())
+				continue
+			}
+			tmpNode.Gateways = append(tmpNode.Gateways, gw)
+		}
+		if len(tmpNode.Gateways) == 0 {
+			logger.Info("Skipping node", "node", node.Name, "error", "couldn't find vnet gateway")
+			continue
+		}
+
+		nodesMap[node.Name] = tmpNode
+	}
+
+	w.data.nodesMap = nodesMap
+	return nil
+}
+
+// nodeAddresses returns the first internal address of each IP family of the node.
+func nodeAddresses(node *v1.Node) []net.IP {
+	var ipv4, ipv6 net.IP
+	for _, addr := range node.Status.Addresses {
+		if addr.Type != v1.NodeInternalIP {
+			continue
+		}
+		ip := net.ParseIP(addr.Address)
+		if ip == nil {
+			continue
+		}
+		if ip.To4() != nil && ipv4 == nil {
+			ipv4 = ip
+		} else if ip.To4() == nil && ipv6 == nil {
+			ipv6 = ip
+		}
+	}
+
+	addrs := []net.IP{}
+	for _, ip := range []net.IP{ipv4, ipv6} {
+		if ip != nil {
+			addrs = append(addrs, ip)
+		}
+	}
+	return addrs
+}
+
+func (w *Watcher) sortedNodeNames() []string {
+	names := []string{}
+	for name := range w.data.nodesMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metallbwatcher
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/netrisai/netris-operator/metallbwatcher/metallb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	peerPrefix = "netris"
+	// peerLabel marks the MetalLB BGPPeers managed by the watcher.
+	peerLabel = "k8s.netris.ai/metallbwatcher"
+)
+
+// generatePeers generates a MetalLB BGPPeer per speaker address. The speaker of the node
+// speaks in the AS of the node and peers with the VNet gateway in the site's public AS.
+func (w *Watcher) generatePeers() []*metallb.BGPPeer {
+	nameReg, _ := regexp.Compile("[^a-z0-9.]+")
+
+	peers := []*metallb.BGPPeer{}
+	for _, name := range w.sortedNodeNames() {
+		node := w.data.nodesMap[name]
+		for _, gw := range node.Gateways {
+			peerName := strings.Join([]string{peerPrefix, name, gw.NodeIP}, "-")
+			peer := &metallb.BGPPeer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      strings.Trim(nameReg.ReplaceAllString(peerName, "-"), "-"),
+					Namespace: w.Options.Namespace,
+					Labels:    map[string]string{peerLabel: "true"},
+				},
+				Spec: metallb.BGPPeerSpec{
+					MyASN:   int64(node.ASN),
+					ASN:     int64(gw.SiteASN),
+					Address: gw.IP(),
+					NodeSelectors: []metav1.LabelSelector{{
+						MatchLabels: map[string]string{"kubernetes.io/hostname": node.Hostname},
+					}},
+				},
+			}
+			peers = append(peers, peer)
+		}
+	}
+	return peers
+}
+
+// peersProcessing creates and updates the generated BGPPeers and deletes the stale ones.
+func (w *Watcher) peersProcessing() error {
+	peers, err := w.MetalLB.GetBGPPeers(w.Options.Namespace, peerLabel+"=true")
+	if err != nil {
+		return err
+	}
+
+	peersMap := make(map[string]*metallb.BGPPeer)
+	for _, peer := range peers {
+		peersMap[peer.Name] = peer
+	}
+
+	generated := []*metallb.BGPPeer{}
+	if !w.data.deleteMode {
+		generated = w.generatePeers()
+	}
+
+	generatedMap := make(map[string]bool)
+	for _, peer := range generated {
+		generatedMap[peer.Name] = true
+		existing, ok := peersMap[peer.Name]
+		if !ok {
+			debugLogger.Info("Creating BGP peer", "peer", peer.Name, "deleteMode", w.data.deleteMode)
+			if err := w.MetalLB.CreateBGPPeer(peer); err != nil {
+				return err
+			}
+			logger.Info("BGP peer is created", "peer", peer.Name)
+			continue
+		}
+		if reflect.DeepEqual(existing.Spec, peer.Spec) {
+			continue
+		}
+		debugLogger.Info("Updating BGP peer", "peer", peer.Name, "deleteMode", w.data.deleteMode)
+		peer.ResourceVersion = existing.ResourceVersion
+		if err := w.MetalLB.UpdateBGPPeer(peer); err != nil {
+			return err
+		}
+		logger.Info("BGP peer is updated", "peer", peer.Name)
+	}
+
+	for _, peer := range peers {
+		if generatedMap[peer.Name] {
+			continue
+		}
+		debugLogger.Info("Deleting BGP peer", "peer", peer.Name, "deleteMode", w.data.deleteMode)
+		if err := w.MetalLB.DeleteBGPPeer(peer.Namespace, peer.Name); err != nil {
+			return err
+		}
+		logger.Info("BGP peer is deleted", "peer", peer.Name)
+	}
+	return nil
+}
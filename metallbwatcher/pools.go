@@ -0,0 +1,168 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metallbwatcher
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/netrisai/netris-operator/metallbwatcher/metallb"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// nodePoolCIDRs returns the CIDRs of the pools announced by the node.
+// A pool is announced by the node if a BGPAdvertisement selects both of them.
+func (w *Watcher) nodePoolCIDRs(node *v1.Node) ([]string, error) {
+	poolNames := make(map[string]bool)
+	for _, adv := range w.data.advertisements {
+		ok, err := selectorsMatch(adv.Spec.NodeSelectors, node.GetLabels())
+		if err != nil {
+			return nil, fmt.Errorf("{nodePoolCIDRs} BGPAdvertisement %s: %s", adv.Name, err)
+		}
+		if !ok {
+			continue
+		}
+		for _, pool := range w.data.pools {
+			ok, err := advertisesPool(adv, pool)
+			if err != nil {
+				return nil, fmt.Errorf("{nodePoolCIDRs} BGPAdvertisement %s: %s", adv.Name, err)
+			}
+			if ok {
+				poolNames[pool.Name] = true
+			}
+		}
+	}
+
+	pools := append([]*metallb.IPAddressPool{}, w.data.pools...)
+	sort.Slice(pools, func(i, j int) bool { return pools[i].Name < pools[j].Name })
+
+	cidrs := []string{}
+	seen := make(map[string]bool)
+	for _, pool := range pools {
+		if !poolNames[pool.Name] {
+			continue
+		}
+		for _, addr := range pool.Spec.Addresses {
+			poolCIDRs, err := addressToCIDRs(addr)
+			if err != nil {
+				logger.Info("Skipping invalid pool address", "pool", pool.Name, "address", addr, "error", err.Error())
+				continue
+			}
+			for _, cidr := range poolCIDRs {
+				if !seen[cidr] {
+					seen[cidr] = true
+					cidrs = append(cidrs, cidr)
+				}
+			}
+		}
+	}
+	return cidrs, nil
+}
+
+// advertisesPool reports whether the advertisement selects the pool, an advertisement without pools selects all of them.
+func advertisesPool(adv *metallb.BGPAdvertisement, pool *metallb.IPAddressPool) (bool, error) {
+	if len(adv.Spec.IPAddressPools) == 0 && len(adv.Spec.IPAddressPoolSelectors) == 0 {
+		return true, nil
+	}
+	for _, name := range adv.Spec.IPAddressPools {
+		if name == pool.Name {
+			return true, nil
+		}
+	}
+	if len(adv.Spec.IPAddressPoolSelectors) == 0 {
+		return false, nil
+	}
+	return selectorsMatch(adv.Spec.IPAddressPoolSelectors, pool.GetLabels())
+}
+
+// selectorsMatch reports whether any of the selectors matches the labels, empty selectors match everything.
+func selectorsMatch(selectors []metav1.LabelSelector, lbls map[string]string) (bool, error) {
+	if len(selectors) == 0 {
+		return true, nil
+	}
+	for i := range selectors {
+		selector, err := metav1.LabelSelectorAsSelector(&selectors[i])
+		if err != nil {
+			return false, err
+		}
+		if selector.Matches(labels.Set(lbls)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// addressToCIDRs converts a pool address, a CIDR or a "first-last" range, to the CIDRs covering it.
+func addressToCIDRs(addr string) ([]string, error) {
+	if strings.Contains(addr, "/") {
+		_, ipNet, err := net.ParseCIDR(addr)
+		if err != nil {
+			return nil, err
+		}
+		return []string{ipNet.String()}, nil
+	}
+
+	bounds := strings.Split(addr, "-")
+	if len(bounds) != 2 {
+		return nil, fmt.Errorf("invalid address %s", addr)
+	}
+	first := net.ParseIP(strings.TrimSpace(bounds[0]))
+	last := net.ParseIP(strings.TrimSpace(bounds[1]))
+	if first == nil || last == nil || (first.To4() == nil) != (last.To4() == nil) {
+		return nil, fmt.Errorf("invalid address range %s", addr)
+	}
+
+	bits := 128
+	if first.To4() != nil {
+		bits = 32
+		first, last = first.To4(), last.To4()
+	}
+	start := new(big.Int).SetBytes(first)
+	end := new(big.Int).SetBytes(last)
+	if start.Cmp(end) > 0 {
+		return nil, fmt.Errorf("invalid address range %s", addr)
+	}
+
+	one := big.NewInt(1)
+	cidrs := []string{}
+	for start.Cmp(end) <= 0 {
+		// The largest block aligned at start that doesn't go past end.
+		size := bits
+		if start.Sign() != 0 {
+			size = int(start.TrailingZeroBits())
+		}
+		for size > 0 {
+			blockEnd := new(big.Int).Lsh(one, uint(size))
+			blockEnd.Add(blockEnd, start).Sub(blockEnd, one)
+			if blockEnd.Cmp(end) <= 0 {
+				break
+			}
+			size--
+		}
+
+		ip := make(net.IP, bits/8)
+		start.FillBytes(ip)
+		cidrs = append(cidrs, fmt.Sprintf("%s/%d", ip.String(), bits-size))
+		start.Add(start, new(big.Int).Lsh(one, uint(size)))
+	}
+	return cidrs, nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netrisstorage
+
+import (
+	"sync"
+
+	"github.com/netrisai/netriswebapi/v1/types/bgpobject"
+)
+
+// BGPObjectStorage caches BGP objects retrieved from Netris API.
+type BGPObjectStorage struct {
+	sync.Mutex
+	BGPObjects []*bgpobject.BGPObject
+}
+
+// NewBGPObjectStorage creates new BGP object storage.
+func NewBGPObjectStorage() *BGPObjectStorage {
+	return &BGPObjectStorage{}
+}
+
+// GetAll returns a copy of cached BGP objects.
+func (p *BGPObjectStorage) GetAll() []bgpobject.BGPObject {
+	p.Lock()
+	defer p.Unlock()
+	objects := []bgpobject.BGPObject{}
+	for _, item := range p.BGPObjects {
+		objects = append(objects, *item)
+	}
+	return objects
+}
+
+// FindByName returns BGP object by name if present in cache, triggering refresh on miss.
+func (p *BGPObjectStorage) FindByName(name string) (*bgpobject.BGPObject, bool) {
+	p.Lock()
+	defer p.Unlock()
+	item, ok := p.findByName(name)
+	if !ok {
+		_ = p.download()
+		return p.findByName(name)
+	}
+	return item, ok
+}
+
+func (p *BGPObjectStorage) findByName(name string) (*bgpobject.BGPObject, bool) {
+	for _, item := range p.BGPObjects {
+		if item.Name == name {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+// FindByID returns BGP object by ID, refreshing cache on miss.
+func (p *BGPObjectStorage) FindByID(id int) (*bgpobject.BGPObject, bool) {
+	p.Lock()
+	defer p.Unlock()
+	item, ok := p.findByID(id)
+	if !ok {
+		_ = p.download()
+		return p.findByID(id)
+	}
+	return item, ok
+}
+
+func (p *BGPObjectStorage) findByID(id int) (*bgpobject.BGPObject, bool) {
+	for _, item := range p.BGPObjects {
+		if item.ID == id {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+func (p *BGPObjectStorage) storeAll(items []*bgpobject.BGPObject) {
+	p.BGPObjects = items
+}
+
+func (p *BGPObjectStorage) download() error {
+	items, err := Cred.BGPObject().Get()
+	if err != nil {
+		return err
+	}
+	p.storeAll(items)
+	return nil
+}
+
+// Download refreshes cached BGP objects.
+func (p *BGPObjectStorage) Download() error {
+	p.Lock()
+	defer p.Unlock()
+	return p.download()
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netrisstorage
+
+import (
+	"sync"
+
+	"github.com/netrisai/netriswebapi/v2/types/dhcp"
+)
+
+// DHCPOptionSetStorage caches DHCP option sets retrieved from Netris API.
+type DHCPOptionSetStorage struct {
+	sync.Mutex
+	DHCPOptionSets []*dhcp.DHCPOptionSet
+}
+
+// NewDHCPOptionSetStorage creates new DHCP option set storage.
+func NewDHCPOptionSetStorage() *DHCPOptionSetStorage {
+	return &DHCPOptionSetStorage{}
+}
+
+// GetAll returns a copy of cached DHCP option sets.
+func (p *DHCPOptionSetStorage) GetAll() []dhcp.DHCPOptionSet {
+	p.Lock()
+	defer p.Unlock()
+	sets := []dhcp.DHCPOptionSet{}
+	for _, item := range p.DHCPOptionSets {
+		sets = append(sets, *item)
+	}
+	return sets
+}
+
+// FindByName returns DHCP option set by name if present in cache, triggering refresh on miss.
+func (p *DHCPOptionSetStorage) FindByName(name string) (*dhcp.DHCPOptionSet, bool) {
+	p.Lock()
+	defer p.Unlock()
+	item, ok := p.findByName(name)
+	if !ok {
+		_ = p.download()
+		return p.findByName(name)
+	}
+	return item, ok
+}
+
+func (p *DHCPOptionSetStorage) findByName(name string) (*dhcp.DHCPOptionSet, bool) {
+	for _, item := range p.DHCPOptionSets {
+		if item.Name == name {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+// FindByID returns DHCP option set by ID, refreshing cache on miss.
+func (p *DHCPOptionSetStorage) FindByID(id int) (*dhcp.DHCPOptionSet, bool) {
+	p.Lock()
+	defer p.Unlock()
+	item, ok := p.findByID(id)
+	if !ok {
+		_ = p.download()
+		return p.findByID(id)
+	}
+	return item, ok
+}
+
+func (p *DHCPOptionSetStorage) findByID(id int) (*dhcp.DHCPOptionSet, bool) {
+	for _, item := range p.DHCPOptionSets {
+		if item.ID == id {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+func (p *DHCPOptionSetStorage) storeAll(items []*dhcp.DHCPOptionSet) {
+	p.DHCPOptionSets = items
+}
+
+func (p *DHCPOptionSetStorage) download() error {
+	items, err := Cred.DHCP().Get()
+	if err != nil {
+		return err
+	}
+	p.storeAll(items)
+	return nil
+}
+
+// Download refreshes cached DHCP option sets.
+func (p *DHCPOptionSetStorage) Download() error {
+	p.Lock()
+	defer p.Unlock()
+	return p.download()
+}
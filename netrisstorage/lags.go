@@ -0,0 +1,146 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netrisstorage
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/netrisai/netriswebapi/v2/types/port"
+)
+
+// LAGStorage caches aggregated ports retrieved from Netris API.
+type LAGStorage struct {
+	sync.Mutex
+	LAGs []*port.AggregatedPort
+}
+
+// NewLAGStorage creates new LAG storage.
+func NewLAGStorage() *LAGStorage {
+	return &LAGStorage{}
+}
+
+// GetAll returns a copy of cached aggregated ports.
+func (p *LAGStorage) GetAll() []port.AggregatedPort {
+	p.Lock()
+	defer p.Unlock()
+	lags := []port.AggregatedPort{}
+	for _, item := range p.LAGs {
+		lags = append(lags, *item)
+	}
+	return lags
+}
+
+// FindByName returns aggregated port by name if present in cache, triggering refresh on miss.
+func (p *LAGStorage) FindByName(name string) (*port.AggregatedPort, bool) {
+	p.Lock()
+	defer p.Unlock()
+	item, ok := p.findByName(name)
+	if !ok {
+		_ = p.download()
+		return p.findByName(name)
+	}
+	return item, ok
+}
+
+func (p *LAGStorage) findByName(name string) (*port.AggregatedPort, bool) {
+	for _, item := range p.LAGs {
+		if item.Name == name {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+// FindByID returns aggregated port by ID, refreshing cache on miss.
+func (p *LAGStorage) FindByID(id int) (*port.AggregatedPort, bool) {
+	p.Lock()
+	defer p.Unlock()
+	item, ok := p.findByID(id)
+	if !ok {
+		_ = p.download()
+		return p.findByID(id)
+	}
+	return item, ok
+}
+
+func (p *LAGStorage) findByID(id int) (*port.AggregatedPort, bool) {
+	for _, item := range p.LAGs {
+		if item.ID == id {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+// FindByMembers returns the aggregated port whose member ports exactly match the given port IDs, refreshing cache on miss.
+func (p *LAGStorage) FindByMembers(ids []int) (*port.AggregatedPort, bool) {
+	p.Lock()
+	defer p.Unlock()
+	item, ok := p.findByMembers(ids)
+	if !ok {
+		_ = p.download()
+		return p.findByMembers(ids)
+	}
+	return item, ok
+}
+
+func (p *LAGStorage) findByMembers(ids []int) (*port.AggregatedPort, bool) {
+	want := append([]int{}, ids...)
+	sort.Ints(want)
+	for _, item := range p.LAGs {
+		if len(item.LagMembers) != len(want) {
+			continue
+		}
+		got := []int{}
+		for _, member := range item.LagMembers {
+			got = append(got, member.ID)
+		}
+		sort.Ints(got)
+		match := true
+		for i := range got {
+			if got[i] != want[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+func (p *LAGStorage) storeAll(items []*port.AggregatedPort) {
+	p.LAGs = items
+}
+
+func (p *LAGStorage) download() error {
+	items, err := Cred.Port().GetAggregatedPorts()
+	if err != nil {
+		return err
+	}
+	p.storeAll(items)
+	return nil
+}
+
+// Download refreshes cached aggregated ports.
+func (p *LAGStorage) Download() error {
+	p.Lock()
+	defer p.Unlock()
+	return p.download()
+}
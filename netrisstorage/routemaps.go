@@ -0,0 +1,107 @@
+/*
+Copyright 2025. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netrisstorage
+
+import (
+	"sync"
+
+	"github.com/netrisai/netriswebapi/v1/types/routemap"
+)
+
+// RouteMapStorage caches route maps retrieved from Netris API.
+type RouteMapStorage struct {
+	sync.Mutex
+	RouteMaps []*routemap.RouteMap
+}
+
+// NewRouteMapStorage creates new route map storage.
+func NewRouteMapStorage() *RouteMapStorage {
+	return &RouteMapStorage{}
+}
+
+// GetAll returns a copy of cached route maps.
+func (p *RouteMapStorage) GetAll() []routemap.RouteMap {
+	p.Lock()
+	defer p.Unlock()
+	routeMaps := []routemap.RouteMap{}
+	for _, item := range p.RouteMaps {
+		routeMaps = append(routeMaps, *item)
+	}
+	return routeMaps
+}
+
+// FindByName returns route map by name if present in cache, triggering refresh on miss.
+func (p *RouteMapStorage) FindByName(name string) (*routemap.RouteMap, bool) {
+	p.Lock()
+	defer p.Unlock()
+	item, ok := p.findByName(name)
+	if !ok {
+		_ = p.download()
+		return p.findByName(name)
+	}
+	return item, ok
+}
+
+func (p *RouteMapStorage) findByName(name string) (*routemap.RouteMap, bool) {
+	for _, item := range p.RouteMaps {
+		if item.Name == name {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+// FindByID returns route map by ID, refreshing cache on miss.
+func (p *RouteMapStorage) FindByID(id int) (*routemap.RouteMap, bool) {
+	p.Lock()
+	defer p.Unlock()
+	item, ok := p.findByID(id)
+	if !ok {
+		_ = p.download()
+		return p.findByID(id)
+	}
+	return item, ok
+}
+
+func (p *RouteMapStorage) findByID(id int) (*routemap.RouteMap, bool) {
+	for _, item := range p.RouteMaps {
+		if item.ID == id {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+func (p *RouteMapStorage) storeAll(items []*routemap.RouteMap) {
+	p.RouteMaps = items
+}
+
+func (p *RouteMapStorage) download() error {
+	items, err := Cred.RouteMap().Get()
+	if err != nil {
+		return err
+	}
+	p.storeAll(items)
+	return nil
+}
+
+// Download refreshes cached route maps.
+func (p *RouteMapStorage) Download() error {
+	p.Lock()
+	defer p.Unlock()
+	return p.download()
+}
@@ -39,7 +39,11 @@ type Storage struct {
 	*TenantsStorage
 	*VNetStorage
 	*VPCStorage
+	*LAGStorage
+	*DHCPOptionSetStorage
 	*BGPStorage
+	*RouteMapStorage
+	*BGPObjectStorage
 	*L4LBStorage
 	*SubnetsStorage
 	*HWsStorage
@@ -59,7 +63,11 @@ func NewStorage(cred *api.Clientset) *Storage {
 		TenantsStorage:               NewTenantsStorage(),
 		VNetStorage:                  NewVNetStorage(),
 		VPCStorage:                   NewVPCStorage(),
+		LAGStorage:                   NewLAGStorage(),
+		DHCPOptionSetStorage:         NewDHCPOptionSetStorage(),
 		BGPStorage:                   NewBGPStorage(),
+		RouteMapStorage:              NewRouteMapStorage(),
+		BGPObjectStorage:             NewBGPObjectStorage(),
 		L4LBStorage:                  NewL4LBStorage(),
 		SubnetsStorage:               NewSubnetsStorage(),
 		HWsStorage:                   NewHWsStorage(),
@@ -95,10 +103,26 @@ func (s *Storage) Download() error {
 		fmt.Println("VPCStorage", err)
 		return err
 	}
+	if err := s.LAGStorage.Download(); err != nil {
+		fmt.Println("LAGStorage", err)
+		return err
+	}
+	if err := s.DHCPOptionSetStorage.Download(); err != nil {
+		fmt.Println("DHCPOptionSetStorage", err)
+		return err
+	}
 	if err := s.BGPStorage.Download(); err != nil {
 		fmt.Println("BGPStorage", err)
 		return err
 	}
+	if err := s.RouteMapStorage.Download(); err != nil {
+		fmt.Println("RouteMapStorage", err)
+		return err
+	}
+	if err := s.BGPObjectStorage.Download(); err != nil {
+		fmt.Println("BGPObjectStorage", err)
+		return err
+	}
 	if err := s.L4LBStorage.Download(); err != nil {
 		fmt.Println("L4LBStorage", err)
 		return err
@@ -0,0 +1,289 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package watchercore holds the parts shared by the watchers peering the cluster nodes with the fabric:
+// the AS number ledger of the nodes, the BGPs generated for them and the VNet gateway lookup.
+package watchercore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/zap/zapcore"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// DefaultASNReclaimAfter is how long the AS number of a removed node is kept for a node re-created with the same name.
+const DefaultASNReclaimAfter = 24 * time.Hour
+
+// ASNAllocation is the ledger entry of a node.
+type ASNAllocation struct {
+	ASN int `json:"asn"`
+	// ReleasedAt is set once the node is gone, the ASN is reclaimed after the grace period.
+	ReleasedAt *metav1.Time `json:"releasedAt,omitempty"`
+}
+
+// ASNLedger assigns the AS numbers of a range to the nodes. The assignments are recorded in a ConfigMap
+// updated with the resourceVersion it was read with, so concurrent replicas never hand out the same ASN,
+// and are mirrored in a node annotation.
+type ASNLedger struct {
+	Clientset kubernetes.Interface
+	Logger    logr.Logger
+	// Name and Namespace of the ledger ConfigMap.
+	Name      string
+	Namespace string
+	// Annotation is the node annotation holding the AS number.
+	Annotation     string
+	ContextTimeout time.Duration
+}
+
+// load loads the ledger. The returned ConfigMap is nil if the ledger doesn't exist yet.
+func (l *ASNLedger) load() (*v1.ConfigMap, map[string]*ASNAllocation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), l.ContextTimeout)
+	defer cancel()
+
+	allocations := make(map[string]*ASNAllocation)
+	cm, err := l.Clientset.CoreV1().ConfigMaps(l.Namespace).Get(ctx, l.Name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, allocations, nil
+		}
+		return nil, nil, err
+	}
+
+	for node, value := range cm.Data {
+		allocation := &ASNAllocation{}
+		if err := json.Unmarshal([]byte(value), allocation); err != nil {
+			l.Logger.Info("Skipping invalid ASN ledger entry", "ledger", l.Name, "node", node, "error", err.Error())
+			continue
+		}
+		allocations[node] = allocation
+	}
+	return cm, allocations, nil
+}
+
+// save writes the ledger. The update carries the resourceVersion it was read with,
+// so a concurrent change by another replica fails with a conflict instead of being overwritten.
+func (l *ASNLedger) save(cm *v1.ConfigMap, allocations map[string]*ASNAllocation) error {
+	ctx, cancel := context.WithTimeout(context.Background(), l.ContextTimeout)
+	defer cancel()
+
+	data := make(map[string]string)
+	for node, allocation := range allocations {
+		js, _ := json.Marshal(allocation)
+		data[node] = string(js)
+	}
+
+	if cm == nil {
+		cm = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      l.Name,
+				Namespace: l.Namespace,
+			},
+			Data: data,
+		}
+		_, err := l.Clientset.CoreV1().ConfigMaps(cm.Namespace).Create(ctx, cm, metav1.CreateOptions{})
+		if errors.IsAlreadyExists(err) {
+			return errors.NewConflict(v1.Resource("configmaps"), l.Name, err)
+		}
+		return err
+	}
+
+	cm.Data = data
+	_, err := l.Clientset.CoreV1().ConfigMaps(cm.Namespace).Update(ctx, cm, metav1.UpdateOptions{})
+	return err
+}
+
+// AllocateASNs reconciles the ledger with the nodes: nodes keep the ASN recorded for their name,
+// nodes without one adopt the ASN of their annotation if it's in the range and free, the rest get
+// the first free ASN of the range, and ASNs of removed nodes are reclaimed after the grace period.
+// It reports whether the ledger changed.
+func AllocateASNs(allocations map[string]*ASNAllocation, nodes []*v1.Node, annotation string, start, end int, reclaimAfter time.Duration, now time.Time) (bool, error) {
+	changed := false
+	live := make(map[string]bool)
+	for _, node := range nodes {
+		live[node.Name] = true
+	}
+
+	// ASNs of removed nodes are reclaimed first, so they are free for the new nodes.
+	for name, allocation := range allocations {
+		if live[name] {
+			continue
+		}
+		if allocation.ReleasedAt == nil {
+			allocation.ReleasedAt = &metav1.Time{Time: now}
+			changed = true
+		} else if now.Sub(allocation.ReleasedAt.Time) >= reclaimAfter {
+			delete(allocations, name)
+			changed = true
+		}
+	}
+
+	used := make(map[int]string)
+	for name, allocation := range allocations {
+		used[allocation.ASN] = name
+	}
+
+	// Nodes are processed in name order, so concurrent replicas make the same decisions.
+	sorted := append([]*v1.Node{}, nodes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	// Existing annotations within the range are adopted first, unless another node holds the ASN.
+	for _, node := range sorted {
+		if _, ok := allocations[node.Name]; ok {
+			continue
+		}
+		asn, err := strconv.Atoi(node.GetAnnotations()[annotation])
+		if err != nil || asn < start || asn > end {
+			continue
+		}
+		if _, ok := used[asn]; ok {
+			continue
+		}
+		allocations[node.Name] = &ASNAllocation{ASN: asn}
+		used[asn] = node.Name
+		changed = true
+	}
+
+	// ASNs are only taken from here on, so everything below the cursor stays used and
+	// the range is scanned once for all the nodes.
+	next := start
+	for _, node := range sorted {
+		if allocation, ok := allocations[node.Name]; ok {
+			if allocation.ReleasedAt != nil {
+				allocation.ReleasedAt = nil
+				changed = true
+			}
+			continue
+		}
+
+		for ; next <= end; next++ {
+			if _, ok := used[next]; !ok {
+				break
+			}
+		}
+		if next > end {
+			return changed, fmt.Errorf("no free AS number left in range %d-%d", start, end)
+		}
+		allocations[node.Name] = &ASNAllocation{ASN: next}
+		used[next] = node.Name
+		changed = true
+	}
+	return changed, nil
+}
+
+// Assign assigns the AS numbers to the nodes through the ledger and annotates the nodes with them.
+// The annotations of the given node objects are updated as well.
+func (l *ASNLedger) Assign(nodes []*v1.Node, start, end int, reclaimAfter time.Duration) error {
+	var allocations map[string]*ASNAllocation
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, ledger, err := l.load()
+		if err != nil {
+			return err
+		}
+		changed, err := AllocateASNs(ledger, nodes, l.Annotation, start, end, reclaimAfter, time.Now())
+		if err != nil {
+			return err
+		}
+		if changed {
+			if err := l.save(cm, ledger); err != nil {
+				return err
+			}
+		}
+		allocations = ledger
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		allocation, ok := allocations[node.Name]
+		if !ok {
+			continue
+		}
+		asn := strconv.Itoa(allocation.ASN)
+		anns := node.GetAnnotations()
+		if anns[l.Annotation] == asn {
+			continue
+		}
+
+		if err := l.patchAnnotation(node.Name, asn); err != nil {
+			return err
+		}
+		if anns == nil {
+			anns = make(map[string]string)
+		}
+		anns[l.Annotation] = asn
+		node.SetAnnotations(anns)
+		l.Logger.V(int(zapcore.WarnLevel)).Info("Node AS number is set", "node", node.Name, "asn", asn)
+	}
+	return nil
+}
+
+// Release removes the AS numbers of the range from the node annotations and deletes the ledger.
+func (l *ASNLedger) Release(nodes []*v1.Node, start, end int) error {
+	for _, node := range nodes {
+		value, ok := node.GetAnnotations()[l.Annotation]
+		if !ok {
+			continue
+		}
+		if asn, _ := strconv.Atoi(value); asn < start || asn > end {
+			continue
+		}
+		if err := l.patchAnnotation(node.Name, nil); err != nil {
+			return err
+		}
+	}
+	return l.Delete()
+}
+
+// Delete removes the ledger.
+func (l *ASNLedger) Delete() error {
+	ctx, cancel := context.WithTimeout(context.Background(), l.ContextTimeout)
+	defer cancel()
+
+	err := l.Clientset.CoreV1().ConfigMaps(l.Namespace).Delete(ctx, l.Name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// patchAnnotation sets the AS number annotation of the node, a nil value removes it.
+func (l *ASNLedger) patchAnnotation(node string, value interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), l.ContextTimeout)
+	defer cancel()
+
+	payload := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{l.Annotation: value},
+		},
+	}
+	payloadBytes, _ := json.Marshal(payload)
+	_, err := l.Clientset.CoreV1().Nodes().Patch(ctx, node, types.MergePatchType, payloadBytes, metav1.PatchOptions{})
+	return err
+}
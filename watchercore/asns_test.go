@@ -0,0 +1,249 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watchercore
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const testAnnotation = "k8s.netris.ai/test-asn"
+
+func asnNode(name, asn string) *v1.Node {
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if asn != "" {
+		node.Annotations = map[string]string{testAnnotation: asn}
+	}
+	return node
+}
+
+func ledgerASNs(allocations map[string]*ASNAllocation) map[string]int {
+	asns := make(map[string]int)
+	for name, allocation := range allocations {
+		asns[name] = allocation.ASN
+	}
+	return asns
+}
+
+func TestAllocateASNs(t *testing.T) {
+	now := time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)
+	released := func(asn int, ago time.Duration) *ASNAllocation {
+		return &ASNAllocation{ASN: asn, ReleasedAt: &metav1.Time{Time: now.Add(-ago)}}
+	}
+
+	tests := []struct {
+		name        string
+		allocations map[string]*ASNAllocation
+		nodes       []*v1.Node
+		start, end  int
+		want        map[string]int
+		wantChanged bool
+		wantErr     bool
+	}{
+		{
+			name:        "first free ASNs in node name order",
+			allocations: map[string]*ASNAllocation{"node2": {ASN: 100}},
+			nodes:       []*v1.Node{asnNode("node3", ""), asnNode("node1", ""), asnNode("node2", "")},
+			start:       100, end: 110,
+			want:        map[string]int{"node1": 101, "node2": 100, "node3": 102},
+			wantChanged: true,
+		},
+		{
+			name:        "last ASN of the range is handed out",
+			allocations: map[string]*ASNAllocation{"node1": {ASN: 100}, "node2": {ASN: 101}},
+			nodes:       []*v1.Node{asnNode("node1", ""), asnNode("node2", ""), asnNode("node3", "")},
+			start:       100, end: 102,
+			want:        map[string]int{"node1": 100, "node2": 101, "node3": 102},
+			wantChanged: true,
+		},
+		{
+			name:        "range exhausted",
+			allocations: map[string]*ASNAllocation{"node1": {ASN: 100}, "node2": {ASN: 101}},
+			nodes:       []*v1.Node{asnNode("node1", ""), asnNode("node2", ""), asnNode("node3", "")},
+			start:       100, end: 101,
+			wantErr: true,
+		},
+		{
+			name:        "gaps of a nearly full range are filled",
+			allocations: map[string]*ASNAllocation{"node1": {ASN: 100}, "node3": {ASN: 102}, "node5": {ASN: 104}},
+			nodes:       []*v1.Node{asnNode("node1", ""), asnNode("node2", ""), asnNode("node3", ""), asnNode("node4", ""), asnNode("node5", "")},
+			start:       100, end: 104,
+			want:        map[string]int{"node1": 100, "node2": 101, "node3": 102, "node4": 103, "node5": 104},
+			wantChanged: true,
+		},
+		{
+			name:        "nearly full range exhausted",
+			allocations: map[string]*ASNAllocation{"node1": {ASN: 100}, "node3": {ASN: 102}, "node5": {ASN: 104}},
+			nodes:       []*v1.Node{asnNode("node1", ""), asnNode("node2", ""), asnNode("node3", ""), asnNode("node4", ""), asnNode("node5", ""), asnNode("node6", "")},
+			start:       100, end: 104,
+			wantErr: true,
+		},
+		{
+			name:        "annotation in range is adopted",
+			allocations: map[string]*ASNAllocation{},
+			nodes:       []*v1.Node{asnNode("node1", ""), asnNode("node2", "105")},
+			start:       100, end: 110,
+			want:        map[string]int{"node1": 100, "node2": 105},
+			wantChanged: true,
+		},
+		{
+			name:        "annotation out of range is replaced",
+			allocations: map[string]*ASNAllocation{},
+			nodes:       []*v1.Node{asnNode("node1", "65000"), asnNode("node2", "111")},
+			start:       100, end: 110,
+			want:        map[string]int{"node1": 100, "node2": 101},
+			wantChanged: true,
+		},
+		{
+			name:        "annotation held by another node is replaced",
+			allocations: map[string]*ASNAllocation{"node1": {ASN: 100}},
+			nodes:       []*v1.Node{asnNode("node1", "100"), asnNode("node2", "100")},
+			start:       100, end: 110,
+			want:        map[string]int{"node1": 100, "node2": 101},
+			wantChanged: true,
+		},
+		{
+			name:        "removed node is released",
+			allocations: map[string]*ASNAllocation{"node1": {ASN: 100}, "node2": {ASN: 101}},
+			nodes:       []*v1.Node{asnNode("node1", "100")},
+			start:       100, end: 110,
+			want:        map[string]int{"node1": 100, "node2": 101},
+			wantChanged: true,
+		},
+		{
+			name:        "released ASN is kept during the grace period",
+			allocations: map[string]*ASNAllocation{"node1": {ASN: 100}, "node2": released(101, time.Hour)},
+			nodes:       []*v1.Node{asnNode("node1", "100"), asnNode("node3", "")},
+			start:       100, end: 110,
+			want:        map[string]int{"node1": 100, "node2": 101, "node3": 102},
+			wantChanged: true,
+		},
+		{
+			name:        "re-created node gets its released ASN back",
+			allocations: map[string]*ASNAllocation{"node1": {ASN: 100}, "node2": released(101, time.Hour)},
+			nodes:       []*v1.Node{asnNode("node1", "100"), asnNode("node2", "")},
+			start:       100, end: 110,
+			want:        map[string]int{"node1": 100, "node2": 101},
+			wantChanged: true,
+		},
+		{
+			name:        "released ASN is reclaimed after the grace period",
+			allocations: map[string]*ASNAllocation{"node1": {ASN: 100}, "node2": released(101, 25*time.Hour)},
+			nodes:       []*v1.Node{asnNode("node1", "100")},
+			start:       100, end: 110,
+			want:        map[string]int{"node1": 100},
+			wantChanged: true,
+		},
+		{
+			name:        "reclaimed ASN is handed out again",
+			allocations: map[string]*ASNAllocation{"node1": {ASN: 100}, "node2": released(101, 25*time.Hour)},
+			nodes:       []*v1.Node{asnNode("node1", "100"), asnNode("node3", "")},
+			start:       100, end: 101,
+			want:        map[string]int{"node1": 100, "node3": 101},
+			wantChanged: true,
+		},
+		{
+			name:        "nothing changed",
+			allocations: map[string]*ASNAllocation{"node1": {ASN: 100}},
+			nodes:       []*v1.Node{asnNode("node1", "100")},
+			start:       100, end: 110,
+			want: map[string]int{"node1": 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed, err := AllocateASNs(tt.allocations, tt.nodes, testAnnotation, tt.start, tt.end, DefaultASNReclaimAfter, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("AllocateASNs() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if got := ledgerASNs(tt.allocations); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ASNs = %v, want %v", got, tt.want)
+			}
+			for _, node := range tt.nodes {
+				if allocation := tt.allocations[node.Name]; allocation != nil && allocation.ReleasedAt != nil {
+					t.Errorf("live node %s is released", node.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestASNLedger(t *testing.T) {
+	clientset := kubefake.NewSimpleClientset(asnNode("node1", ""), asnNode("node2", ""))
+	newLedger := func() *ASNLedger {
+		return &ASNLedger{
+			Clientset:      clientset,
+			Logger:         ctrl.Log,
+			Name:           "test-asns",
+			Namespace:      "default",
+			Annotation:     testAnnotation,
+			ContextTimeout: time.Second,
+		}
+	}
+	nodeASN := func(name string) string {
+		node, err := clientset.CoreV1().Nodes().Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return node.GetAnnotations()[testAnnotation]
+	}
+
+	// Two replicas with their own view of the nodes share the ledger, so they never hand out the same ASN.
+	node1 := asnNode("node1", "")
+	if err := newLedger().Assign([]*v1.Node{node1}, 100, 110, DefaultASNReclaimAfter); err != nil {
+		t.Fatal(err)
+	}
+	node2 := asnNode("node2", "")
+	if err := newLedger().Assign([]*v1.Node{node1.DeepCopy(), node2}, 100, 110, DefaultASNReclaimAfter); err != nil {
+		t.Fatal(err)
+	}
+	if got := node1.GetAnnotations()[testAnnotation]; got != "100" {
+		t.Errorf("node1 object ASN = %s, want 100", got)
+	}
+	if got, want := []string{nodeASN("node1"), nodeASN("node2")}, []string{"100", "101"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("node ASNs = %v, want %v", got, want)
+	}
+
+	// Releasing removes the annotations and the ledger.
+	if err := newLedger().Release([]*v1.Node{node1, node2}, 100, 110); err != nil {
+		t.Fatal(err)
+	}
+	if got := nodeASN("node1") + nodeASN("node2"); got != "" {
+		t.Errorf("node ASNs after release = %s, want none", got)
+	}
+	if _, err := clientset.CoreV1().ConfigMaps("default").Get(context.Background(), "test-asns", metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Errorf("ledger after release: %v, want not found", err)
+	}
+}
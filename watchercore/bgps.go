@@ -0,0 +1,198 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watchercore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/r3labs/diff/v2"
+	"go.uber.org/zap/zapcore"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// bgpNamespace is the namespace of the generated BGPs.
+const bgpNamespace = "default"
+
+// GenerateBGP generates the BGP session of the node address with its VNet gateway,
+// marked with the annotation of the watcher it belongs to.
+func GenerateBGP(name string, asn int, gw *Gateway, inbound, outbound []string, annotation string) *v1alpha1.BGP {
+	nameReg, _ := regexp.Compile("[^a-z0-9.]+")
+
+	bgp := &v1alpha1.BGP{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      strings.Trim(nameReg.ReplaceAllString(name, "-"), "-"),
+			Namespace: bgpNamespace,
+		},
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "BGP",
+			APIVersion: "k8s.netris.ai/v1alpha1",
+		},
+		Spec: v1alpha1.BGPSpec{
+			Site:       gw.Site,
+			NeighborAS: asn,
+			Transport: v1alpha1.BGPTransport{
+				Type: "vnet",
+				Name: gw.VNet,
+			},
+			LocalIP:            gw.Prefix,
+			RemoteIP:           gw.NodePrefix(),
+			PrefixListInbound:  inbound,
+			PrefixListOutbound: outbound,
+		},
+	}
+	anns := make(map[string]string)
+	anns[annotation] = "true"
+	anns["resource.k8s.netris.ai/import"] = "true"
+	bgp.SetAnnotations(anns)
+	return bgp
+}
+
+// BGPs manages the BGPs of a watcher, the ones it owns carry its annotation.
+type BGPs struct {
+	Client         client.Client
+	Logger         logr.Logger
+	Annotation     string
+	ContextTimeout time.Duration
+}
+
+// List returns the BGPs owned by the watcher.
+func (b *BGPs) List() ([]*v1alpha1.BGP, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.ContextTimeout)
+	defer cancel()
+	bgps := &v1alpha1.BGPList{}
+	if err := b.Client.List(ctx, bgps, &client.ListOptions{}); err != nil {
+		return nil, err
+	}
+
+	owned := []*v1alpha1.BGP{}
+	for _, bgp := range bgps.Items {
+		if ann, ok := bgp.GetAnnotations()[b.Annotation]; ok && ann == "true" {
+			owned = append(owned, bgp.DeepCopy())
+		}
+	}
+	return owned, nil
+}
+
+// Sync brings the BGPs owned by the watcher in line with the generated ones.
+func (b *BGPs) Sync(generated []*v1alpha1.BGP) error {
+	debugLogger := b.Logger.V(int(zapcore.WarnLevel))
+
+	debugLogger.Info("Getting BGP list from k8s")
+	bgpList, err := b.List()
+	if err != nil {
+		return err
+	}
+
+	bgpsForCreate, bgpsForDelete, bgpsForUpdate := CompareBGPs(generated, bgpList)
+
+	js, _ := json.Marshal(bgpsForCreate)
+	debugLogger.Info("BGPs for create", "List", string(js))
+	js, _ = json.Marshal(bgpsForDelete)
+	debugLogger.Info("BGPs for delete", "List", string(js))
+	js, _ = json.Marshal(bgpsForUpdate)
+	debugLogger.Info("BGPs for update", "List", string(js))
+
+	var errors []error
+	for _, bgp := range bgpsForDelete {
+		if err := b.delete(bgp); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	for _, bgp := range bgpsForUpdate {
+		if err := b.update(bgp); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	for _, bgp := range bgpsForCreate {
+		if err := b.create(bgp); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	if len(errors) > 0 {
+		return fmt.Errorf("{Sync} %v", errors)
+	}
+	return nil
+}
+
+func (b *BGPs) create(bgp *v1alpha1.BGP) error {
+	ctx, cancel := context.WithTimeout(context.Background(), b.ContextTimeout)
+	defer cancel()
+	return b.Client.Create(ctx, bgp.DeepCopyObject(), &client.CreateOptions{})
+}
+
+func (b *BGPs) update(bgp *v1alpha1.BGP) error {
+	ctx, cancel := context.WithTimeout(context.Background(), b.ContextTimeout)
+	defer cancel()
+	return b.Client.Update(ctx, bgp.DeepCopyObject(), &client.UpdateOptions{})
+}
+
+func (b *BGPs) delete(bgp *v1alpha1.BGP) error {
+	ctx, cancel := context.WithTimeout(context.Background(), b.ContextTimeout)
+	defer cancel()
+	return b.Client.Delete(ctx, bgp.DeepCopyObject(), &client.DeleteAllOfOptions{})
+}
+
+// CompareBGPs returns the generated BGPs missing from the existing ones, the existing BGPs
+// that aren't generated anymore, and the existing BGPs whose spec changed, set to the generated one.
+func CompareBGPs(generated, existing []*v1alpha1.BGP) ([]*v1alpha1.BGP, []*v1alpha1.BGP, []*v1alpha1.BGP) {
+	genBGPsMap := make(map[string]*v1alpha1.BGP)
+	BGPsMap := make(map[string]*v1alpha1.BGP)
+
+	bgpsForCreate := []*v1alpha1.BGP{}
+	bgpsForDelete := []*v1alpha1.BGP{}
+	bgpsForUpdate := []*v1alpha1.BGP{}
+
+	for _, bgp := range generated {
+		genBGPsMap[bgpKey(bgp)] = bgp
+	}
+
+	for _, bgp := range existing {
+		BGPsMap[bgpKey(bgp)] = bgp
+	}
+
+	for _, genBGP := range generated {
+		if bgp, ok := BGPsMap[bgpKey(genBGP)]; !ok {
+			bgpsForCreate = append(bgpsForCreate, genBGP)
+		} else {
+			changelog, _ := diff.Diff(bgp.Spec, genBGP.Spec)
+			if len(changelog) > 0 {
+				bgp.Spec = genBGP.Spec
+				bgpsForUpdate = append(bgpsForUpdate, bgp)
+			}
+		}
+	}
+
+	for _, bgp := range existing {
+		if _, ok := genBGPsMap[bgpKey(bgp)]; !ok {
+			bgpsForDelete = append(bgpsForDelete, bgp)
+		}
+	}
+
+	return bgpsForCreate, bgpsForDelete, bgpsForUpdate
+}
+
+func bgpKey(bgp *v1alpha1.BGP) string {
+	return fmt.Sprintf("%s/%s", bgp.Namespace, bgp.Name)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watchercore
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/netrisai/netris-operator/netrisstorage"
+	"github.com/netrisai/netriswebapi/v2/types/ipam"
+)
+
+// DefaultASNRange is the range the node AS numbers are assigned from unless configured otherwise.
+const DefaultASNRange = "4230000000-4239999999"
+
+// Gateway is the VNet gateway a node address peers with.
+type Gateway struct {
+	NodeIP  string
+	IPv6    bool
+	Site    string
+	SiteASN int
+	VNet    string
+	// Prefix is the gateway address with the VNet prefix length, e.g. "10.0.0.1/24".
+	Prefix string
+}
+
+// NodePrefix returns the node address with the VNet prefix length.
+func (gw *Gateway) NodePrefix() string {
+	_, ipNet, _ := net.ParseCIDR(gw.Prefix)
+	ones, _ := ipNet.Mask.Size()
+	return fmt.Sprintf("%s/%d", gw.NodeIP, ones)
+}
+
+// IP returns the gateway address without the prefix length.
+func (gw *Gateway) IP() string {
+	ip, _, _ := net.ParseCIDR(gw.Prefix)
+	return ip.String()
+}
+
+// FindGateway finds the site, the VNet and the VNet gateway of the node address.
+func FindGateway(nStorage *netrisstorage.Storage, ip net.IP) (*Gateway, error) {
+	sbnt, err := findIPAMByIP(ip.String(), nStorage.SubnetsStorage.GetAll())
+	if err != nil {
+		return nil, err
+	}
+
+	_, ipNet, err := net.ParseCIDR(sbnt.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	subnet := ipNet.String()
+
+	id := 0
+	if len(sbnt.Sites) > 0 {
+		id = sbnt.Sites[0].ID
+	}
+
+	st, ok := nStorage.SitesStorage.FindByID(id)
+	if !ok {
+		return nil, fmt.Errorf("couldn't find site for %s", ip.String())
+	}
+
+	vn, ok := nStorage.VNetStorage.FindByGateway(subnet)
+	if !ok || vn == nil || vn.ID == 0 {
+		return nil, fmt.Errorf("couldn't find vnet for %s", ip.String())
+	}
+
+	for _, gw := range vn.Gateways {
+		_, gwNet, err := net.ParseCIDR(gw.Prefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vnet gateway %s", gw.Prefix)
+		}
+		if gwNet.String() == subnet || gwNet.Contains(ip) {
+			return &Gateway{
+				NodeIP:  ip.String(),
+				IPv6:    ip.To4() == nil,
+				Site:    st.Name,
+				SiteASN: st.PublicAsn,
+				VNet:    vn.Name,
+				Prefix:  gw.Prefix,
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("couldn't find gateway of vnet %s for %s", vn.Name, ip.String())
+}
+
+func findIPAMByIP(ip string, subnets []*ipam.IPAM) (*ipam.IPAM, error) {
+	for _, subnet := range subnets {
+		ipAddr := net.ParseIP(ip)
+		_, ipNet, err := net.ParseCIDR(subnet.Prefix)
+		if err != nil {
+			return nil, err
+		}
+
+		if ipNet.Contains(ipAddr) {
+			if len(subnet.Children) > 0 {
+				ip, err := findIPAMByIP(ip, subnet.Children)
+				if ip != nil {
+					return ip, err
+				}
+			}
+			return subnet, nil
+		}
+	}
+
+	return nil, fmt.Errorf("there are no subnet for specified IP address %s", ip)
+}
+
+// IsIPv6CIDR reports whether the CIDR is an IPv6 one.
+func IsIPv6CIDR(cidr string) bool {
+	ip, _, err := net.ParseCIDR(cidr)
+	return err == nil && ip.To4() == nil
+}
+
+// ParseASNRange parses a "start-end" AS number range.
+func ParseASNRange(asns string) (int, int, error) {
+	s := strings.Split(asns, "-")
+	if len(s) != 2 {
+		return 0, 0, fmt.Errorf("invalid ASN range")
+	}
+	a, err := strconv.Atoi(s[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	b, err := strconv.Atoi(s[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	if !(a > 0 && a <= 4294967294) || !(b > 0 && b <= 4294967294) || !(a < b) {
+		return a, b, fmt.Errorf("invalid ASN range")
+	}
+	return a, b, nil
+}
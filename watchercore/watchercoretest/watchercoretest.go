@@ -0,0 +1,133 @@
+/*
+Copyright 2021. Netris, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package watchercoretest provides the Netris storage, node and BGP fixtures shared by
+// the tests of the CNI and MetalLB watchers.
+package watchercoretest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/netrisai/netris-operator/api/v1alpha1"
+	"github.com/netrisai/netris-operator/netrisstorage"
+	"github.com/netrisai/netriswebapi/v2/types/ipam"
+	"github.com/netrisai/netriswebapi/v2/types/site"
+	"github.com/netrisai/netriswebapi/v2/types/vnet"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	// Site is the name of the site of the test subnets.
+	Site = "site1"
+	// SiteASN is the public AS number of the site.
+	SiteASN = 65001
+	// VNet is the name of the VNet holding the gateways of the test subnets.
+	VNet = "vnet1"
+)
+
+// Storage returns a storage with the subnets assigned to the site and a VNet with a gateway
+// on the first address of each subnet.
+func Storage(t testing.TB, subnets ...string) *netrisstorage.Storage {
+	t.Helper()
+	nStorage := netrisstorage.NewStorage(nil)
+	nStorage.SitesStorage.Sites = []*site.Site{{ID: 1, Name: Site, PublicAsn: SiteASN}}
+	gateways := []vnet.VNetGateway{}
+	for _, subnet := range subnets {
+		_, ipNet, err := net.ParseCIDR(subnet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gw := append(net.IP{}, ipNet.IP...)
+		gw[len(gw)-1]++
+		ones, _ := ipNet.Mask.Size()
+		gateways = append(gateways, vnet.VNetGateway{Prefix: fmt.Sprintf("%s/%d", gw, ones)})
+		nStorage.SubnetsStorage.Subnets = append(nStorage.SubnetsStorage.Subnets, &ipam.IPAM{
+			Prefix: ipNet.String(),
+			Sites:  []ipam.IDName{{ID: 1, Name: Site}},
+		})
+	}
+	nStorage.VNetStorage.VNets = []*vnet.VNet{{ID: 1, Name: VNet, Gateways: gateways}}
+	return nStorage
+}
+
+// Node returns a node with the hostname label, the given labels and annotations and the
+// addresses as InternalIPs.
+func Node(name string, labels, annotations map[string]string, addresses ...string) *v1.Node {
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:        name,
+		Labels:      map[string]string{"kubernetes.io/hostname": name},
+		Annotations: annotations,
+	}}
+	for k, v := range labels {
+		node.Labels[k] = v
+	}
+	for _, address := range addresses {
+		node.Status.Addresses = append(node.Status.Addresses, v1.NodeAddress{Type: v1.NodeInternalIP, Address: address})
+	}
+	return node
+}
+
+// NewClient returns a fake client of the Netris resources holding the objects.
+func NewClient(t testing.TB, objs ...runtime.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return ctrlfake.NewFakeClientWithScheme(scheme, objs...)
+}
+
+// BGPs returns the BGPs stored by the client by name.
+func BGPs(t testing.TB, cl client.Client) map[string]*v1alpha1.BGP {
+	t.Helper()
+	bgps := &v1alpha1.BGPList{}
+	if err := cl.List(context.Background(), bgps); err != nil {
+		t.Fatal(err)
+	}
+	result := make(map[string]*v1alpha1.BGP)
+	for i := range bgps.Items {
+		result[bgps.Items[i].Name] = &bgps.Items[i]
+	}
+	return result
+}
+
+// Settle processes the queue until it stays empty for a while, the events of the informers are
+// delivered asynchronously. The items added with a delay are not waited for.
+func Settle(t testing.TB, queue workqueue.Interface, processNextItem func() bool) {
+	t.Helper()
+	idle := 0
+	for deadline := time.Now().Add(5 * time.Second); idle < 5; {
+		if time.Now().After(deadline) {
+			t.Fatal("queue didn't settle")
+		}
+		if queue.Len() == 0 {
+			idle++
+			time.Sleep(20 * time.Millisecond)
+			continue
+		}
+		idle = 0
+		processNextItem()
+	}
+}